@@ -0,0 +1,381 @@
+package main
+
+import (
+	gen "bookService/internal/delivery/protos/gen/go"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+type filterFlags struct {
+	author string
+	year   int
+	genre  string
+	from   int
+	to     int
+	prefix string
+	sort   string
+	desc   bool
+	lang   string
+	series string
+}
+
+var sortFields = map[string]gen.BookSortField{
+	"title":      gen.BookSortField_BOOK_SORT_FIELD_TITLE,
+	"author":     gen.BookSortField_BOOK_SORT_FIELD_AUTHOR,
+	"year":       gen.BookSortField_BOOK_SORT_FIELD_PUBLICATION_YEAR,
+	"created_at": gen.BookSortField_BOOK_SORT_FIELD_CREATED_AT,
+	"rating":     gen.BookSortField_BOOK_SORT_FIELD_RATING,
+}
+
+func (f *filterFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.author, "author", "", "filter by author, comma-separated for several")
+	fs.IntVar(&f.year, "year", 0, "filter by publication year")
+	fs.StringVar(&f.genre, "genre", "", "filter by genre, comma-separated for several")
+	fs.IntVar(&f.from, "from", 0, "published in or after this year")
+	fs.IntVar(&f.to, "to", 0, "published in or before this year")
+	fs.StringVar(&f.prefix, "prefix", "", "title prefix")
+	fs.StringVar(&f.sort, "sort", "", "sort by title|author|year|created_at|rating")
+	fs.BoolVar(&f.desc, "desc", false, "sort in descending order")
+	fs.StringVar(&f.lang, "language", "", "language tag; en also matches en-GB")
+	fs.StringVar(&f.series, "series", "", "series name")
+}
+
+// request builds a ListBooksRequest from the flags; GetUserBooks copies its
+// fields.
+func (f *filterFlags) request() (*gen.ListBooksRequest, error) {
+	req := &gen.ListBooksRequest{
+		Authors: splitList(f.author),
+		Genres:  splitList(f.genre),
+	}
+	if f.year != 0 {
+		y := int32(f.year)
+		req.PublicationYear = &y
+	}
+	if f.from != 0 {
+		y := int32(f.from)
+		req.PublicationYearFrom = &y
+	}
+	if f.to != 0 {
+		y := int32(f.to)
+		req.PublicationYearTo = &y
+	}
+	if f.prefix != "" {
+		req.TitlePrefix = &f.prefix
+	}
+	if f.lang != "" {
+		req.Language = &f.lang
+	}
+	if f.series != "" {
+		req.Series = &f.series
+	}
+	if f.sort != "" {
+		field, ok := sortFields[f.sort]
+		if !ok {
+			return nil, fmt.Errorf("unknown sort field %q", f.sort)
+		}
+		req.SortBy = field
+	}
+	if f.desc {
+		req.SortDirection = gen.SortDirection_SORT_DIRECTION_DESC
+	}
+	return req, nil
+}
+
+func splitList(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// metadataFlags are the extended bibliographic fields shared by add and
+// update.
+type metadataFlags struct {
+	publisher    string
+	language     string
+	pages        int
+	edition      string
+	description  string
+	subjects     string
+	series       string
+	seriesNumber int
+	isbn         string
+}
+
+func (m *metadataFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&m.publisher, "publisher", "", "publisher")
+	fs.StringVar(&m.language, "language", "", "BCP-47 language tag, e.g. en or pt-BR")
+	fs.IntVar(&m.pages, "pages", 0, "page count")
+	fs.StringVar(&m.edition, "edition", "", "edition")
+	fs.StringVar(&m.description, "description", "", "description")
+	fs.StringVar(&m.subjects, "subjects", "", "comma-separated subjects")
+	fs.StringVar(&m.series, "series", "", "series name")
+	fs.IntVar(&m.seriesNumber, "series-number", 0, "number of the book in its series")
+	fs.StringVar(&m.isbn, "isbn", "", "ISBN-10 or ISBN-13")
+}
+
+func (c *cli) add(args []string) error {
+	fs := flag.NewFlagSet("add", flag.ExitOnError)
+	title := fs.String("title", "", "book title")
+	author := fs.String("author", "", "book author")
+	year := fs.Int("year", 0, "publication year")
+	genre := fs.String("genre", "", "genre")
+	var m metadataFlags
+	m.register(fs)
+	_ = fs.Parse(args)
+
+	if *title == "" || *author == "" {
+		return errors.New("add: -title and -author are required")
+	}
+
+	req := &gen.AddBookRequest{Title: *title, Author: *author}
+	if *year != 0 {
+		y := int32(*year)
+		req.PublicationYear = &y
+	}
+	if *genre != "" {
+		req.Genre = genre
+	}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "publisher":
+			req.Publisher = &m.publisher
+		case "language":
+			req.Language = &m.language
+		case "pages":
+			n := int32(m.pages)
+			req.PageCount = &n
+		case "edition":
+			req.Edition = &m.edition
+		case "description":
+			req.Description = &m.description
+		case "subjects":
+			req.Subjects = splitList(m.subjects)
+		case "series":
+			req.SeriesName = &m.series
+		case "series-number":
+			n := int32(m.seriesNumber)
+			req.SeriesNumber = &n
+		case "isbn":
+			req.Isbn = &m.isbn
+		}
+	})
+
+	book, err := c.client.AddBook(c.ctx, req)
+	if err != nil {
+		return err
+	}
+	return c.out.books(book)
+}
+
+func (c *cli) get(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: get <book_id>")
+	}
+
+	book, err := c.client.GetBook(c.ctx, &gen.GetBookRequest{BookId: args[0]})
+	if err != nil {
+		return err
+	}
+	return c.out.books(book)
+}
+
+func (c *cli) update(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: update <book_id> [flags]")
+	}
+
+	fs := flag.NewFlagSet("update", flag.ExitOnError)
+	title := fs.String("title", "", "book title")
+	author := fs.String("author", "", "book author")
+	year := fs.Int("year", 0, "publication year")
+	genre := fs.String("genre", "", "genre")
+	var m metadataFlags
+	m.register(fs)
+	_ = fs.Parse(args[1:])
+
+	req := &gen.UpdateBookRequest{BookId: args[0]}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "title":
+			req.Title = title
+		case "author":
+			req.Author = author
+		case "year":
+			y := int32(*year)
+			req.PublicationYear = &y
+		case "genre":
+			req.Genre = genre
+		case "publisher":
+			req.Publisher = &m.publisher
+		case "language":
+			req.Language = &m.language
+		case "pages":
+			n := int32(m.pages)
+			req.PageCount = &n
+		case "edition":
+			req.Edition = &m.edition
+		case "description":
+			req.Description = &m.description
+		case "subjects":
+			req.Subjects = splitList(m.subjects)
+			req.SetSubjects = true
+		case "series":
+			req.SeriesName = &m.series
+		case "series-number":
+			n := int32(m.seriesNumber)
+			req.SeriesNumber = &n
+		case "isbn":
+			req.Isbn = &m.isbn
+		}
+	})
+
+	book, err := c.client.UpdateBook(c.ctx, req)
+	if err != nil {
+		return err
+	}
+	return c.out.books(book)
+}
+
+func (c *cli) delete(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: delete <book_id>")
+	}
+
+	resp, err := c.client.DeleteBook(c.ctx, &gen.DeleteBookRequest{BookId: args[0]})
+	if err != nil {
+		return err
+	}
+	return c.out.ids("deleted", resp.GetBookId())
+}
+
+func (c *cli) list(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	var f filterFlags
+	f.register(fs)
+	_ = fs.Parse(args)
+
+	req, err := f.request()
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.ListBooks(c.ctx, req)
+	if err != nil {
+		return err
+	}
+	return c.out.books(resp.GetBooks()...)
+}
+
+func (c *cli) shelf(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: shelf add|add-series|remove|list")
+	}
+	if c.userID == "" {
+		return errors.New("shelf: -user or BOOKCTL_USER_ID is required")
+	}
+
+	switch args[0] {
+	case "add":
+		if len(args) != 2 {
+			return errors.New("usage: shelf add <book_id>")
+		}
+		resp, err := c.client.AddBookToUser(c.ctx, &gen.UserBookRequest{UserId: c.userID, BookId: args[1]})
+		if err != nil {
+			return err
+		}
+		return c.out.ids("shelved", resp.GetBookId())
+	case "add-series":
+		if len(args) != 2 {
+			return errors.New("usage: shelf add-series <series_id>")
+		}
+		resp, err := c.client.AddSeriesToUser(c.ctx, &gen.AddSeriesToUserRequest{UserId: c.userID, SeriesId: args[1]})
+		if err != nil {
+			return err
+		}
+		return c.out.ids("shelved", resp.GetBookIds()...)
+	case "remove":
+		if len(args) != 2 {
+			return errors.New("usage: shelf remove <book_id>")
+		}
+		resp, err := c.client.RemoveBookFromUser(c.ctx, &gen.UserBookRequest{UserId: c.userID, BookId: args[1]})
+		if err != nil {
+			return err
+		}
+		return c.out.ids("removed", resp.GetBookId())
+	case "list":
+		fs := flag.NewFlagSet("shelf list", flag.ExitOnError)
+		var f filterFlags
+		f.register(fs)
+		group := fs.Bool("group", false, "also show progress through each series")
+		shelfID := fs.String("shelf", "", "list the books on this custom shelf")
+		_ = fs.Parse(args[1:])
+
+		lr, err := f.request()
+		if err != nil {
+			return err
+		}
+		req := &gen.GetUserBooksRequest{
+			UserId:              c.userID,
+			PublicationYear:     lr.PublicationYear,
+			Authors:             lr.Authors,
+			Genres:              lr.Genres,
+			PublicationYearFrom: lr.PublicationYearFrom,
+			PublicationYearTo:   lr.PublicationYearTo,
+			TitlePrefix:         lr.TitlePrefix,
+			SortBy:              lr.SortBy,
+			SortDirection:       lr.SortDirection,
+			Language:            lr.Language,
+			Series:              lr.Series,
+			GroupBySeries:       *group,
+		}
+		if *shelfID != "" {
+			req.ShelfId = shelfID
+		}
+
+		resp, err := c.client.GetUserBooks(c.ctx, req)
+		if err != nil {
+			return err
+		}
+		if err := c.out.books(resp.GetBooks()...); err != nil {
+			return err
+		}
+		if len(resp.GetSeriesProgress()) == 0 {
+			return nil
+		}
+		return c.out.progress(resp.GetSeriesProgress()...)
+	default:
+		return fmt.Errorf("unknown shelf command %q", args[0])
+	}
+}
+
+func (c *cli) series(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: series list | get <series_id>")
+	}
+
+	switch args[0] {
+	case "list":
+		resp, err := c.client.ListSeries(c.ctx, &gen.ListSeriesRequest{})
+		if err != nil {
+			return err
+		}
+		return c.out.series(resp.GetSeries()...)
+	case "get":
+		if len(args) != 2 {
+			return errors.New("usage: series get <series_id>")
+		}
+		series, err := c.client.GetSeries(c.ctx, &gen.GetSeriesRequest{SeriesId: args[1]})
+		if err != nil {
+			return err
+		}
+		return c.out.series(series)
+	default:
+		return fmt.Errorf("unknown series command %q", args[0])
+	}
+}
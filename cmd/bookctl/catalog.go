@@ -0,0 +1,167 @@
+package main
+
+import (
+	gen "bookService/internal/delivery/protos/gen/go"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+var catalogFormats = map[string]gen.CatalogFormat{
+	"marc21":     gen.CatalogFormat_CATALOG_FORMAT_MARC21,
+	"marc":       gen.CatalogFormat_CATALOG_FORMAT_MARC21,
+	"marcxml":    gen.CatalogFormat_CATALOG_FORMAT_MARCXML,
+	"dc":         gen.CatalogFormat_CATALOG_FORMAT_DUBLIN_CORE,
+	"dublincore": gen.CatalogFormat_CATALOG_FORMAT_DUBLIN_CORE,
+	"csv":        gen.CatalogFormat_CATALOG_FORMAT_CSV,
+	"jsonl":      gen.CatalogFormat_CATALOG_FORMAT_JSONL,
+}
+
+const catalogUsage = "usage: catalog import -format marc21|marcxml|dc|csv|jsonl [-dry-run] [-update] [-map H=field,...] [-skip N] <file> | export -format marc21|marcxml|dc [-out file] [filter flags]"
+
+func (c *cli) catalog(args []string) error {
+	if len(args) == 0 {
+		return errors.New(catalogUsage)
+	}
+
+	switch args[0] {
+	case "import":
+		fs := flag.NewFlagSet("catalog import", flag.ExitOnError)
+		format := fs.String("format", "", "marc21, marcxml, dc, csv or jsonl")
+		dryRun := fs.Bool("dry-run", false, "report what would happen without writing")
+		update := fs.Bool("update", false, "update duplicates instead of skipping them")
+		columns := fs.String("map", "", "column mapping as Header=field[,Header=field]")
+		skip := fs.Int("skip", 0, "records to pass over, to resume an interrupted import")
+		_ = fs.Parse(args[1:])
+		if fs.NArg() != 1 {
+			return errors.New(catalogUsage)
+		}
+		f, ok := catalogFormats[*format]
+		if !ok {
+			return fmt.Errorf("unknown catalog format %q", *format)
+		}
+		options := &gen.ImportOptions{Format: f, DryRun: *dryRun, SkipRecords: int32(*skip)}
+		if *update {
+			options.OnDuplicate = gen.DuplicatePolicy_DUPLICATE_POLICY_UPDATE
+		}
+		if *columns != "" {
+			options.Columns = make(map[string]string)
+			for _, pair := range strings.Split(*columns, ",") {
+				column, field, ok := strings.Cut(pair, "=")
+				if !ok {
+					return fmt.Errorf("bad -map entry %q", pair)
+				}
+				options.Columns[strings.TrimSpace(column)] = strings.TrimSpace(field)
+			}
+		}
+		return c.importCatalog(options, fs.Arg(0))
+	case "export":
+		fs := flag.NewFlagSet("catalog export", flag.ExitOnError)
+		format := fs.String("format", "", "marc21, marcxml or dc")
+		out := fs.String("out", "", "output file, stdout when empty")
+		var filter filterFlags
+		filter.register(fs)
+		_ = fs.Parse(args[1:])
+		f, ok := catalogFormats[*format]
+		if !ok {
+			return fmt.Errorf("unknown catalog format %q", *format)
+		}
+		req, err := filter.request()
+		if err != nil {
+			return err
+		}
+		return c.exportCatalog(&gen.ExportCatalogRequest{Format: f, Filter: req}, *out)
+	default:
+		return fmt.Errorf("unknown catalog command %q", args[0])
+	}
+}
+
+func (c *cli) importCatalog(options *gen.ImportOptions, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	stream, err := c.catalogClient.ImportCatalog(c.ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&gen.ImportCatalogRequest{Data: &gen.ImportCatalogRequest_Options{Options: options}}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, coverChunkSize)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			chunk := &gen.ImportCatalogRequest{Data: &gen.ImportCatalogRequest_Chunk{Chunk: buf[:n]}}
+			if err := stream.Send(chunk); err != nil {
+				// The server ended the stream; its status comes from CloseAndRecv.
+				break
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	report, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+
+	res := importResult{
+		DryRun:  report.GetDryRun(),
+		Added:   int(report.GetCreated()),
+		Updated: int(report.GetUpdated()),
+		Skipped: int(report.GetSkipped()),
+		Failed:  int(report.GetFailed()),
+	}
+	for _, r := range report.GetRecords() {
+		res.Records = append(res.Records, importLine{
+			Line:   int(r.GetIndex()),
+			Status: r.GetStatus(),
+			BookID: r.GetBookId(),
+			Title:  r.GetTitle(),
+			Error:  r.GetError(),
+		})
+	}
+	return c.out.importResult(res)
+}
+
+func (c *cli) exportCatalog(req *gen.ExportCatalogRequest, path string) error {
+	stream, err := c.catalogClient.ExportCatalog(c.ctx, req)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(chunk.GetData()); err != nil {
+			return err
+		}
+	}
+}
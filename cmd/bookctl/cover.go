@@ -0,0 +1,107 @@
+package main
+
+import (
+	gen "bookService/internal/delivery/protos/gen/go"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+const coverChunkSize = 64 << 10
+
+func (c *cli) cover(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: cover upload <book_id> <file> | get <book_id> [-thumbnail] [-out file]")
+	}
+
+	switch args[0] {
+	case "upload":
+		if len(args) != 3 {
+			return errors.New("usage: cover upload <book_id> <file>")
+		}
+		return c.uploadCover(args[1], args[2])
+	case "get":
+		fs := flag.NewFlagSet("cover get", flag.ExitOnError)
+		thumbnail := fs.Bool("thumbnail", false, "fetch the thumbnail instead of the full image")
+		out := fs.String("out", "", "output file, stdout when empty")
+		_ = fs.Parse(args[1:])
+		if fs.NArg() != 1 {
+			return errors.New("usage: cover get <book_id> [-thumbnail] [-out file]")
+		}
+		return c.getCover(fs.Arg(0), *thumbnail, *out)
+	default:
+		return fmt.Errorf("unknown cover command %q", args[0])
+	}
+}
+
+func (c *cli) uploadCover(bookID, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	stream, err := c.client.UploadCover(c.ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&gen.UploadCoverRequest{Data: &gen.UploadCoverRequest_BookId{BookId: bookID}}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, coverChunkSize)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			chunk := &gen.UploadCoverRequest{Data: &gen.UploadCoverRequest_Chunk{Chunk: buf[:n]}}
+			if err := stream.Send(chunk); err != nil {
+				// The server ended the stream; its status comes from CloseAndRecv.
+				break
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	return c.out.ids("cover uploaded", resp.GetBookId())
+}
+
+func (c *cli) getCover(bookID string, thumbnail bool, path string) error {
+	stream, err := c.client.GetCover(c.ctx, &gen.GetCoverRequest{BookId: bookID, Thumbnail: thumbnail})
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(chunk.GetData()); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	gen "bookService/internal/delivery/protos/gen/go"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type importRecord struct {
+	Title           string `json:"title"`
+	Author          string `json:"author"`
+	PublicationYear int32  `json:"publication_year,omitempty"`
+	Genre           string `json:"genre,omitempty"`
+}
+
+type importLine struct {
+	Line   int    `json:"line"`
+	Status string `json:"status"`
+	BookID string `json:"book_id,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type importResult struct {
+	DryRun  bool         `json:"dry_run,omitempty"`
+	Added   int          `json:"added"`
+	Updated int          `json:"updated"`
+	Skipped int          `json:"skipped"`
+	Failed  int          `json:"failed"`
+	Records []importLine `json:"records"`
+}
+
+// importBooks reads a CSV file with a title,author,publication_year,genre
+// header or a JSON array of objects and calls AddBook for each record.
+func (c *cli) importBooks(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	format := fs.String("format", "", "csv or json (detected from extension when empty)")
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: import [-format csv|json] <file>")
+	}
+	path := fs.Arg(0)
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var records []importRecord
+	switch *format {
+	case "csv":
+		records, err = readCSV(f)
+	case "json":
+		err = json.NewDecoder(f).Decode(&records)
+	default:
+		return fmt.Errorf("unknown import format %q", *format)
+	}
+	if err != nil {
+		return fmt.Errorf("import: %w", err)
+	}
+
+	var res importResult
+	for i, rec := range records {
+		line := importLine{Line: i + 1}
+		book, err := c.client.AddBook(c.ctx, toAddRequest(rec))
+		if err != nil {
+			line.Status = "failed"
+			line.Error = err.Error()
+			res.Failed++
+		} else {
+			line.Status = "added"
+			line.BookID = book.GetBookId()
+			res.Added++
+		}
+		res.Records = append(res.Records, line)
+	}
+
+	return c.out.importResult(res)
+}
+
+func readCSV(r io.Reader) ([]importRecord, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"title", "author"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing %q column", required)
+		}
+	}
+
+	field := func(row []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	var records []importRecord
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		rec := importRecord{
+			Title:  field(row, "title"),
+			Author: field(row, "author"),
+			Genre:  field(row, "genre"),
+		}
+		if y := field(row, "publication_year"); y != "" {
+			year, err := strconv.ParseInt(y, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid publication_year %q", len(records)+2, y)
+			}
+			rec.PublicationYear = int32(year)
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+func toAddRequest(rec importRecord) *gen.AddBookRequest {
+	req := &gen.AddBookRequest{Title: rec.Title, Author: rec.Author}
+	if rec.PublicationYear != 0 {
+		req.PublicationYear = &rec.PublicationYear
+	}
+	if rec.Genre != "" {
+		req.Genre = &rec.Genre
+	}
+	return req
+}
@@ -0,0 +1,183 @@
+// Command bookctl is a command-line client for the BookService gRPC API.
+package main
+
+import (
+	gen "bookService/internal/delivery/protos/gen/go"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"os"
+	"time"
+)
+
+const usage = `Usage: bookctl [global flags] <command> [flags] [args]
+
+Commands:
+  add      -title T -author A [-year Y] [-genre G] [metadata flags]
+  get      <book_id>
+  update   <book_id> [-title T] [-author A] [-year Y] [-genre G] [metadata flags]
+  delete   <book_id>
+  list     [filter flags]
+  shelf    add <book_id> | add-series <series_id> | remove <book_id> | list [-group] [-shelf id] [filter flags]
+  shelves  list | create <name> [-visibility v] | rename <id> <name> | visibility <id> private|link|public
+           delete <id> | add <id> <book_id> | remove <id> <book_id> | reorder <id> <book_id>...
+           get <id> [-token T]
+  series   list | get <series_id>
+  recommend similar <book_id> [-limit N] | user [-limit N]
+  import   [-format csv|json] <file>
+  cover    upload <book_id> <file> | get <book_id> [-thumbnail] [-out file]
+  catalog  import -format marc21|marcxml|dc|csv|jsonl [-dry-run] [-update] [-map H=field,...] [-skip N] <file>
+           export -format marc21|marcxml|dc [-out file] [filter flags]
+
+Filter flags:
+  -author A[,B]  -genre G[,H]  -year Y  -from Y  -to Y  -prefix P
+  -sort title|author|year|created_at|rating  -desc  -language L  -series S
+
+Metadata flags:
+  -publisher P  -language L  -pages N  -edition E  -description D
+  -subjects S[,T]  -series S  -series-number N  -isbn I
+
+Global flags:
+`
+
+type cli struct {
+	client               gen.BookServiceClient
+	catalogClient        gen.CatalogServiceClient
+	recommendationClient gen.RecommendationServiceClient
+	out                  printer
+	userID               string
+	ctx                  context.Context
+}
+
+func main() {
+	addr := flag.String("addr", envOr("BOOKCTL_ADDR", "localhost:44044"), "gRPC server address (BOOKCTL_ADDR)")
+	role := flag.String("role", os.Getenv("BOOKCTL_ROLE"), "value sent as x-user-role (BOOKCTL_ROLE)")
+	userID := flag.String("user", os.Getenv("BOOKCTL_USER_ID"), "user id for shelf commands (BOOKCTL_USER_ID)")
+	output := flag.String("o", envOr("BOOKCTL_OUTPUT", "table"), "output format: table or json (BOOKCTL_OUTPUT)")
+	timeout := flag.Duration("timeout", 10*time.Second, "per-command timeout")
+	useTLS := flag.Bool("tls", os.Getenv("BOOKCTL_TLS") != "", "connect with TLS (BOOKCTL_TLS)")
+	caFile := flag.String("ca", os.Getenv("BOOKCTL_CA"), "CA bundle used to verify the server (BOOKCTL_CA)")
+	certFile := flag.String("cert", os.Getenv("BOOKCTL_CERT"), "client certificate for mTLS (BOOKCTL_CERT)")
+	keyFile := flag.String("key", os.Getenv("BOOKCTL_KEY"), "client key for mTLS (BOOKCTL_KEY)")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	out, err := newPrinter(*output, os.Stdout)
+	if err != nil {
+		fatal(err)
+	}
+
+	creds := insecure.NewCredentials()
+	if *useTLS {
+		creds, err = tlsCredentials(*caFile, *certFile, *keyFile)
+		if err != nil {
+			fatal(err)
+		}
+	}
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	if *role != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-user-role", *role)
+	}
+
+	c := &cli{
+		client:               gen.NewBookServiceClient(conn),
+		catalogClient:        gen.NewCatalogServiceClient(conn),
+		recommendationClient: gen.NewRecommendationServiceClient(conn),
+		out:                  out,
+		userID:               *userID,
+		ctx:                  ctx,
+	}
+
+	if err := c.run(flag.Arg(0), flag.Args()[1:]); err != nil {
+		fatal(err)
+	}
+}
+
+func (c *cli) run(cmd string, args []string) error {
+	switch cmd {
+	case "add":
+		return c.add(args)
+	case "get":
+		return c.get(args)
+	case "update":
+		return c.update(args)
+	case "delete":
+		return c.delete(args)
+	case "list":
+		return c.list(args)
+	case "shelf":
+		return c.shelf(args)
+	case "shelves":
+		return c.shelves(args)
+	case "import":
+		return c.importBooks(args)
+	case "cover":
+		return c.cover(args)
+	case "series":
+		return c.series(args)
+	case "catalog":
+		return c.catalog(args)
+	case "recommend":
+		return c.recommend(args)
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+}
+
+func tlsCredentials(caFile, certFile, keyFile string) (credentials.TransportCredentials, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("ca file contains no certificates")
+		}
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "bookctl:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	gen "bookService/internal/delivery/protos/gen/go"
+	"encoding/json"
+	"fmt"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+type printer interface {
+	books(books ...*gen.Book) error
+	ids(action string, ids ...string) error
+	importResult(res importResult) error
+	series(series ...*gen.Series) error
+	progress(progress ...*gen.SeriesProgress) error
+	shelves(shelves ...*gen.Shelf) error
+	recommendations(recs ...*gen.Recommendation) error
+}
+
+func newPrinter(format string, w io.Writer) (printer, error) {
+	switch format {
+	case "table":
+		return tablePrinter{w: w}, nil
+	case "json":
+		return jsonPrinter{w: w}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+type tablePrinter struct {
+	w io.Writer
+}
+
+func (p tablePrinter) books(books ...*gen.Book) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTITLE\tAUTHOR\tYEAR\tGENRE")
+	for _, b := range books {
+		year := ""
+		if b.PublicationYear != nil && b.GetPublicationYear() != 0 {
+			year = strconv.Itoa(int(b.GetPublicationYear()))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", b.GetBookId(), b.GetTitle(), b.GetAuthor(), year, b.GetGenre())
+	}
+	return tw.Flush()
+}
+
+func (p tablePrinter) ids(action string, ids ...string) error {
+	for _, id := range ids {
+		if _, err := fmt.Fprintf(p.w, "%s %s\n", action, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p tablePrinter) importResult(res importResult) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "LINE\tSTATUS\tID\tTITLE\tERROR")
+	for _, r := range res.Records {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", r.Line, r.Status, r.BookID, r.Title, r.Error)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	summary := fmt.Sprintf("%d added, %d updated, %d skipped, %d failed", res.Added, res.Updated, res.Skipped, res.Failed)
+	if res.DryRun {
+		summary += " (dry run, nothing written)"
+	}
+	_, err := fmt.Fprintf(p.w, "\n%s\n", summary)
+	return err
+}
+
+func (p tablePrinter) series(series ...*gen.Series) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tBOOKS")
+	for _, s := range series {
+		fmt.Fprintf(tw, "%s\t%s\t%d\n", s.GetSeriesId(), s.GetName(), s.GetBookCount())
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	// A single series is printed with its volumes, as returned by GetSeries.
+	if len(series) == 1 && len(series[0].GetBooks()) > 0 {
+		fmt.Fprintln(p.w)
+		return p.books(series[0].GetBooks()...)
+	}
+	return nil
+}
+
+func (p tablePrinter) progress(progress ...*gen.SeriesProgress) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SERIES\tNAME\tPROGRESS")
+	for _, sp := range progress {
+		fmt.Fprintf(tw, "%s\t%s\t%d of %d\n", sp.GetSeriesId(), sp.GetName(), sp.GetOwned(), sp.GetTotal())
+	}
+	return tw.Flush()
+}
+
+func (p tablePrinter) shelves(shelves ...*gen.Shelf) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tVISIBILITY\tBOOKS\tSHARE TOKEN")
+	for _, s := range shelves {
+		visibility := strings.ToLower(strings.TrimPrefix(s.GetVisibility().String(), "SHELF_VISIBILITY_"))
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", s.GetShelfId(), s.GetName(), visibility, s.GetBookCount(), s.GetShareToken())
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	// A single shelf is printed with its books, as returned by GetShelf.
+	if len(shelves) == 1 && len(shelves[0].GetBooks()) > 0 {
+		fmt.Fprintln(p.w)
+		return p.books(shelves[0].GetBooks()...)
+	}
+	return nil
+}
+
+func (p tablePrinter) recommendations(recs ...*gen.Recommendation) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTITLE\tAUTHOR\tSCORE\tREASON")
+	for _, r := range recs {
+		reason := strings.ToLower(strings.TrimPrefix(r.GetReason().String(), "RECOMMENDATION_REASON_"))
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%.3f\t%s\n", r.GetBook().GetBookId(), r.GetBook().GetTitle(), r.GetBook().GetAuthor(), r.GetScore(), reason)
+	}
+	return tw.Flush()
+}
+
+type jsonPrinter struct {
+	w io.Writer
+}
+
+func (p jsonPrinter) books(books ...*gen.Book) error {
+	return encodeMessages(p, books)
+}
+
+func (p jsonPrinter) series(series ...*gen.Series) error {
+	return encodeMessages(p, series)
+}
+
+func (p jsonPrinter) progress(progress ...*gen.SeriesProgress) error {
+	return encodeMessages(p, progress)
+}
+
+func (p jsonPrinter) shelves(shelves ...*gen.Shelf) error {
+	return encodeMessages(p, shelves)
+}
+
+func (p jsonPrinter) recommendations(recs ...*gen.Recommendation) error {
+	return encodeMessages(p, recs)
+}
+
+// encodeMessages prints a single message as an object and several as an
+// array.
+func encodeMessages[M proto.Message](p jsonPrinter, msgs []M) error {
+	raw := make([]json.RawMessage, 0, len(msgs))
+	for _, m := range msgs {
+		data, err := protojson.Marshal(m)
+		if err != nil {
+			return err
+		}
+		raw = append(raw, data)
+	}
+	if len(msgs) == 1 {
+		return p.encode(raw[0])
+	}
+	return p.encode(raw)
+}
+
+func (p jsonPrinter) ids(action string, ids ...string) error {
+	return p.encode(map[string][]string{action: ids})
+}
+
+func (p jsonPrinter) importResult(res importResult) error {
+	return p.encode(res)
+}
+
+func (p jsonPrinter) encode(v any) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
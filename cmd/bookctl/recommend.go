@@ -0,0 +1,47 @@
+package main
+
+import (
+	gen "bookService/internal/delivery/protos/gen/go"
+	"errors"
+	"flag"
+	"fmt"
+)
+
+func (c *cli) recommend(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: recommend similar <book_id> [-limit N] | user [-limit N]")
+	}
+
+	fs := flag.NewFlagSet("recommend "+args[0], flag.ExitOnError)
+	limit := fs.Int("limit", 0, "number of books to return; 0 uses the server default")
+	_ = fs.Parse(args[1:])
+
+	var (
+		resp *gen.RecommendationsResponse
+		err  error
+	)
+	switch args[0] {
+	case "similar":
+		if fs.NArg() != 1 {
+			return errors.New("usage: recommend similar <book_id> [-limit N]")
+		}
+		resp, err = c.recommendationClient.GetSimilarBooks(c.ctx, &gen.GetSimilarBooksRequest{
+			BookId: fs.Arg(0),
+			Limit:  int32(*limit),
+		})
+	case "user":
+		if c.userID == "" {
+			return errors.New("recommend: -user or BOOKCTL_USER_ID is required")
+		}
+		resp, err = c.recommendationClient.GetRecommendationsForUser(c.ctx, &gen.GetRecommendationsForUserRequest{
+			UserId: c.userID,
+			Limit:  int32(*limit),
+		})
+	default:
+		return fmt.Errorf("unknown recommend command %q", args[0])
+	}
+	if err != nil {
+		return err
+	}
+	return c.out.recommendations(resp.GetRecommendations()...)
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	gen "bookService/internal/delivery/protos/gen/go"
+	"errors"
+	"flag"
+	"fmt"
+)
+
+var shelfVisibilities = map[string]gen.ShelfVisibility{
+	"private": gen.ShelfVisibility_SHELF_VISIBILITY_PRIVATE,
+	"link":    gen.ShelfVisibility_SHELF_VISIBILITY_LINK,
+	"public":  gen.ShelfVisibility_SHELF_VISIBILITY_PUBLIC,
+}
+
+func visibility(name string) (gen.ShelfVisibility, error) {
+	v, ok := shelfVisibilities[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown visibility %q, want private, link or public", name)
+	}
+	return v, nil
+}
+
+func (c *cli) shelves(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: shelves list|create|rename|visibility|delete|add|remove|reorder|get")
+	}
+	// Shared shelves are read without a user.
+	if args[0] == "get" {
+		fs := flag.NewFlagSet("shelves get", flag.ExitOnError)
+		token := fs.String("token", "", "share token of a link-shared shelf")
+		_ = fs.Parse(args[1:])
+		if fs.NArg() != 1 {
+			return errors.New("usage: shelves get <shelf_id> [-token T]")
+		}
+		req := &gen.GetShelfRequest{ShelfId: fs.Arg(0)}
+		if *token != "" {
+			req.ShareToken = token
+		}
+		shelf, err := c.client.GetShelf(c.ctx, req)
+		if err != nil {
+			return err
+		}
+		return c.out.shelves(shelf)
+	}
+	if c.userID == "" {
+		return errors.New("shelves: -user or BOOKCTL_USER_ID is required")
+	}
+
+	switch args[0] {
+	case "list":
+		resp, err := c.client.ListShelves(c.ctx, &gen.ListShelvesRequest{UserId: c.userID})
+		if err != nil {
+			return err
+		}
+		return c.out.shelves(resp.GetShelves()...)
+	case "create":
+		fs := flag.NewFlagSet("shelves create", flag.ExitOnError)
+		vis := fs.String("visibility", "private", "private, link or public")
+		_ = fs.Parse(args[1:])
+		if fs.NArg() != 1 {
+			return errors.New("usage: shelves create <name> [-visibility private|link|public]")
+		}
+		v, err := visibility(*vis)
+		if err != nil {
+			return err
+		}
+		shelf, err := c.client.CreateShelf(c.ctx, &gen.CreateShelfRequest{UserId: c.userID, Name: fs.Arg(0), Visibility: v})
+		if err != nil {
+			return err
+		}
+		return c.out.shelves(shelf)
+	case "rename":
+		if len(args) != 3 {
+			return errors.New("usage: shelves rename <shelf_id> <name>")
+		}
+		shelf, err := c.client.RenameShelf(c.ctx, &gen.RenameShelfRequest{UserId: c.userID, ShelfId: args[1], Name: args[2]})
+		if err != nil {
+			return err
+		}
+		return c.out.shelves(shelf)
+	case "visibility":
+		if len(args) != 3 {
+			return errors.New("usage: shelves visibility <shelf_id> private|link|public")
+		}
+		v, err := visibility(args[2])
+		if err != nil {
+			return err
+		}
+		shelf, err := c.client.SetShelfVisibility(c.ctx, &gen.SetShelfVisibilityRequest{UserId: c.userID, ShelfId: args[1], Visibility: v})
+		if err != nil {
+			return err
+		}
+		return c.out.shelves(shelf)
+	case "delete":
+		if len(args) != 2 {
+			return errors.New("usage: shelves delete <shelf_id>")
+		}
+		resp, err := c.client.DeleteShelf(c.ctx, &gen.DeleteShelfRequest{UserId: c.userID, ShelfId: args[1]})
+		if err != nil {
+			return err
+		}
+		return c.out.ids("deleted", resp.GetShelfId())
+	case "add", "remove":
+		if len(args) != 3 {
+			return fmt.Errorf("usage: shelves %s <shelf_id> <book_id>", args[0])
+		}
+		req := &gen.ShelfBookRequest{UserId: c.userID, ShelfId: args[1], BookId: args[2]}
+		call := c.client.AddBookToShelf
+		if args[0] == "remove" {
+			call = c.client.RemoveBookFromShelf
+		}
+		shelf, err := call(c.ctx, req)
+		if err != nil {
+			return err
+		}
+		return c.out.shelves(shelf)
+	case "reorder":
+		if len(args) < 2 {
+			return errors.New("usage: shelves reorder <shelf_id> <book_id>...")
+		}
+		shelf, err := c.client.ReorderShelf(c.ctx, &gen.ReorderShelfRequest{UserId: c.userID, ShelfId: args[1], BookIds: args[2:]})
+		if err != nil {
+			return err
+		}
+		return c.out.shelves(shelf)
+	default:
+		return fmt.Errorf("unknown shelves command %q", args[0])
+	}
+}
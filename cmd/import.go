@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bookService/config"
+	"bookService/internal/app"
+	"bookService/internal/catalog"
+	"bookService/internal/domain/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+const importUsage = `Usage: bookService [-config file] import [flags] <file>
+
+Imports books from a CSV, JSON Lines, MARC or Dublin Core file with the
+validation, duplicate detection and events of the ImportCatalog RPC.
+
+Flags:
+`
+
+// checkpointEvery is how many records an import reads between checkpoints.
+const checkpointEvery = 100
+
+var importFormats = map[string]string{
+	".csv":    catalog.FormatCSV,
+	".jsonl":  catalog.FormatJSONLines,
+	".ndjson": catalog.FormatJSONLines,
+	".mrc":    catalog.FormatMARC21,
+	".marc":   catalog.FormatMARC21,
+}
+
+// checkpoint records how far an import got, so -resume can carry on after
+// the records already handled.
+type checkpoint struct {
+	File      string `json:"file"`
+	Format    string `json:"format"`
+	Processed int    `json:"processed"`
+}
+
+func runImport(log *slog.Logger, cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	format := fs.String("format", "", "csv, jsonl, marc21, marcxml or dc; taken from the file extension when unset")
+	dryRun := fs.Bool("dry-run", false, "report what would be created, updated or skipped without writing")
+	update := fs.Bool("update", false, "update books that duplicate a record instead of skipping the record")
+	columns := fs.String("map", "", "column mapping as Header=field[,Header=field]")
+	resume := fs.Bool("resume", false, "carry on from the checkpoint of an interrupted import")
+	checkpointPath := fs.String("checkpoint", "", "checkpoint file (default <file>.progress)")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), importUsage)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("import: expected exactly one file")
+	}
+	path := fs.Arg(0)
+	if *checkpointPath == "" {
+		*checkpointPath = path + ".progress"
+	}
+
+	f, err := importFormat(*format, path)
+	if err != nil {
+		return err
+	}
+	opts := models.ImportOptions{DryRun: *dryRun, OnDuplicate: models.DuplicateSkip}
+	if *update {
+		opts.OnDuplicate = models.DuplicateUpdate
+	}
+	if opts.Columns, err = parseColumns(*columns); err != nil {
+		return err
+	}
+
+	state := checkpoint{File: path, Format: f}
+	if *resume {
+		saved, err := loadCheckpoint(*checkpointPath)
+		if err != nil {
+			return fmt.Errorf("import: resume: %w", err)
+		}
+		if saved.File != state.File || saved.Format != state.Format {
+			return fmt.Errorf("import: resume: checkpoint is for %s (%s)", saved.File, saved.Format)
+		}
+		opts.Skip = saved.Processed
+		log.Info("resuming import", slog.Int("processed", saved.Processed))
+	}
+
+	// Dry runs change nothing, so there is nothing to resume.
+	save := func(processed int) {
+		if opts.DryRun {
+			return
+		}
+		state.Processed = processed
+		if err := saveCheckpoint(*checkpointPath, state); err != nil {
+			log.Warn("failed to save checkpoint", slog.String("error", err.Error()))
+		}
+	}
+	opts.Progress = func(report *models.ImportReport) {
+		if report.Processed%checkpointEvery == 0 {
+			save(report.Processed)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("import: %w", err)
+	}
+	defer file.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	service := app.NewCatalogService(log, cfg)
+	report, err := service.Import(ctx, f, file, opts)
+	if report != nil {
+		printImportReport(report)
+	}
+	if err != nil {
+		if report != nil && report.Processed > opts.Skip {
+			save(report.Processed)
+			return fmt.Errorf("import: %w (run again with -resume to continue after record %d)", err, report.Processed)
+		}
+		return fmt.Errorf("import: %w", err)
+	}
+
+	if err := os.Remove(*checkpointPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Warn("failed to remove checkpoint", slog.String("error", err.Error()))
+	}
+	return nil
+}
+
+func importFormat(format, path string) (string, error) {
+	switch format {
+	case "":
+		if f, ok := importFormats[strings.ToLower(filepath.Ext(path))]; ok {
+			return f, nil
+		}
+		return "", fmt.Errorf("import: cannot tell the format of %s, set -format", path)
+	case "dc":
+		return catalog.FormatDublinCore, nil
+	case catalog.FormatCSV, catalog.FormatJSONLines, catalog.FormatMARC21, catalog.FormatMARCXML, catalog.FormatDublinCore:
+		return format, nil
+	}
+	return "", fmt.Errorf("import: unknown format %q", format)
+}
+
+// parseColumns reads "Book Title=title,Writer=author".
+func parseColumns(s string) (map[string]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	columns := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		column, field, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(column) == "" {
+			return nil, fmt.Errorf("import: bad -map entry %q", pair)
+		}
+		columns[strings.TrimSpace(column)] = strings.TrimSpace(field)
+	}
+	return columns, nil
+}
+
+func loadCheckpoint(path string) (checkpoint, error) {
+	var c checkpoint
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(data, &c)
+	return c, err
+}
+
+// saveCheckpoint replaces the checkpoint file in one rename, so an
+// interrupted write never leaves a torn one.
+func saveCheckpoint(path string, c checkpoint) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func printImportReport(report *models.ImportReport) {
+	for _, r := range report.Records {
+		if r.Status != models.ImportFailed {
+			continue
+		}
+		if r.Title != "" {
+			fmt.Printf("record %d (%s): %s\n", r.Index, r.Title, r.Error)
+		} else {
+			fmt.Printf("record %d: %s\n", r.Index, r.Error)
+		}
+	}
+	summary := fmt.Sprintf("%d created, %d updated, %d skipped, %d failed, %d records read",
+		report.Created, report.Updated, report.Skipped, report.Failed, report.Processed)
+	if report.DryRun {
+		summary += " (dry run, nothing written)"
+	}
+	fmt.Println(summary)
+}
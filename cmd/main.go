@@ -3,10 +3,14 @@ package main
 import (
 	"bookService/config"
 	"bookService/internal/app"
+	"bookService/internal/metrics"
+	"context"
+	"flag"
 	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
 const (
@@ -20,11 +24,36 @@ func main() {
 
 	log := setupLogger(cfg.Env)
 
+	if flag.Arg(0) == "import" {
+		if err := runImport(log, cfg, flag.Args()[1:]); err != nil {
+			log.Error("import failed", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		return
+	}
+
 	log.Info("Starting up")
 
+	metrics.Init()
+
 	application := app.New(log, cfg.GRPC.Port, cfg)
 
+	if application.Certs != nil {
+		application.Certs.Start()
+	}
+	if application.Cache != nil {
+		application.Cache.Start()
+	}
 	go application.GRPCSrv.MustRun()
+	if application.HTTPSrv != nil {
+		go application.HTTPSrv.MustRun()
+	}
+	if application.Webhooks != nil {
+		application.Webhooks.Start()
+	}
+	if application.Recommendations != nil {
+		application.Recommendations.Start()
+	}
 
 	stop := make(chan os.Signal, 1)
 
@@ -32,7 +61,28 @@ func main() {
 
 	<-stop
 
+	if application.HTTPSrv != nil {
+		application.HTTPSrv.Stop()
+	}
 	application.GRPCSrv.Stop()
+	if application.Webhooks != nil {
+		application.Webhooks.Stop()
+	}
+	if application.Recommendations != nil {
+		application.Recommendations.Stop()
+	}
+	if application.Cache != nil {
+		application.Cache.Stop()
+	}
+	if application.Certs != nil {
+		application.Certs.Stop()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := application.Tracing.Shutdown(ctx); err != nil {
+		log.Error("failed to flush traces", slog.String("error", err.Error()))
+	}
 
 	log.Info("Shutting down")
 }
@@ -1,21 +1,67 @@
 package config
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	"github.com/ilyakaznacheev/cleanenv"
 	"os"
 	"time"
 )
 
 type Config struct {
-	Env   string      `yaml:"env" env-default:"local"`
-	GRPC  GRPCConfig  `yaml:"grpc"`
-	DB    DBConfig    `yaml:"db"`
-	Cache RedisConfig `yaml:"redis_db"`
+	Env       string          `yaml:"env" env-default:"local"`
+	GRPC      GRPCConfig      `yaml:"grpc"`
+	HTTP      HTTPConfig      `yaml:"http"`
+	DB        DBConfig        `yaml:"db"`
+	Cache     RedisConfig     `yaml:"redis_db"`
+	Webhooks  WebhookConfig   `yaml:"webhooks"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Quotas    QuotaConfig     `yaml:"quotas"`
+	Covers    CoversConfig    `yaml:"covers"`
+	Catalog   CatalogConfig   `yaml:"catalog"`
+
+	Recommendations RecommendationsConfig `yaml:"recommendations"`
+	Tracing         TracingConfig         `yaml:"tracing"`
+	ErrorReporting  ErrorReportingConfig  `yaml:"error_reporting"`
 }
 type GRPCConfig struct {
-	Port    int           `yaml:"port"`
-	Timeout time.Duration `yaml:"timeout"`
+	Port       int             `yaml:"port"`
+	Timeout    time.Duration   `yaml:"timeout"`
+	Reflection bool            `yaml:"reflection" env-default:"false"`
+	TLS        TLSConfig       `yaml:"tls"`
+	AccessLog  AccessLogConfig `yaml:"access_log"`
+}
+
+// AccessLogConfig controls the line logged for every gRPC call.
+type AccessLogConfig struct {
+	Enabled bool `yaml:"enabled" env-default:"true"`
+	// Payloads adds the request message of unary calls to the line.
+	Payloads bool `yaml:"payloads"`
+	// Redact lists request fields, by their proto names, whose values are
+	// masked wherever they appear in a logged request.
+	Redact []string `yaml:"redact" env-default:"secret,share_token,token,password,api_key"`
+}
+type TLSConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	// ClientCAFile enables mutual TLS: client certificates signed by this CA
+	// are verified and mapped to roles through ClientRoles.
+	ClientCAFile      string            `yaml:"client_ca_file"`
+	RequireClientCert bool              `yaml:"require_client_cert"`
+	ClientRoles       map[string]string `yaml:"client_roles"`
+	// ReloadInterval is how often the files are checked for changes. It
+	// must not be negative.
+	ReloadInterval time.Duration `yaml:"reload_interval" env-default:"30s"`
+	// Client certificate used by the HTTP gateway when RequireClientCert is set.
+	GatewayCertFile string `yaml:"gateway_cert_file"`
+	GatewayKeyFile  string `yaml:"gateway_key_file"`
+}
+type HTTPConfig struct {
+	Enabled bool          `yaml:"enabled" env-default:"true"`
+	Port    int           `yaml:"port" env-default:"8080"`
+	Timeout time.Duration `yaml:"timeout" env-default:"10s"`
 }
 type DBConfig struct {
 	Host     string `yaml:"host"`
@@ -24,14 +70,174 @@ type DBConfig struct {
 	Password string `yaml:"password"`
 	DBName   string `yaml:"dbname"`
 	SSLMode  string `yaml:"sslmode"`
+	// Driver selects the book storage: "pq" (lib/pq and sqlx), "pgx" (pgx
+	// pool with cached prepared statements) or "memory". Everything else
+	// stays on pq with pq or pgx. "memory" is for local development without
+	// a database: books live in process and are lost on restart, genres stay
+	// free text, and the services that need Postgres (webhooks, genres,
+	// recommendations, covers, the catalog, series and shelves) are off.
+	Driver string `yaml:"driver" env-default:"pq"`
+
+	MaxOpenConns int `yaml:"max_open_conns" env-default:"25"`
+	// MaxIdleConns applies to pq only: the pgx pool closes idle
+	// connections by ConnMaxIdleTime alone.
+	MaxIdleConns    int           `yaml:"max_idle_conns" env-default:"25"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env-default:"30m"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env-default:"5m"`
+	// QueryTimeout aborts statements that run longer; 0 uses the gRPC
+	// timeout.
+	QueryTimeout time.Duration `yaml:"query_timeout"`
+	// Replicas are DSNs of read replicas, as URLs or key=value strings.
+	// Book reads are spread over them; writes always go to the primary.
+	// The pgx driver does not support them and refuses to start with any.
+	Replicas []string `yaml:"replicas"`
+	// ReadYourWrites sends the reads of a book, of a user shelf, or of the
+	// book lists after any book changed, to the primary for this long after
+	// the write, so that callers see their own changes despite replication
+	// lag. Only writes made by the same process count. 0 disables it.
+	ReadYourWrites time.Duration `yaml:"read_your_writes" env-default:"5s"`
 }
 
 type RedisConfig struct {
+	// Backend is "redis", "memory", which caches in process and does not
+	// need a Redis server, or "none", which caches nothing.
+	Backend  string `yaml:"backend" env-default:"redis"`
 	Addr     string `yaml:"addr"`
 	Password string `yaml:"password"`
 	DB       int    `yaml:"db"`
 	Username string `yaml:"username"`
 	TTL      int    `yaml:"ttl"`
+	// TTLJitter moves each book's expiry by up to this fraction of TTL
+	// either way, so that books cached together do not expire together.
+	TTLJitter float64 `yaml:"ttl_jitter" env-default:"0.1"`
+	// NotFoundTTL caches lookups of books that do not exist; 0 disables it.
+	NotFoundTTL time.Duration `yaml:"not_found_ttl" env-default:"30s"`
+	// EarlyRefresh makes a lookup treat a book with r left to live as a
+	// miss with probability exp(-r/EarlyRefresh), so that a hot book is
+	// reloaded before it expires; 0 disables it.
+	EarlyRefresh time.Duration `yaml:"early_refresh" env-default:"30s"`
+
+	// Required makes startup fail when Redis is down. Otherwise the
+	// service starts with the cache off and turns it on once Redis
+	// answers.
+	Required bool `yaml:"required"`
+	// Timeout bounds connecting to Redis and each read and write.
+	Timeout time.Duration `yaml:"timeout" env-default:"200ms"`
+	Breaker BreakerConfig `yaml:"breaker"`
+	// Local keeps hot books in process in front of Redis.
+	Local LocalCacheConfig `yaml:"local"`
+}
+
+// LocalCacheConfig sizes the in-process tier. Entries are fresh for TTL;
+// for StaleTTL after that they are still served while a background lookup
+// refreshes them. Replicas drop entries changed elsewhere through Redis
+// pub/sub, so TTL only bounds staleness when a message is lost.
+type LocalCacheConfig struct {
+	Enabled  bool          `yaml:"enabled" env-default:"true"`
+	Size     int           `yaml:"size" env-default:"10000"`
+	TTL      time.Duration `yaml:"ttl" env-default:"5s"`
+	StaleTTL time.Duration `yaml:"stale_ttl" env-default:"30s"`
+}
+
+// BreakerConfig stops calls to a failing dependency: after Failures errors
+// in a row it is skipped for Cooldown, then one call probes whether it is
+// back.
+type BreakerConfig struct {
+	Failures int           `yaml:"failures" env-default:"5"`
+	Cooldown time.Duration `yaml:"cooldown" env-default:"10s"`
+}
+
+type WebhookConfig struct {
+	PollInterval   time.Duration `yaml:"poll_interval" env-default:"1s"`
+	BatchSize      int           `yaml:"batch_size" env-default:"50"`
+	RequestTimeout time.Duration `yaml:"request_timeout" env-default:"5s"`
+	MaxAttempts    int32         `yaml:"max_attempts" env-default:"8"`
+	InitialBackoff time.Duration `yaml:"initial_backoff" env-default:"5s"`
+	MaxBackoff     time.Duration `yaml:"max_backoff" env-default:"1h"`
+}
+
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Backend is "redis" for limits shared across replicas or "local".
+	Backend string                 `yaml:"backend" env-default:"redis"`
+	Default LimitConfig            `yaml:"default"`
+	Methods map[string]LimitConfig `yaml:"methods"`
+}
+
+type LimitConfig struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+type QuotaConfig struct {
+	// MaxShelfSize limits the number of books per user shelf; 0 disables it.
+	MaxShelfSize int `yaml:"max_shelf_size"`
+	// MaxShelves limits the number of custom shelves per user; 0 disables it.
+	MaxShelves int `yaml:"max_shelves"`
+}
+
+type CoversConfig struct {
+	// Backend is "fs" to keep covers under Dir or "s3".
+	Backend string   `yaml:"backend" env-default:"fs"`
+	Dir     string   `yaml:"dir" env-default:"./data/covers"`
+	S3      S3Config `yaml:"s3"`
+	// MaxSize is the largest accepted upload in bytes.
+	MaxSize       int64 `yaml:"max_size" env-default:"5242880"`
+	ThumbnailSize int   `yaml:"thumbnail_size" env-default:"256"`
+}
+
+// S3Config points at an S3-compatible object store such as AWS S3 or MinIO.
+type S3Config struct {
+	Endpoint  string `yaml:"endpoint"`
+	Region    string `yaml:"region"`
+	Bucket    string `yaml:"bucket"`
+	AccessKey string `yaml:"access_key" env:"S3_ACCESS_KEY"`
+	SecretKey string `yaml:"secret_key" env:"S3_SECRET_KEY"`
+	UseSSL    bool   `yaml:"use_ssl" env-default:"true"`
+}
+
+// CatalogConfig overrides the MARC, Dublin Core and CSV/JSON Lines column
+// mappings of catalog import and export per book field, e.g. title: ["245a"].
+// Fields that are not listed keep the defaults of the catalog package.
+type CatalogConfig struct {
+	MARC       map[string][]string `yaml:"marc"`
+	DublinCore map[string][]string `yaml:"dublin_core"`
+	Columns    map[string][]string `yaml:"columns"`
+	// DuplicateThreshold is the similarity, above 0 and at most 1, from
+	// which an imported title and author are taken to match an existing
+	// book.
+	DuplicateThreshold float64 `yaml:"duplicate_threshold" env-default:"0.9"`
+}
+
+// RecommendationsConfig tunes the job that precomputes book similarities
+// from shelf co-occurrence.
+type RecommendationsConfig struct {
+	// RefreshInterval is how often the scores are rebuilt. It must be
+	// positive.
+	RefreshInterval time.Duration `yaml:"refresh_interval" env-default:"1h"`
+	// TopN is the number of similar books kept per book. It must be
+	// positive.
+	TopN int `yaml:"top_n" env-default:"50"`
+	// MinCoShelved is the number of readers two books must share to count
+	// as similar.
+	MinCoShelved int `yaml:"min_co_shelved" env-default:"2"`
+}
+
+type TracingConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Exporter is "otlp" to send spans to a collector over gRPC or "stdout"
+	// to print them, which needs no collector.
+	Exporter    string  `yaml:"exporter" env-default:"stdout"`
+	Endpoint    string  `yaml:"endpoint" env-default:"localhost:4317"`
+	Insecure    bool    `yaml:"insecure"`
+	ServiceName string  `yaml:"service_name" env-default:"bookService"`
+	SampleRatio float64 `yaml:"sample_ratio" env-default:"1"`
+}
+
+type ErrorReportingConfig struct {
+	// Backend is "file" to append reports to File as JSON lines, or "none".
+	Backend string `yaml:"backend" env-default:"none"`
+	File    string `yaml:"file" env-default:"./data/errors.jsonl"`
 }
 
 func MustLoad() *Config {
@@ -49,9 +255,43 @@ func MustLoad() *Config {
 	if err := cleanenv.ReadConfig(path, &cfg); err != nil {
 		panic("failed to read config: " + err.Error())
 	}
+	if err := cfg.Validate(); err != nil {
+		panic("invalid config: " + err.Error())
+	}
 	return &cfg
 }
 
+// Validate reports settings that cannot work, so that they fail at startup
+// rather than when first used.
+func (c *Config) Validate() error {
+	var errs []error
+	if c.GRPC.TLS.ReloadInterval < 0 {
+		errs = append(errs, fmt.Errorf("grpc.tls.reload_interval must not be negative, got %s", c.GRPC.TLS.ReloadInterval))
+	}
+	switch c.DB.Driver {
+	case "", "pq", "pgx":
+	case "memory":
+		if c.Env == "prod" {
+			errs = append(errs, errors.New(`db.driver "memory" is not for prod: books are lost on restart`))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("db.driver must be pq, pgx or memory, got %q", c.DB.Driver))
+	}
+	if c.DB.Driver == "pgx" && len(c.DB.Replicas) > 0 {
+		errs = append(errs, errors.New("db.replicas are not supported by the pgx driver"))
+	}
+	if t := c.Catalog.DuplicateThreshold; !(t > 0 && t <= 1) {
+		errs = append(errs, fmt.Errorf("catalog.duplicate_threshold must be in (0, 1], got %v", t))
+	}
+	if c.Recommendations.RefreshInterval <= 0 {
+		errs = append(errs, fmt.Errorf("recommendations.refresh_interval must be positive, got %s", c.Recommendations.RefreshInterval))
+	}
+	if c.Recommendations.TopN <= 0 {
+		errs = append(errs, fmt.Errorf("recommendations.top_n must be positive, got %d", c.Recommendations.TopN))
+	}
+	return errors.Join(errs...)
+}
+
 func fetchConfigPath() string {
 	var res string
 	flag.StringVar(&res, "config", "", "config file path")
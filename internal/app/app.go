@@ -3,14 +3,48 @@ package app
 import (
 	"bookService/config"
 	grpcapp "bookService/internal/app/grpc"
+	httpapp "bookService/internal/app/http"
+	"bookService/internal/blobstore"
+	"bookService/internal/catalog"
+	"bookService/internal/certs"
+	bookServicegrpc "bookService/internal/grpc/book-service"
+	genreServicegrpc "bookService/internal/grpc/genre-service"
+	webhookServicegrpc "bookService/internal/grpc/webhook-service"
+	"bookService/internal/ratelimit"
+	"bookService/internal/recommendations"
+	"bookService/internal/reporting"
 	bookService "bookService/internal/services/bookService"
+	"bookService/internal/services/catalogService"
+	"bookService/internal/services/coverService"
+	"bookService/internal/services/genreService"
+	"bookService/internal/services/recommendationService"
+	"bookService/internal/services/webhookService"
+	"bookService/internal/storage/caching"
+	"bookService/internal/storage/memory"
+	"bookService/internal/storage/pgxstore"
 	"bookService/internal/storage/postres"
 	"bookService/internal/storage/redis"
+	"bookService/internal/tracing"
+	"bookService/internal/webhooks"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"google.golang.org/grpc/credentials"
 	"log/slog"
+	"time"
 )
 
+const envProd = "prod"
+
 type App struct {
-	GRPCSrv *grpcapp.App
+	GRPCSrv         *grpcapp.App
+	HTTPSrv         *httpapp.App
+	Webhooks        *webhooks.Dispatcher
+	Recommendations *recommendations.Refresher
+	Certs           *certs.Reloader
+	// Cache is nil unless books are cached in process in front of Redis.
+	Cache   *caching.Tiered
+	Tracing *tracing.Provider
 }
 
 func New(
@@ -18,14 +52,228 @@ func New(
 	grpcPort int,
 	config *config.Config,
 ) *App {
-	storage, err := postres.New(config.DB)
-	cache, err := redis.New(config.Cache)
+	tracer, err := tracing.New(context.Background(), config.Tracing)
+	if err != nil {
+		panic(err)
+	}
+
+	cache, redisCache, err := bookCache(log, config.Cache)
+	if err != nil {
+		panic(err)
+	}
+	tiered, _ := cache.(*caching.Tiered)
+	var srv services
+	if config.DB.Driver == "memory" {
+		srv = memoryServices(log, config, cache)
+	} else {
+		srv = postgresServices(log, config, cache)
+	}
+
+	var (
+		reloader    *certs.Reloader
+		serverCreds credentials.TransportCredentials
+		clientCreds credentials.TransportCredentials
+	)
+	if config.GRPC.TLS.Enabled {
+		reloader, err = certs.NewReloader(log, config.GRPC.TLS)
+		if err != nil {
+			panic(err)
+		}
+		serverCreds = credentials.NewTLS(reloader.ServerConfig())
+
+		var gatewayCert *tls.Certificate
+		if config.GRPC.TLS.GatewayCertFile != "" {
+			cert, err := tls.LoadX509KeyPair(config.GRPC.TLS.GatewayCertFile, config.GRPC.TLS.GatewayKeyFile)
+			if err != nil {
+				panic(err)
+			}
+			gatewayCert = &cert
+		}
+		clientCreds = credentials.NewTLS(reloader.PinnedClientConfig(gatewayCert))
+	}
+
+	grpcConfig := config.GRPC
+	grpcConfig.Port = grpcPort
+	grpcConfig.Reflection = config.GRPC.Reflection && config.Env != envProd
+
+	var limiter ratelimit.Limiter
+	if config.RateLimit.Enabled {
+		switch config.RateLimit.Backend {
+		case "local":
+			limiter = ratelimit.NewLocal()
+		default:
+			// Without Redis there is nothing to share limits through.
+			if redisCache != nil {
+				limiter = ratelimit.NewRedis(log, redisCache.Client(), ratelimit.NewLocal())
+			} else {
+				limiter = ratelimit.NewLocal()
+			}
+		}
+	}
+
+	reporter, err := reporting.New(config.ErrorReporting)
 	if err != nil {
 		panic(err)
 	}
-	libraryService := bookService.New(storage, storage, cache, log)
-	grpcApp := grpcapp.New(log, grpcPort, libraryService)
+
+	grpcApp := grpcapp.New(log, grpcConfig, serverCreds, limiter, config.RateLimit, reporter, srv.books, srv.covers, srv.catalog, srv.webhooks, srv.genres, srv.recommendations)
+
+	var httpApp *httpapp.App
+	if config.HTTP.Enabled {
+		httpApp, err = httpapp.New(log, config.HTTP, grpcPort, clientCreds)
+		if err != nil {
+			panic(err)
+		}
+	}
 	return &App{
-		GRPCSrv: grpcApp,
+		GRPCSrv:         grpcApp,
+		HTTPSrv:         httpApp,
+		Webhooks:        srv.dispatcher,
+		Recommendations: srv.refresher,
+		Certs:           reloader,
+		Cache:           tiered,
+		Tracing:         tracer,
+	}
+}
+
+// services are what New serves over gRPC and the workers behind them. All
+// but books are nil when the storage cannot back them.
+type services struct {
+	books           *bookService.BookService
+	covers          bookServicegrpc.CoverService
+	catalog         bookServicegrpc.CatalogService
+	webhooks        webhookServicegrpc.WebhookService
+	genres          genreServicegrpc.GenreService
+	recommendations bookServicegrpc.RecommendationService
+	dispatcher      *webhooks.Dispatcher
+	refresher       *recommendations.Refresher
+}
+
+func postgresServices(log *slog.Logger, config *config.Config, cache bookService.BookCache) services {
+	storage, err := postres.New(config.DB, config.GRPC.Timeout)
+	if err != nil {
+		panic(err)
+	}
+	dispatcher := webhooks.New(log, storage, config.Webhooks)
+	books, err := bookStorage(config.DB, config.GRPC.Timeout, storage)
+	if err != nil {
+		panic(err)
+	}
+	libraryService := bookService.New(books, books, cache, dispatcher, storage, storage, storage, config.Quotas.MaxShelfSize, config.Quotas.MaxShelves, log)
+
+	var blobs blobstore.BlobStore
+	switch config.Covers.Backend {
+	case "s3":
+		blobs, err = blobstore.NewS3(context.Background(), config.Covers.S3)
+	default:
+		blobs, err = blobstore.NewFS(config.Covers.Dir)
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	return services{
+		books:           libraryService,
+		covers:          coverService.New(blobs, storage, cache, config.Covers.MaxSize, config.Covers.ThumbnailSize, log),
+		catalog:         mustCatalogService(log, config, libraryService, storage),
+		webhooks:        webhookService.New(storage, log),
+		genres:          genreService.New(storage, cache, log),
+		recommendations: recommendationService.New(storage, log),
+		dispatcher:      dispatcher,
+		refresher:       recommendations.New(log, storage, config.Recommendations),
+	}
+}
+
+// memoryServices keeps books in process, for running without a database.
+// Everything that needs Postgres is left out: genres stay free text, series
+// and shelf calls fail, and the other services are not served.
+func memoryServices(log *slog.Logger, config *config.Config, cache bookService.BookCache) services {
+	log.Warn("db.driver is memory: books are lost on restart and the services that need postgres are off")
+	books := memory.New()
+	return services{
+		books: bookService.New(books, books, cache, nil, nil, nil, nil, config.Quotas.MaxShelfSize, config.Quotas.MaxShelves, log),
+	}
+}
+
+// NewCatalogService builds the catalog service and what it depends on
+// without starting any server, for one-off commands such as import.
+func NewCatalogService(log *slog.Logger, config *config.Config) *catalogService.CatalogService {
+	if config.DB.Driver == "memory" {
+		panic("the catalog needs postgres, db.driver is memory")
+	}
+	// Imports are not bound to a gRPC deadline.
+	storage, err := postres.New(config.DB, 0)
+	if err != nil {
+		panic(err)
+	}
+	cache, _, err := bookCache(log, config.Cache)
+	if err != nil {
+		panic(err)
+	}
+	// Events land in the webhook outbox; a running server delivers them.
+	dispatcher := webhooks.New(log, storage, config.Webhooks)
+	books, err := bookStorage(config.DB, 0, storage)
+	if err != nil {
+		panic(err)
+	}
+	libraryService := bookService.New(books, books, cache, dispatcher, storage, storage, storage, config.Quotas.MaxShelfSize, config.Quotas.MaxShelves, log)
+	return mustCatalogService(log, config, libraryService, storage)
+}
+
+// bookStorage returns the book storage cfg.Driver selects; pq is the
+// storage the rest of the service already uses.
+func bookStorage(cfg config.DBConfig, timeout time.Duration, pq *postres.Storage) (bookStorer, error) {
+	switch cfg.Driver {
+	case "pgx":
+		storage, err := pgxstore.New(cfg, timeout)
+		if err != nil {
+			return nil, err
+		}
+		return storage, nil
+	case "", "pq":
+		return pq, nil
+	}
+	return nil, fmt.Errorf("unknown db driver %q", cfg.Driver)
+}
+
+// bookCache returns the cache cfg.Backend selects and, when that is Redis,
+// the Redis cache itself. Calls to Redis go through a circuit breaker;
+// unless cfg.Required, Redis being down at startup only leaves the
+// breaker open. With cfg.Local enabled, hot books are also kept in process.
+func bookCache(log *slog.Logger, cfg config.RedisConfig) (bookService.BookCache, *redis.Cache, error) {
+	switch cfg.Backend {
+	case "none":
+		return caching.Noop{}, nil, nil
+	case "memory":
+		return memory.NewCache(time.Duration(cfg.TTL)*time.Minute, cfg.NotFoundTTL), nil, nil
+	case "", "redis":
+		cache := redis.Open(cfg)
+		breaker := caching.NewBreaker(log, "redis", cache, cfg.Breaker)
+		if err := cache.Ping(context.Background()); err != nil {
+			if cfg.Required {
+				_ = cache.Close()
+				return nil, nil, err
+			}
+			log.Warn("redis is unavailable, starting without the cache", slog.String("error", err.Error()))
+			breaker.Open()
+		}
+		if cfg.Local.Enabled {
+			return caching.NewTiered(log, "redis", breaker, breaker.Bus(cache), cfg.Local), cache, nil
+		}
+		return breaker, cache, nil
+	}
+	return nil, nil, fmt.Errorf("unknown cache backend %q", cfg.Backend)
+}
+
+type bookStorer interface {
+	bookService.BookSaver
+	bookService.BookProvider
+}
+
+func mustCatalogService(log *slog.Logger, config *config.Config, books *bookService.BookService, storage *postres.Storage) *catalogService.CatalogService {
+	mappings, err := catalog.NewMappings(config.Catalog)
+	if err != nil {
+		panic(err)
 	}
+	return catalogService.New(books, storage, mappings, config.Catalog.DuplicateThreshold, log)
 }
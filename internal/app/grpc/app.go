@@ -1,11 +1,19 @@
 package grpcapp
 
 import (
+	"bookService/config"
 	interceptors "bookService/internal/delivery/interceptors"
 	bookServicegrpc "bookService/internal/grpc/book-service"
+	genreServicegrpc "bookService/internal/grpc/genre-service"
+	webhookServicegrpc "bookService/internal/grpc/webhook-service"
+	"bookService/internal/ratelimit"
+	"bookService/internal/reporting"
 	"context"
 	"fmt"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/reflection"
 	"log/slog"
 	"net"
 	"time"
@@ -17,24 +25,77 @@ type App struct {
 	port       int
 }
 
+// New builds the gRPC server. creds may be nil, in which case the server
+// listens without transport security; limiter may be nil to disable rate
+// limiting, and reporter to only log recovered panics. coverService and the
+// services after it may be nil when the storage cannot back them; nil
+// services are not registered, so calls to them are unimplemented.
 func New(
 	log *slog.Logger,
-	port int,
+	cfg config.GRPCConfig,
+	creds credentials.TransportCredentials,
+	limiter ratelimit.Limiter,
+	rateLimit config.RateLimitConfig,
+	reporter reporting.ErrorReporter,
 	bookService bookServicegrpc.BookService,
-
+	coverService bookServicegrpc.CoverService,
+	catalogService bookServicegrpc.CatalogService,
+	webhookService webhookServicegrpc.WebhookService,
+	genreService genreServicegrpc.GenreService,
+	recommendationService bookServicegrpc.RecommendationService,
 ) *App {
-	gRPCServer := grpc.NewServer(
-		grpc.ChainUnaryInterceptor(
-			interceptors.AuthInterceptor,
-			interceptors.MetricsInterceptor,
-		),
-	)
+	unary := []grpc.UnaryServerInterceptor{
+		interceptors.NewRequestInterceptor(log, cfg.AccessLog),
+		interceptors.MetricsInterceptor,
+		interceptors.NewRecoveryInterceptor(log, reporter),
+		interceptors.NewAuthInterceptor(cfg.TLS.ClientRoles),
+	}
+	stream := []grpc.StreamServerInterceptor{
+		interceptors.NewRequestStreamInterceptor(log, cfg.AccessLog),
+		interceptors.MetricsStreamInterceptor,
+		interceptors.NewRecoveryStreamInterceptor(log, reporter),
+		interceptors.NewAuthStreamInterceptor(cfg.TLS.ClientRoles),
+	}
+	if limiter != nil {
+		unary = append(unary, interceptors.NewRateLimitInterceptor(log, limiter, rateLimit))
+		stream = append(stream, interceptors.NewRateLimitStreamInterceptor(log, limiter, rateLimit))
+	}
+	unary = append(unary, interceptors.ValidationInterceptor)
+	stream = append(stream, interceptors.StreamValidationInterceptor)
 
-	bookServicegrpc.Register(gRPCServer, bookService)
+	opts := []grpc.ServerOption{
+		// Spans are started before the interceptors run, so they cover auth
+		// and validation failures too.
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+	if creds != nil {
+		opts = append(opts, grpc.Creds(creds))
+	}
+	gRPCServer := grpc.NewServer(opts...)
+
+	bookServicegrpc.Register(gRPCServer, bookService, coverService)
+	if catalogService != nil {
+		bookServicegrpc.RegisterCatalog(gRPCServer, catalogService)
+	}
+	if recommendationService != nil {
+		bookServicegrpc.RegisterRecommendations(gRPCServer, recommendationService)
+	}
+	if webhookService != nil {
+		webhookServicegrpc.Register(gRPCServer, webhookService)
+	}
+	if genreService != nil {
+		genreServicegrpc.Register(gRPCServer, genreService)
+	}
+	if cfg.Reflection {
+		reflection.Register(gRPCServer)
+		log.Info("grpc reflection enabled")
+	}
 	return &App{
 		log:        log,
 		gRPCServer: gRPCServer,
-		port:       port,
+		port:       cfg.Port,
 	}
 }
 
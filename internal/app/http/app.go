@@ -0,0 +1,169 @@
+package httpapp
+
+import (
+	"bookService/config"
+	"bookService/internal/delivery/interceptors"
+	gen "bookService/internal/delivery/protos/gen/go"
+	"bookService/internal/delivery/protos/gen/openapiv2"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// forwardedHeaders are HTTP headers passed through to gRPC metadata so the
+// gRPC interceptors see the same values they would for a native client.
+var forwardedHeaders = []string{
+	"x-user-role",
+	"x-user-id",
+	"x-api-key",
+	interceptors.RequestIDHeader,
+}
+
+// App serves the REST/JSON mapping of the gRPC API. Every request is proxied
+// to the local gRPC listener, so auth, metrics and any other interceptor
+// chained in grpcapp.New apply unchanged. It also serves the Prometheus
+// metrics of the process at /metrics.
+type App struct {
+	log        *slog.Logger
+	httpServer *http.Server
+	port       int
+	cancel     context.CancelFunc
+	conn       *grpc.ClientConn
+}
+
+func New(
+	log *slog.Logger,
+	cfg config.HTTPConfig,
+	grpcPort int,
+	grpcCreds credentials.TransportCredentials,
+) (*App, error) {
+	const op = "httpapp.New"
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	gwMux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+	)
+	endpoint := fmt.Sprintf("localhost:%d", grpcPort)
+	if grpcCreds == nil {
+		grpcCreds = insecure.NewCredentials()
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(grpcCreds)}
+
+	if err := gen.RegisterBookServiceHandlerFromEndpoint(ctx, gwMux, endpoint, opts); err != nil {
+		cancel()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := gen.RegisterWebhookServiceHandlerFromEndpoint(ctx, gwMux, endpoint, opts); err != nil {
+		cancel()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := gen.RegisterGenreServiceHandlerFromEndpoint(ctx, gwMux, endpoint, opts); err != nil {
+		cancel()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := gen.RegisterRecommendationServiceHandlerFromEndpoint(ctx, gwMux, endpoint, opts); err != nil {
+		cancel()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	conn, err := grpc.NewClient(endpoint, opts...)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := gwMux.HandlePath(http.MethodGet, coverPath, coverHandler(log, gwMux, gen.NewBookServiceClient(conn))); err != nil {
+		cancel()
+		_ = conn.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(openapiv2.Spec)
+	})
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/", gwMux)
+
+	return &App{
+		log: log,
+		httpServer: &http.Server{
+			Addr:         fmt.Sprintf(":%d", cfg.Port),
+			Handler:      mux,
+			ReadTimeout:  cfg.Timeout,
+			WriteTimeout: cfg.Timeout,
+		},
+		port:   cfg.Port,
+		cancel: cancel,
+		conn:   conn,
+	}, nil
+}
+
+func (a *App) MustRun() {
+	if err := a.Run(); err != nil {
+		panic(err)
+	}
+}
+
+func (a *App) Run() error {
+	const op = "httpapp.Run"
+	log := a.log.With(
+		slog.String("op", op),
+		slog.Int("port", a.port),
+	)
+
+	log.Info("http gateway started", slog.String("addr", a.httpServer.Addr))
+
+	if err := a.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (a *App) Stop() {
+	const op = "httpapp.Stop"
+	const timeout = 5 * time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	a.log.With(slog.String("op", op)).
+		Info("http gateway stopping", slog.Int("port", a.port))
+
+	if err := a.httpServer.Shutdown(ctx); err != nil {
+		a.log.Warn("forcing http gateway shutdown", slog.String("error", err.Error()))
+		_ = a.httpServer.Close()
+	}
+	a.cancel()
+	_ = a.conn.Close()
+}
+
+func headerMatcher(key string) (string, bool) {
+	lower := strings.ToLower(key)
+	for _, h := range forwardedHeaders {
+		if lower == h {
+			return lower, true
+		}
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher returns the request ID as a plain X-Request-Id
+// header; other response metadata keeps the Grpc-Metadata- prefix.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == interceptors.RequestIDHeader {
+		return http.CanonicalHeaderKey(key), true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
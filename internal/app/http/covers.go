@@ -0,0 +1,60 @@
+package httpapp
+
+import (
+	gen "bookService/internal/delivery/protos/gen/go"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+const coverPath = "/v1/books/{book_id}/cover"
+
+// coverHandler serves GetCover as a plain image response instead of the
+// gateway's newline-delimited JSON mapping of server streams, so cover URLs
+// can be used directly in an <img> tag.
+func coverHandler(log *slog.Logger, mux *runtime.ServeMux, client gen.BookServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+
+		ctx, err := runtime.AnnotateContext(r.Context(), mux, r,
+			"/bookService.BookService/GetCover", runtime.WithHTTPPathPattern(coverPath))
+		if err != nil {
+			runtime.HTTPError(r.Context(), mux, outbound, w, r, err)
+			return
+		}
+
+		thumbnail, _ := strconv.ParseBool(r.URL.Query().Get("thumbnail"))
+		stream, err := client.GetCover(ctx, &gen.GetCoverRequest{BookId: params["book_id"], Thumbnail: thumbnail})
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+
+		// The status is only known once the first message arrives.
+		chunk, err := stream.Recv()
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", chunk.GetContentType())
+		w.Header().Set("Content-Length", strconv.FormatInt(chunk.GetSize(), 10))
+
+		for {
+			if _, err := w.Write(chunk.GetData()); err != nil {
+				return
+			}
+			chunk, err = stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				log.Warn("cover stream interrupted", slog.String("error", err.Error()))
+				return
+			}
+		}
+	}
+}
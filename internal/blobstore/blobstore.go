@@ -0,0 +1,27 @@
+// Package blobstore keeps binary objects such as cover images outside the
+// database.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+type Info struct {
+	Size        int64
+	ContentType string
+}
+
+// BlobStore stores objects under slash-separated keys.
+type BlobStore interface {
+	// Put stores size bytes read from r under key, replacing any existing
+	// object.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the object stored under key. The caller must close it.
+	Get(ctx context.Context, key string) (io.ReadCloser, Info, error)
+	// Delete removes the object; deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
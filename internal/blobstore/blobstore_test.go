@@ -0,0 +1,121 @@
+package blobstore_test
+
+import (
+	"bookService/internal/blobstore"
+	"bookService/internal/storage/storagetest"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestFS(t *testing.T) {
+	s, err := blobstore.NewFS(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFS: %v", err)
+	}
+	testBlobStore(t, s)
+}
+
+// TestS3 runs against the store configured under covers.s3 in the
+// TEST_CONFIG_PATH configuration, e.g. a local MinIO. It writes to that
+// bucket under random keys and removes what it wrote.
+func TestS3(t *testing.T) {
+	cfg := storagetest.Config(t)
+	if cfg.Covers.S3.Endpoint == "" {
+		t.Skip("covers.s3.endpoint is not set")
+	}
+	s, err := blobstore.NewS3(context.Background(), cfg.Covers.S3)
+	if err != nil {
+		t.Fatalf("NewS3: %v", err)
+	}
+	testBlobStore(t, s)
+}
+
+func testBlobStore(t *testing.T, s blobstore.BlobStore) {
+	ctx := context.Background()
+	prefix := "blobstore-test/" + uuid.New().String()
+	// Larger than a read buffer, so that Put has to stream it.
+	data := bytes.Repeat([]byte("0123456789abcdef"), 64<<10)
+
+	t.Run("Streaming", func(t *testing.T) {
+		for name, size := range map[string]int64{"KnownSize": int64(len(data)), "UnknownSize": -1} {
+			t.Run(name, func(t *testing.T) {
+				key := prefix + "/" + name + ".jpg"
+				t.Cleanup(func() { _ = s.Delete(context.Background(), key) })
+
+				// A pipe cannot be rewound or sized up front.
+				pr, pw := io.Pipe()
+				defer pr.Close()
+				go func() {
+					for rest := data; len(rest) > 0; {
+						n := min(len(rest), 32<<10)
+						if _, err := pw.Write(rest[:n]); err != nil {
+							return
+						}
+						rest = rest[n:]
+					}
+					pw.Close()
+				}()
+				if err := s.Put(ctx, key, pr, size, "image/jpeg"); err != nil {
+					t.Fatalf("Put: %v", err)
+				}
+
+				r, info, err := s.Get(ctx, key)
+				if err != nil {
+					t.Fatalf("Get: %v", err)
+				}
+				defer r.Close()
+				got, err := io.ReadAll(r)
+				if err != nil {
+					t.Fatalf("read: %v", err)
+				}
+				if !bytes.Equal(got, data) {
+					t.Errorf("Get returned %d bytes, want the %d put", len(got), len(data))
+				}
+				if info.Size != int64(len(data)) || info.ContentType != "image/jpeg" {
+					t.Errorf("Get info = %+v, want size %d and image/jpeg", info, len(data))
+				}
+			})
+		}
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		key := prefix + "/replace.jpg"
+		t.Cleanup(func() { _ = s.Delete(context.Background(), key) })
+
+		for _, body := range []string{"first", "second"} {
+			if err := s.Put(ctx, key, bytes.NewReader([]byte(body)), int64(len(body)), "image/jpeg"); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+		}
+		r, _, err := s.Get(ctx, key)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		defer r.Close()
+		if got, err := io.ReadAll(r); err != nil || string(got) != "second" {
+			t.Errorf("Get = %q, %v, want the object put last", got, err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		key := prefix + "/delete.jpg"
+		if err := s.Put(ctx, key, bytes.NewReader(data[:10]), 10, "image/jpeg"); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+		if err := s.Delete(ctx, key); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, _, err := s.Get(ctx, key); !errors.Is(err, blobstore.ErrNotFound) {
+			t.Errorf("Get after Delete = %v, want ErrNotFound", err)
+		}
+		// Deleting again is no error.
+		if err := s.Delete(ctx, key); err != nil {
+			t.Errorf("second Delete: %v", err)
+		}
+	})
+}
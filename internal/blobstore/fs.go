@@ -0,0 +1,113 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// FS stores objects as files under a root directory. The content type is
+// derived from the key's extension.
+type FS struct {
+	root string
+}
+
+func NewFS(root string) (*FS, error) {
+	const op = "blobstore.NewFS"
+
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &FS{root: root}, nil
+}
+
+func (s *FS) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	const op = "blobstore.FS.Put"
+
+	name, err := s.path(key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Write to a temporary file first so readers never see a partial object.
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if size >= 0 && n != size {
+		return fmt.Errorf("%s: wrote %d bytes, expected %d", op, n, size)
+	}
+
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (s *FS) Get(ctx context.Context, key string) (io.ReadCloser, Info, error) {
+	const op = "blobstore.FS.Get"
+
+	name, err := s.path(key)
+	if err != nil {
+		return nil, Info{}, fmt.Errorf("%s: %w", op, err)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, Info{}, fmt.Errorf("%s: %w", op, ErrNotFound)
+		}
+		return nil, Info{}, fmt.Errorf("%s: %w", op, err)
+	}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, Info{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return f, Info{Size: st.Size(), ContentType: contentType}, nil
+}
+
+func (s *FS) Delete(ctx context.Context, key string) error {
+	const op = "blobstore.FS.Delete"
+
+	name, err := s.path(key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// path maps key to a file under root, rejecting keys that would escape it.
+func (s *FS) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if key == "" || clean != "/"+key || strings.Contains(key, "\\") {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(clean[1:])), nil
+}
@@ -0,0 +1,307 @@
+// Package catalog converts books to and from library catalog formats: MARC 21
+// in its binary (ISO 2709) and MARCXML forms and simple Dublin Core. Books
+// can also be read from spreadsheets exported as CSV or JSON Lines.
+package catalog
+
+import (
+	"bookService/config"
+	"bookService/internal/domain/models"
+	"bookService/internal/isbn"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	FormatMARC21     = "marc21"
+	FormatMARCXML    = "marcxml"
+	FormatDublinCore = "dublincore"
+	FormatCSV        = "csv"
+	FormatJSONLines  = "jsonl"
+)
+
+// Book fields that can be mapped.
+const (
+	FieldTitle           = "title"
+	FieldAuthor          = "author"
+	FieldPublicationYear = "publication_year"
+	FieldGenre           = "genre"
+	FieldPublisher       = "publisher"
+	FieldLanguage        = "language"
+	FieldPageCount       = "page_count"
+	FieldEdition         = "edition"
+	FieldDescription     = "description"
+	FieldSubjects        = "subjects"
+	FieldSeriesName      = "series_name"
+	FieldSeriesNumber    = "series_number"
+	FieldISBN            = "isbn"
+)
+
+// fields is also the order in which export writes values, which puts the
+// publisher before the date as in a 264 field.
+var fields = []string{
+	FieldTitle, FieldAuthor, FieldGenre, FieldPublisher, FieldPublicationYear,
+	FieldLanguage, FieldPageCount, FieldEdition, FieldDescription, FieldSubjects,
+	FieldSeriesName, FieldSeriesNumber, FieldISBN,
+}
+
+var (
+	ErrUnknownFormat = errors.New("unknown catalog format")
+	ErrNoExport      = errors.New("format cannot be exported")
+	ErrBadMapping    = errors.New("bad catalog mapping")
+)
+
+// RecordError reports a record that could not be read or mapped to a book.
+// Decoding can go on with the next record.
+type RecordError struct {
+	Err error
+}
+
+func (e *RecordError) Error() string { return e.Err.Error() }
+func (e *RecordError) Unwrap() error { return e.Err }
+
+// Decoder reads books from a catalog file. Decode returns io.EOF after the
+// last record and a *RecordError for a record that has to be skipped; any
+// other error is final.
+type Decoder interface {
+	Decode() (*models.Book, error)
+}
+
+// Encoder writes books to a catalog file. Close finishes the document.
+type Encoder interface {
+	Encode(book *models.Book) error
+	Close() error
+}
+
+// Mapping lists, for every book field, where its value is found in a record.
+// Sources are tried in order on import; export writes to the first one.
+type Mapping map[string][]string
+
+// Mappings holds the mapping of every format. Columns serves both CSV and
+// JSON Lines.
+type Mappings struct {
+	MARC       Mapping
+	DublinCore Mapping
+	Columns    Mapping
+}
+
+// NewMappings merges the configured overrides into the default mappings.
+func NewMappings(cfg config.CatalogConfig) (Mappings, error) {
+	marcMapping, err := merge(DefaultMARCMapping, cfg.MARC)
+	if err != nil {
+		return Mappings{}, fmt.Errorf("marc: %w", err)
+	}
+	for field, sources := range marcMapping {
+		for _, source := range sources {
+			if _, err := parseSpec(source); err != nil {
+				return Mappings{}, fmt.Errorf("marc: %s: %w", field, err)
+			}
+		}
+	}
+
+	dcMapping, err := merge(DefaultDublinCoreMapping, cfg.DublinCore)
+	if err != nil {
+		return Mappings{}, fmt.Errorf("dublin core: %w", err)
+	}
+	// Elements are matched by their lower-case name, and only the fifteen
+	// standard ones are written, so any other name would never be used.
+	for field, elements := range dcMapping {
+		for _, element := range elements {
+			if !isDublinCoreElement(element) {
+				return Mappings{}, fmt.Errorf("dublin core: %s: %w: element %q", field, ErrBadMapping, element)
+			}
+		}
+	}
+	columns, err := merge(DefaultColumnMapping, cfg.Columns)
+	if err != nil {
+		return Mappings{}, fmt.Errorf("columns: %w", err)
+	}
+	return Mappings{MARC: marcMapping, DublinCore: dcMapping, Columns: columns}, nil
+}
+
+func merge(defaults Mapping, overrides map[string][]string) (Mapping, error) {
+	merged := make(Mapping, len(fields))
+	for field, sources := range defaults {
+		merged[field] = sources
+	}
+	for field, sources := range overrides {
+		if !isField(field) {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrBadMapping, field)
+		}
+		merged[field] = sources
+	}
+	return merged, nil
+}
+
+func isField(name string) bool {
+	for _, f := range fields {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+func NewDecoder(format string, r io.Reader, m Mappings) (Decoder, error) {
+	switch format {
+	case FormatMARC21:
+		return newMARCDecoder(r, m.MARC, false), nil
+	case FormatMARCXML:
+		return newMARCDecoder(r, m.MARC, true), nil
+	case FormatDublinCore:
+		return newDublinCoreDecoder(r, m.DublinCore), nil
+	case FormatCSV:
+		return newCSVDecoder(r, m.Columns), nil
+	case FormatJSONLines:
+		return newJSONLinesDecoder(r, m.Columns), nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+}
+
+func NewEncoder(format string, w io.Writer, m Mappings) (Encoder, error) {
+	switch format {
+	case FormatMARC21:
+		return newMARCEncoder(w, m.MARC, false), nil
+	case FormatMARCXML:
+		return newMARCEncoder(w, m.MARC, true), nil
+	case FormatDublinCore:
+		return newDublinCoreEncoder(w, m.DublinCore), nil
+	case FormatCSV, FormatJSONLines:
+		return nil, fmt.Errorf("%w: %q", ErrNoExport, format)
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+}
+
+var (
+	yearPattern   = regexp.MustCompile(`\b\d{4}\b`)
+	numberPattern = regexp.MustCompile(`\d+`)
+)
+
+// buildBook assembles a book from the values lookup returns for each field.
+// Values that cannot be interpreted, such as a malformed ISBN, are dropped
+// rather than failing the record; a record without title or author fails.
+func buildBook(lookup func(field string) []string, parseLanguage func(string) (string, bool)) (*models.Book, error) {
+	first := func(field string) string {
+		for _, v := range lookup(field) {
+			if v = trimPunctuation(v); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+
+	book := &models.Book{
+		Title:       first(FieldTitle),
+		Author:      first(FieldAuthor),
+		Genre:       first(FieldGenre),
+		Publisher:   first(FieldPublisher),
+		Edition:     first(FieldEdition),
+		Description: first(FieldDescription),
+		SeriesName:  first(FieldSeriesName),
+	}
+	if book.Title == "" {
+		return nil, errors.New("record has no title")
+	}
+	if book.Author == "" {
+		return nil, errors.New("record has no author")
+	}
+
+	for _, v := range lookup(FieldPublicationYear) {
+		if y := yearPattern.FindString(v); y != "" {
+			year, _ := strconv.Atoi(y)
+			book.PublicationYear = int32(year)
+			break
+		}
+	}
+	book.PageCount = firstNumber(lookup(FieldPageCount))
+	book.SeriesNumber = firstNumber(lookup(FieldSeriesNumber))
+
+	for _, v := range lookup(FieldLanguage) {
+		if tag, ok := parseLanguage(v); ok {
+			book.Language = tag
+			break
+		}
+	}
+	for _, v := range lookup(FieldISBN) {
+		if normalized, err := isbn.Normalize(v); err == nil {
+			book.ISBN = normalized
+			break
+		}
+	}
+	for _, v := range lookup(FieldSubjects) {
+		if v = trimPunctuation(v); v != "" {
+			book.Subjects = append(book.Subjects, v)
+		}
+	}
+	return book, nil
+}
+
+func firstNumber(values []string) int32 {
+	for _, v := range values {
+		if n := numberPattern.FindString(v); n != "" {
+			if i, err := strconv.ParseInt(n, 10, 32); err == nil && i > 0 {
+				return int32(i)
+			}
+		}
+	}
+	return 0
+}
+
+// bookValues returns the values export writes for each field.
+func bookValues(book *models.Book) map[string][]string {
+	values := make(map[string][]string, len(fields))
+	set := func(field, value string) {
+		if value != "" {
+			values[field] = []string{value}
+		}
+	}
+	set(FieldTitle, book.Title)
+	set(FieldAuthor, book.Author)
+	set(FieldGenre, book.Genre)
+	set(FieldPublisher, book.Publisher)
+	set(FieldLanguage, book.Language)
+	set(FieldEdition, book.Edition)
+	set(FieldDescription, book.Description)
+	set(FieldSeriesName, book.SeriesName)
+	set(FieldISBN, book.ISBN)
+	if book.PublicationYear > 0 {
+		set(FieldPublicationYear, strconv.Itoa(int(book.PublicationYear)))
+	}
+	if book.PageCount > 0 {
+		set(FieldPageCount, strconv.Itoa(int(book.PageCount)))
+	}
+	if book.SeriesNumber > 0 {
+		set(FieldSeriesNumber, strconv.Itoa(int(book.SeriesNumber)))
+	}
+	if len(book.Subjects) > 0 {
+		values[FieldSubjects] = book.Subjects
+	}
+	return values
+}
+
+// trimPunctuation drops the ISBD punctuation catalogers end fields with, as
+// in "The hobbit /" or "Allen & Unwin,".
+func trimPunctuation(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimRight(s, " /:;,=")
+	// A trailing period is kept after an initial such as "Tolkien, J. R. R."
+	if strings.HasSuffix(s, ".") && !strings.HasSuffix(s, "..") {
+		if i := strings.LastIndexAny(s[:len(s)-1], " ."); i < 0 || len(s)-i > 3 {
+			s = strings.TrimSuffix(s, ".")
+		}
+	}
+	return strings.TrimSpace(s)
+}
+
+func bcp47(value string) (string, bool) {
+	tag, err := language.Parse(strings.TrimSpace(value))
+	if err != nil || tag == language.Und {
+		return "", false
+	}
+	return tag.String(), true
+}
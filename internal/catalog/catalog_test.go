@@ -0,0 +1,26 @@
+package catalog_test
+
+import (
+	"bookService/config"
+	"bookService/internal/catalog"
+	"errors"
+	"testing"
+)
+
+func TestNewMappingsRejectsUnknownDublinCoreElements(t *testing.T) {
+	for _, element := range []string{"fromat", "Format", ""} {
+		cfg := config.CatalogConfig{DublinCore: map[string][]string{catalog.FieldPageCount: {element}}}
+		if _, err := catalog.NewMappings(cfg); !errors.Is(err, catalog.ErrBadMapping) {
+			t.Errorf("NewMappings with element %q = %v, want ErrBadMapping", element, err)
+		}
+	}
+
+	cfg := config.CatalogConfig{DublinCore: map[string][]string{catalog.FieldPageCount: {"format"}}}
+	m, err := catalog.NewMappings(cfg)
+	if err != nil {
+		t.Fatalf("NewMappings: %v", err)
+	}
+	if got := m.DublinCore[catalog.FieldPageCount]; len(got) != 1 || got[0] != "format" {
+		t.Errorf("page count elements = %v, want [format]", got)
+	}
+}
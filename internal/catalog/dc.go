@@ -0,0 +1,107 @@
+package catalog
+
+import (
+	"bookService/internal/catalog/dublincore"
+	"bookService/internal/domain/models"
+	"io"
+	"strings"
+)
+
+// DefaultDublinCoreMapping maps book fields to Dublin Core element names.
+// Genre, edition and series have no natural element and are not mapped.
+var DefaultDublinCoreMapping = Mapping{
+	FieldTitle:           {"title"},
+	FieldAuthor:          {"creator", "contributor"},
+	FieldPublicationYear: {"date"},
+	FieldPublisher:       {"publisher"},
+	FieldLanguage:        {"language"},
+	FieldDescription:     {"description"},
+	FieldSubjects:        {"subject"},
+	FieldISBN:            {"identifier"},
+}
+
+func isDublinCoreElement(name string) bool {
+	for _, e := range dublincore.Elements {
+		if e == name {
+			return true
+		}
+	}
+	return false
+}
+
+type dublinCoreDecoder struct {
+	r *dublincore.Reader
+	m Mapping
+}
+
+func newDublinCoreDecoder(r io.Reader, m Mapping) *dublinCoreDecoder {
+	return &dublinCoreDecoder{r: dublincore.NewReader(r), m: m}
+}
+
+func (d *dublinCoreDecoder) Decode() (*models.Book, error) {
+	rec, err := d.r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := buildBook(func(field string) []string {
+		var values []string
+		for _, element := range d.m[field] {
+			for _, v := range rec[element] {
+				if field == FieldISBN {
+					v = isbnIdentifier(v)
+				}
+				values = append(values, v)
+			}
+		}
+		return values
+	}, bcp47)
+	if err != nil {
+		return nil, &RecordError{Err: err}
+	}
+	return book, nil
+}
+
+// isbnIdentifier strips the prefixes ISBNs carry among other identifiers,
+// as in "urn:isbn:9780261103344" or "ISBN 0-261-10334-2".
+func isbnIdentifier(v string) string {
+	lower := strings.ToLower(v)
+	for _, prefix := range []string{"urn:isbn:", "isbn:", "isbn"} {
+		if strings.HasPrefix(lower, prefix) {
+			return strings.TrimSpace(v[len(prefix):])
+		}
+	}
+	return v
+}
+
+type dublinCoreEncoder struct {
+	w *dublincore.Writer
+	m Mapping
+}
+
+func newDublinCoreEncoder(w io.Writer, m Mapping) *dublinCoreEncoder {
+	return &dublinCoreEncoder{w: dublincore.NewWriter(w), m: m}
+}
+
+func (e *dublinCoreEncoder) Encode(book *models.Book) error {
+	rec := dublincore.Record{}
+	values := bookValues(book)
+	for _, field := range fields {
+		elements := e.m[field]
+		if len(elements) == 0 {
+			continue
+		}
+		for _, v := range values[field] {
+			if field == FieldISBN {
+				v = "urn:isbn:" + v
+			}
+			rec.Add(elements[0], v)
+		}
+	}
+	rec.Add("identifier", "urn:uuid:"+book.ID)
+	return e.w.Write(rec)
+}
+
+func (e *dublinCoreEncoder) Close() error {
+	return e.w.Close()
+}
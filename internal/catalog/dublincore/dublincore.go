@@ -0,0 +1,136 @@
+// Package dublincore reads and writes simple Dublin Core records in the
+// oai_dc XML format.
+package dublincore
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	NamespaceOAI = "http://www.openarchives.org/OAI/2.0/oai_dc/"
+	NamespaceDC  = "http://purl.org/dc/elements/1.1/"
+)
+
+// Elements lists the fifteen Dublin Core elements in the order they are
+// written.
+var Elements = []string{
+	"title", "creator", "subject", "description", "publisher", "contributor",
+	"date", "type", "format", "identifier", "source", "language", "relation",
+	"coverage", "rights",
+}
+
+// Record maps element names such as "title" or "creator" to their values in
+// document order.
+type Record map[string][]string
+
+// First returns the first value of element.
+func (r Record) First(element string) string {
+	if values := r[element]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (r Record) Add(element, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		r[element] = append(r[element], value)
+	}
+}
+
+// Reader reads every oai_dc:dc element of a document, wherever it appears, so
+// both bare records and OAI-PMH responses can be read.
+type Reader struct {
+	d *xml.Decoder
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{d: xml.NewDecoder(r)}
+}
+
+// Read returns the next record and io.EOF after the last one.
+func (r *Reader) Read() (Record, error) {
+	for {
+		tok, err := r.d.Token()
+		if err != nil {
+			return nil, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "dc" {
+			continue
+		}
+		return r.readRecord()
+	}
+}
+
+func (r *Reader) readRecord() (Record, error) {
+	rec := Record{}
+	for {
+		tok, err := r.d.Token()
+		if err != nil {
+			return nil, fmt.Errorf("dublincore: %w", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var value string
+			if err := r.d.DecodeElement(&value, &t); err != nil {
+				return nil, fmt.Errorf("dublincore: %w", err)
+			}
+			rec.Add(strings.ToLower(t.Name.Local), value)
+		case xml.EndElement:
+			return rec, nil
+		}
+	}
+}
+
+// Writer writes records into a <records> document. Close must be called to
+// finish it.
+type Writer struct {
+	w       io.Writer
+	started bool
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+func (w *Writer) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+	_, err := fmt.Fprintf(w.w, "%s<records xmlns:oai_dc=%q xmlns:dc=%q>\n", xml.Header, NamespaceOAI, NamespaceDC)
+	return err
+}
+
+func (w *Writer) Write(rec Record) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("  <oai_dc:dc>\n")
+	for _, element := range Elements {
+		for _, value := range rec[element] {
+			fmt.Fprintf(&buf, "    <dc:%s>", element)
+			if err := xml.EscapeText(&buf, []byte(value)); err != nil {
+				return err
+			}
+			fmt.Fprintf(&buf, "</dc:%s>\n", element)
+		}
+	}
+	buf.WriteString("  </oai_dc:dc>\n")
+	_, err := w.w.Write(buf.Bytes())
+	return err
+}
+
+func (w *Writer) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w.w, "</records>\n")
+	return err
+}
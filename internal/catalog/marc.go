@@ -0,0 +1,276 @@
+package catalog
+
+import (
+	"bookService/internal/catalog/marc"
+	"bookService/internal/domain/models"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultMARCMapping follows the Library of Congress MARC 21 bibliographic
+// format. A source is a tag followed by subfield codes, "245ab" joining
+// subfields a and b of field 245, or a control field with a position range,
+// "008/35-37".
+var DefaultMARCMapping = Mapping{
+	FieldTitle:           {"245ab"},
+	FieldAuthor:          {"100a", "110a", "700a"},
+	FieldPublicationYear: {"264c", "260c", "008/07-10"},
+	FieldGenre:           {"655a"},
+	FieldPublisher:       {"264b", "260b"},
+	FieldLanguage:        {"041a", "008/35-37"},
+	FieldPageCount:       {"300a"},
+	FieldEdition:         {"250a"},
+	FieldDescription:     {"520a"},
+	FieldSubjects:        {"650a", "653a"},
+	FieldSeriesName:      {"830a", "490a"},
+	FieldSeriesNumber:    {"830v", "490v"},
+	FieldISBN:            {"020a"},
+}
+
+var specPattern = regexp.MustCompile(`^(\d{3})(?:([a-z0-9]+)|/(\d{2})(?:-(\d{2}))?)?$`)
+
+type spec struct {
+	tag   string
+	codes string
+	// from and to are the inclusive control field positions; from is -1
+	// for the whole field.
+	from, to int
+}
+
+func parseSpec(s string) (spec, error) {
+	m := specPattern.FindStringSubmatch(s)
+	if m == nil {
+		return spec{}, fmt.Errorf("%w: source %q", ErrBadMapping, s)
+	}
+	sp := spec{tag: m[1], codes: m[2], from: -1, to: -1}
+	control := marc.IsControlTag(sp.tag)
+	switch {
+	case m[3] != "":
+		if !control {
+			return spec{}, fmt.Errorf("%w: positions on data field in %q", ErrBadMapping, s)
+		}
+		sp.from, _ = strconv.Atoi(m[3])
+		sp.to = sp.from
+		if m[4] != "" {
+			sp.to, _ = strconv.Atoi(m[4])
+		}
+		if sp.to < sp.from {
+			return spec{}, fmt.Errorf("%w: position range in %q", ErrBadMapping, s)
+		}
+	case sp.codes == "" && !control:
+		return spec{}, fmt.Errorf("%w: no subfield in %q", ErrBadMapping, s)
+	case sp.codes != "" && control:
+		return spec{}, fmt.Errorf("%w: subfield on control field in %q", ErrBadMapping, s)
+	}
+	return sp, nil
+}
+
+// values returns what sp selects from rec, one value per field.
+func (sp spec) values(rec *marc.Record) []string {
+	if marc.IsControlTag(sp.tag) {
+		value := rec.Control(sp.tag)
+		if sp.from >= 0 {
+			if len(value) <= sp.to {
+				return nil
+			}
+			value = value[sp.from : sp.to+1]
+		}
+		// Fill characters and blanks mean "not coded".
+		if value = strings.TrimSpace(strings.Trim(value, "|#")); value == "" {
+			return nil
+		}
+		return []string{value}
+	}
+
+	var values []string
+	for _, f := range rec.Fields(sp.tag) {
+		if len(sp.codes) == 1 {
+			values = append(values, f.SubfieldValues(sp.codes[0])...)
+			continue
+		}
+		var parts []string
+		for _, sf := range f.Subfields {
+			if strings.IndexByte(sp.codes, sf.Code) >= 0 {
+				parts = append(parts, strings.TrimSpace(sf.Value))
+			}
+		}
+		if len(parts) > 0 {
+			values = append(values, strings.Join(parts, " "))
+		}
+	}
+	return values
+}
+
+type marcReader interface {
+	Read() (*marc.Record, error)
+}
+
+type marcDecoder struct {
+	r     marcReader
+	specs map[string][]spec
+}
+
+func newMARCDecoder(r io.Reader, m Mapping, xml bool) *marcDecoder {
+	d := &marcDecoder{specs: compile(m)}
+	if xml {
+		d.r = marc.NewXMLReader(r)
+	} else {
+		d.r = marc.NewReader(r)
+	}
+	return d
+}
+
+func compile(m Mapping) map[string][]spec {
+	specs := make(map[string][]spec, len(m))
+	for field, sources := range m {
+		for _, source := range sources {
+			// NewMappings has validated every source.
+			sp, _ := parseSpec(source)
+			specs[field] = append(specs[field], sp)
+		}
+	}
+	return specs
+}
+
+func (d *marcDecoder) Decode() (*models.Book, error) {
+	rec, err := d.r.Read()
+	if err != nil {
+		if errors.Is(err, marc.ErrInvalidRecord) {
+			return nil, &RecordError{Err: err}
+		}
+		return nil, err
+	}
+
+	book, err := buildBook(func(field string) []string {
+		var values []string
+		for _, sp := range d.specs[field] {
+			values = append(values, sp.values(rec)...)
+			// Scalar fields take the first source that has a value, but
+			// every subject heading counts.
+			if len(values) > 0 && field != FieldSubjects {
+				break
+			}
+		}
+		return values
+	}, marcLanguage)
+	if err != nil {
+		return nil, &RecordError{Err: err}
+	}
+	return book, nil
+}
+
+type marcWriter interface {
+	Write(rec *marc.Record) error
+}
+
+type marcEncoder struct {
+	w     marcWriter
+	xml   *marc.XMLWriter
+	specs map[string][]spec
+}
+
+func newMARCEncoder(w io.Writer, m Mapping, xml bool) *marcEncoder {
+	e := &marcEncoder{specs: compile(m)}
+	if xml {
+		e.xml = marc.NewXMLWriter(w)
+		e.w = e.xml
+	} else {
+		e.w = marc.NewWriter(w)
+	}
+	return e
+}
+
+func (e *marcEncoder) Encode(book *models.Book) error {
+	rec := marc.NewRecord()
+	rec.SetControl("001", book.ID)
+
+	values := bookValues(book)
+	if lang, ok := values[FieldLanguage]; ok {
+		values[FieldLanguage] = []string{marcLanguageCode(lang[0])}
+	}
+	for _, field := range fields {
+		specs := e.specs[field]
+		if len(specs) == 0 {
+			continue
+		}
+		for _, value := range values[field] {
+			e.write(rec, specs[0], field, value)
+		}
+	}
+	return e.w.Write(rec)
+}
+
+func (e *marcEncoder) write(rec *marc.Record, sp spec, field, value string) {
+	if marc.IsControlTag(sp.tag) {
+		current := rec.Control(sp.tag)
+		if sp.from < 0 {
+			rec.SetControl(sp.tag, value)
+			return
+		}
+		width := sp.to - sp.from + 1
+		size := sp.to + 1
+		if sp.tag == "008" {
+			size = max(size, 40)
+		}
+		if len(current) < size {
+			current += strings.Repeat(" ", size-len(current))
+		}
+		value = fmt.Sprintf("%-*.*s", width, width, value)
+		rec.SetControl(sp.tag, current[:sp.from]+value+current[sp.to+1:])
+		return
+	}
+
+	// Every subject heading gets a field of its own; other values share the
+	// field, so the series name and number end up in one 490.
+	if field == FieldSubjects {
+		rec.AddField(marc.DataField{
+			Tag:       sp.tag,
+			Ind1:      ' ',
+			Ind2:      ' ',
+			Subfields: []marc.Subfield{{Code: sp.codes[0], Value: value}},
+		})
+		return
+	}
+	rec.AddSubfield(sp.tag, sp.codes[0], value)
+}
+
+func (e *marcEncoder) Close() error {
+	if e.xml != nil {
+		return e.xml.Close()
+	}
+	return nil
+}
+
+// bibliographicCodes maps the MARC (ISO 639-2/B) language codes that differ
+// from their terminology counterparts.
+var bibliographicCodes = map[string]string{
+	"alb": "sqi", "arm": "hye", "baq": "eus", "bur": "mya", "chi": "zho",
+	"cze": "ces", "dut": "nld", "fre": "fra", "geo": "kat", "ger": "deu",
+	"gre": "ell", "ice": "isl", "mac": "mkd", "mao": "mri", "may": "msa",
+	"per": "fas", "rum": "ron", "slo": "slk", "tib": "bod", "wel": "cym",
+}
+
+func marcLanguage(value string) (string, bool) {
+	code := strings.ToLower(strings.TrimSpace(value))
+	if terminology, ok := bibliographicCodes[code]; ok {
+		code = terminology
+	}
+	return bcp47(code)
+}
+
+func marcLanguageCode(tag string) string {
+	base, _ := language.Make(tag).Base()
+	code := base.ISO3()
+	for bibliographic, terminology := range bibliographicCodes {
+		if terminology == code {
+			return bibliographic
+		}
+	}
+	return code
+}
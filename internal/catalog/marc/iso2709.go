@@ -0,0 +1,198 @@
+package marc
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	leaderLen        = 24
+	directoryLen     = 12
+	fieldTerminator  = 0x1E
+	recordTerminator = 0x1D
+	subfieldDelim    = 0x1F
+	maxRecordLen     = 99999
+)
+
+// Reader reads ISO 2709 records.
+type Reader struct {
+	r *bufio.Reader
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Read returns the next record and io.EOF after the last one. A malformed
+// record is reported with an error wrapping ErrInvalidRecord and skipped, so
+// the caller can go on reading; any other error is final.
+func (r *Reader) Read() (*Record, error) {
+	// Some exports put a newline between records.
+	for {
+		b, err := r.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if b != '\n' && b != '\r' {
+			_ = r.r.UnreadByte()
+			break
+		}
+	}
+
+	prefix := make([]byte, 5)
+	if _, err := io.ReadFull(r.r, prefix); err != nil {
+		return nil, fmt.Errorf("marc: %w", io.ErrUnexpectedEOF)
+	}
+	length, err := number(prefix)
+	if err != nil || length < leaderLen+1 {
+		// Without a usable length the only way forward is the record
+		// terminator.
+		if _, err := r.r.ReadBytes(recordTerminator); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("marc: %w", err)
+		}
+		return nil, fmt.Errorf("%w: bad record length %q", ErrInvalidRecord, prefix)
+	}
+
+	data := make([]byte, length)
+	copy(data, prefix)
+	if _, err := io.ReadFull(r.r, data[5:]); err != nil {
+		return nil, fmt.Errorf("marc: %w", io.ErrUnexpectedEOF)
+	}
+	return parseRecord(data)
+}
+
+func parseRecord(data []byte) (*Record, error) {
+	if data[len(data)-1] != recordTerminator {
+		return nil, fmt.Errorf("%w: missing record terminator", ErrInvalidRecord)
+	}
+	base, err := number(data[12:17])
+	if err != nil || base <= leaderLen || base > len(data) {
+		return nil, fmt.Errorf("%w: bad base address", ErrInvalidRecord)
+	}
+
+	rec := &Record{Leader: string(data[:leaderLen])}
+	directory := data[leaderLen : base-1]
+	if len(directory)%directoryLen != 0 {
+		return nil, fmt.Errorf("%w: bad directory length", ErrInvalidRecord)
+	}
+
+	for i := 0; i < len(directory); i += directoryLen {
+		entry := directory[i : i+directoryLen]
+		tag := string(entry[:3])
+		length, err1 := number(entry[3:7])
+		start, err2 := number(entry[7:12])
+		if err1 != nil || err2 != nil || base+start+length > len(data) {
+			return nil, fmt.Errorf("%w: bad directory entry for %s", ErrInvalidRecord, tag)
+		}
+		field := bytes.TrimSuffix(data[base+start:base+start+length], []byte{fieldTerminator})
+
+		if IsControlTag(tag) {
+			rec.ControlFields = append(rec.ControlFields, ControlField{Tag: tag, Value: string(field)})
+			continue
+		}
+		if len(field) < 2 {
+			return nil, fmt.Errorf("%w: field %s has no indicators", ErrInvalidRecord, tag)
+		}
+		df := DataField{Tag: tag, Ind1: field[0], Ind2: field[1]}
+		for _, part := range bytes.Split(field[2:], []byte{subfieldDelim}) {
+			if len(part) == 0 {
+				continue
+			}
+			df.Subfields = append(df.Subfields, Subfield{Code: part[0], Value: string(part[1:])})
+		}
+		rec.DataFields = append(rec.DataFields, df)
+	}
+	return rec, nil
+}
+
+// number parses a fixed-width numeric field of the leader or directory.
+// Unlike strconv.Atoi it takes digits only, so a field can never be negative.
+func number(b []byte) (int, error) {
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("%w: %q is not a number", ErrInvalidRecord, b)
+		}
+	}
+	return strconv.Atoi(string(b))
+}
+
+// Writer writes ISO 2709 records.
+type Writer struct {
+	w io.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+func (w *Writer) Write(rec *Record) error {
+	var directory, fields bytes.Buffer
+	addField := func(tag string, body []byte) error {
+		body = append(body, fieldTerminator)
+		if len(body) > 9999 {
+			return fmt.Errorf("%w: field %s is too long", ErrInvalidRecord, tag)
+		}
+		fmt.Fprintf(&directory, "%3.3s%04d%05d", tag, len(body), fields.Len())
+		fields.Write(body)
+		return nil
+	}
+
+	for _, f := range rec.ControlFields {
+		if err := addField(f.Tag, []byte(clean(f.Value))); err != nil {
+			return err
+		}
+	}
+	for _, f := range rec.DataFields {
+		body := []byte{indicator(f.Ind1), indicator(f.Ind2)}
+		for _, sf := range f.Subfields {
+			body = append(body, subfieldDelim, sf.Code)
+			body = append(body, clean(sf.Value)...)
+		}
+		if err := addField(f.Tag, body); err != nil {
+			return err
+		}
+	}
+	directory.WriteByte(fieldTerminator)
+
+	base := leaderLen + directory.Len()
+	length := base + fields.Len() + 1
+	if length > maxRecordLen {
+		return fmt.Errorf("%w: record is too long", ErrInvalidRecord)
+	}
+
+	leader := []byte(fmt.Sprintf("%-24.24s", rec.Leader))
+	copy(leader[0:5], fmt.Sprintf("%05d", length))
+	leader[9] = 'a' // UTF-8
+	copy(leader[10:12], "22")
+	copy(leader[12:17], fmt.Sprintf("%05d", base))
+	copy(leader[20:24], "4500")
+
+	buf := make([]byte, 0, length)
+	buf = append(buf, leader...)
+	buf = append(buf, directory.Bytes()...)
+	buf = append(buf, fields.Bytes()...)
+	buf = append(buf, recordTerminator)
+	_, err := w.w.Write(buf)
+	return err
+}
+
+// clean drops the structural delimiters, which cannot appear in values.
+func clean(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == fieldTerminator || r == recordTerminator || r == subfieldDelim {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func indicator(b byte) byte {
+	if b == 0 {
+		return ' '
+	}
+	return b
+}
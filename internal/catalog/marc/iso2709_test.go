@@ -0,0 +1,88 @@
+package marc_test
+
+import (
+	"bookService/internal/catalog/marc"
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+// record returns a valid record with a control field and a data field,
+// whose directory entries start at offsets 24 and 36.
+func record(t testing.TB) []byte {
+	t.Helper()
+	rec := marc.NewRecord()
+	rec.ControlFields = []marc.ControlField{{Tag: "001", Value: "b1"}}
+	rec.DataFields = []marc.DataField{{
+		Tag: "245", Ind1: '1', Ind2: '0',
+		Subfields: []marc.Subfield{{Code: 'a', Value: "Dune"}},
+	}}
+	var buf bytes.Buffer
+	if err := marc.NewWriter(&buf).Write(rec); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadMalformedRecords(t *testing.T) {
+	tests := []struct {
+		name   string
+		offset int
+		value  string
+	}{
+		{"negative record length", 0, "-0050"},
+		{"signed record length", 0, "+0050"},
+		{"negative base address", 12, "-0049"},
+		{"signed base address", 12, "+0049"},
+		{"base address past the end", 12, "99999"},
+		{"negative field length", 24 + 3, "-003"},
+		{"negative field start", 24 + 7, "-0001"},
+		{"signed field start", 36 + 7, "+0003"},
+		{"field past the end", 36 + 3, "9999"},
+		{"non-numeric field length", 36 + 3, "00x9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := record(t)
+			copy(data[tt.offset:], tt.value)
+
+			_, err := marc.NewReader(bytes.NewReader(data)).Read()
+			if !errors.Is(err, marc.ErrInvalidRecord) {
+				t.Fatalf("Read = %v, want ErrInvalidRecord", err)
+			}
+		})
+	}
+}
+
+func TestReadSkipsMalformedRecords(t *testing.T) {
+	bad := record(t)
+	copy(bad[24+7:], "-0001")
+	r := marc.NewReader(bytes.NewReader(append(bad, record(t)...)))
+
+	if _, err := r.Read(); !errors.Is(err, marc.ErrInvalidRecord) {
+		t.Fatalf("first Read = %v, want ErrInvalidRecord", err)
+	}
+	rec, err := r.Read()
+	if err != nil {
+		t.Fatalf("second Read: %v", err)
+	}
+	if got := rec.DataFields[0].Subfields[0].Value; got != "Dune" {
+		t.Errorf("title = %q, want %q", got, "Dune")
+	}
+	if _, err := r.Read(); err != io.EOF {
+		t.Errorf("last Read = %v, want io.EOF", err)
+	}
+}
+
+func FuzzRead(f *testing.F) {
+	f.Add(record(f))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		r := marc.NewReader(bytes.NewReader(data))
+		for {
+			if _, err := r.Read(); err != nil && !errors.Is(err, marc.ErrInvalidRecord) {
+				return
+			}
+		}
+	})
+}
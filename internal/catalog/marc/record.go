@@ -0,0 +1,124 @@
+// Package marc reads and writes MARC 21 bibliographic records in the ISO 2709
+// transmission format and as MARCXML.
+package marc
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidRecord = errors.New("invalid MARC record")
+
+// Record is a single MARC record. Control fields (tags 001-009) hold a plain
+// value, data fields hold indicators and subfields.
+type Record struct {
+	Leader        string
+	ControlFields []ControlField
+	DataFields    []DataField
+}
+
+type ControlField struct {
+	Tag   string
+	Value string
+}
+
+type DataField struct {
+	Tag       string
+	Ind1      byte
+	Ind2      byte
+	Subfields []Subfield
+}
+
+type Subfield struct {
+	Code  byte
+	Value string
+}
+
+// NewRecord returns an empty record with a leader for a monograph encoded as
+// UTF-8.
+func NewRecord() *Record {
+	return &Record{Leader: "00000nam a2200000 i 4500"}
+}
+
+// IsControlTag reports whether tag names a control field.
+func IsControlTag(tag string) bool {
+	return strings.HasPrefix(tag, "00")
+}
+
+// Control returns the value of the first control field with tag.
+func (r *Record) Control(tag string) string {
+	for _, f := range r.ControlFields {
+		if f.Tag == tag {
+			return f.Value
+		}
+	}
+	return ""
+}
+
+// Fields returns every data field with tag in record order.
+func (r *Record) Fields(tag string) []DataField {
+	var fields []DataField
+	for _, f := range r.DataFields {
+		if f.Tag == tag {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// SetControl replaces the control field with tag or adds it.
+func (r *Record) SetControl(tag, value string) {
+	for i := range r.ControlFields {
+		if r.ControlFields[i].Tag == tag {
+			r.ControlFields[i].Value = value
+			return
+		}
+	}
+	r.ControlFields = append(r.ControlFields, ControlField{Tag: tag, Value: value})
+}
+
+// AddSubfield appends a subfield to the first field with tag, creating the
+// field with blank indicators when there is none. Fields are kept in tag
+// order.
+func (r *Record) AddSubfield(tag string, code byte, value string) {
+	for i := range r.DataFields {
+		if r.DataFields[i].Tag == tag {
+			r.DataFields[i].Subfields = append(r.DataFields[i].Subfields, Subfield{Code: code, Value: value})
+			return
+		}
+	}
+	r.AddField(DataField{Tag: tag, Ind1: ' ', Ind2: ' ', Subfields: []Subfield{{Code: code, Value: value}}})
+}
+
+// AddField inserts f after the last field with a tag not greater than its
+// own.
+func (r *Record) AddField(f DataField) {
+	i := len(r.DataFields)
+	for i > 0 && r.DataFields[i-1].Tag > f.Tag {
+		i--
+	}
+	r.DataFields = append(r.DataFields, DataField{})
+	copy(r.DataFields[i+1:], r.DataFields[i:])
+	r.DataFields[i] = f
+}
+
+// Subfield returns the value of the first subfield with code.
+func (f DataField) Subfield(code byte) string {
+	for _, sf := range f.Subfields {
+		if sf.Code == code {
+			return sf.Value
+		}
+	}
+	return ""
+}
+
+// SubfieldValues returns the values of every subfield with code.
+func (f DataField) SubfieldValues(code byte) []string {
+	var values []string
+	for _, sf := range f.Subfields {
+		if sf.Code == code {
+			values = append(values, sf.Value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,147 @@
+package marc
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// Namespace is the MARCXML namespace.
+const Namespace = "http://www.loc.gov/MARC21/slim"
+
+type xmlRecord struct {
+	XMLName       xml.Name          `xml:"record"`
+	Leader        string            `xml:"leader"`
+	ControlFields []xmlControlField `xml:"controlfield"`
+	DataFields    []xmlDataField    `xml:"datafield"`
+}
+
+type xmlControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlDataField struct {
+	Tag       string        `xml:"tag,attr"`
+	Ind1      string        `xml:"ind1,attr"`
+	Ind2      string        `xml:"ind2,attr"`
+	Subfields []xmlSubfield `xml:"subfield"`
+}
+
+type xmlSubfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// XMLReader reads the records of a MARCXML collection or a single MARCXML
+// record.
+type XMLReader struct {
+	d *xml.Decoder
+}
+
+func NewXMLReader(r io.Reader) *XMLReader {
+	return &XMLReader{d: xml.NewDecoder(r)}
+}
+
+// Read returns the next record and io.EOF after the last one. A record with
+// a malformed field is reported with an error wrapping ErrInvalidRecord and
+// skipped; XML syntax errors are final.
+func (r *XMLReader) Read() (*Record, error) {
+	for {
+		tok, err := r.d.Token()
+		if err != nil {
+			return nil, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "record" {
+			continue
+		}
+
+		var xr xmlRecord
+		if err := r.d.DecodeElement(&xr, &start); err != nil {
+			return nil, fmt.Errorf("marcxml: %w", err)
+		}
+		return xr.toRecord()
+	}
+}
+
+func (xr *xmlRecord) toRecord() (*Record, error) {
+	rec := &Record{Leader: xr.Leader}
+	for _, f := range xr.ControlFields {
+		rec.ControlFields = append(rec.ControlFields, ControlField{Tag: f.Tag, Value: f.Value})
+	}
+	for _, f := range xr.DataFields {
+		if len(f.Tag) != 3 {
+			return nil, fmt.Errorf("%w: bad tag %q", ErrInvalidRecord, f.Tag)
+		}
+		df := DataField{Tag: f.Tag, Ind1: firstByte(f.Ind1), Ind2: firstByte(f.Ind2)}
+		for _, sf := range f.Subfields {
+			if len(sf.Code) != 1 {
+				return nil, fmt.Errorf("%w: bad subfield code %q in %s", ErrInvalidRecord, sf.Code, f.Tag)
+			}
+			df.Subfields = append(df.Subfields, Subfield{Code: sf.Code[0], Value: sf.Value})
+		}
+		rec.DataFields = append(rec.DataFields, df)
+	}
+	return rec, nil
+}
+
+func firstByte(s string) byte {
+	if s == "" {
+		return ' '
+	}
+	return s[0]
+}
+
+// XMLWriter writes records as a MARCXML collection. Close must be called to
+// finish the document.
+type XMLWriter struct {
+	w       io.Writer
+	enc     *xml.Encoder
+	started bool
+}
+
+func NewXMLWriter(w io.Writer) *XMLWriter {
+	enc := xml.NewEncoder(w)
+	enc.Indent("  ", "  ")
+	return &XMLWriter{w: w, enc: enc}
+}
+
+func (w *XMLWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+	_, err := io.WriteString(w.w, xml.Header+`<collection xmlns="`+Namespace+`">`+"\n")
+	return err
+}
+
+func (w *XMLWriter) Write(rec *Record) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	xr := xmlRecord{Leader: rec.Leader}
+	for _, f := range rec.ControlFields {
+		xr.ControlFields = append(xr.ControlFields, xmlControlField{Tag: f.Tag, Value: f.Value})
+	}
+	for _, f := range rec.DataFields {
+		xf := xmlDataField{Tag: f.Tag, Ind1: string(indicator(f.Ind1)), Ind2: string(indicator(f.Ind2))}
+		for _, sf := range f.Subfields {
+			xf.Subfields = append(xf.Subfields, xmlSubfield{Code: string(sf.Code), Value: sf.Value})
+		}
+		xr.DataFields = append(xr.DataFields, xf)
+	}
+	return w.enc.Encode(xr)
+}
+
+func (w *XMLWriter) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	if err := w.enc.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w.w, "\n</collection>\n")
+	return err
+}
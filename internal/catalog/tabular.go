@@ -0,0 +1,313 @@
+package catalog
+
+import (
+	"bookService/internal/domain/models"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DefaultColumnMapping maps book fields to the CSV headers and JSON keys
+// they are read from. Names are compared ignoring case, with spaces and
+// hyphens taken as underscores.
+var DefaultColumnMapping = Mapping{
+	FieldTitle:           {"title"},
+	FieldAuthor:          {"author", "authors", "creator"},
+	FieldPublicationYear: {"publication_year", "year", "published"},
+	FieldGenre:           {"genre"},
+	FieldPublisher:       {"publisher"},
+	FieldLanguage:        {"language", "lang"},
+	FieldPageCount:       {"page_count", "pages"},
+	FieldEdition:         {"edition"},
+	FieldDescription:     {"description", "summary"},
+	FieldSubjects:        {"subjects", "subject", "tags"},
+	FieldSeriesName:      {"series_name", "series"},
+	FieldSeriesNumber:    {"series_number", "volume"},
+	FieldISBN:            {"isbn", "isbn13", "isbn_13", "isbn10", "isbn_10"},
+}
+
+// subjectSeparators split a subjects cell; commas are common inside
+// headings, so they are not one of them.
+const subjectSeparators = ";|"
+
+// WithColumns returns a copy of m in which the given columns, mapped from a
+// header or key to a book field, replace the configured sources of their
+// fields.
+func (m Mappings) WithColumns(columns map[string]string) (Mappings, error) {
+	if len(columns) == 0 {
+		return m, nil
+	}
+	merged := make(Mapping, len(m.Columns))
+	for field, sources := range m.Columns {
+		merged[field] = sources
+	}
+	overridden := make(map[string]bool, len(columns))
+	for column, field := range columns {
+		if !isField(field) {
+			return Mappings{}, fmt.Errorf("%w: unknown field %q for column %q", ErrBadMapping, field, column)
+		}
+		if !overridden[field] {
+			merged[field] = nil
+			overridden[field] = true
+		}
+		merged[field] = append(merged[field], column)
+	}
+	m.Columns = merged
+	return m, nil
+}
+
+func columnName(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(s)
+}
+
+// columnIndex resolves every field to the first of its sources present in
+// header.
+func columnIndex(header []string, m Mapping) map[string]int {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		name = columnName(name)
+		if _, ok := positions[name]; !ok {
+			positions[name] = i
+		}
+	}
+
+	index := make(map[string]int, len(m))
+	for field, sources := range m {
+		for _, source := range sources {
+			if i, ok := positions[columnName(source)]; ok {
+				index[field] = i
+				break
+			}
+		}
+	}
+	return index
+}
+
+// buildRowBook assembles a book from a spreadsheet row. Unlike catalog
+// records, rows are taken as they are: a malformed number fails the record
+// and every other value is left for validation to judge.
+func buildRowBook(lookup func(field string) []string) (*models.Book, error) {
+	first := func(field string) string {
+		for _, v := range lookup(field) {
+			if v = strings.TrimSpace(v); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+
+	book := &models.Book{
+		Title:       first(FieldTitle),
+		Author:      first(FieldAuthor),
+		Genre:       first(FieldGenre),
+		Publisher:   first(FieldPublisher),
+		Language:    first(FieldLanguage),
+		Edition:     first(FieldEdition),
+		Description: first(FieldDescription),
+		SeriesName:  first(FieldSeriesName),
+		ISBN:        first(FieldISBN),
+	}
+
+	var err error
+	if book.PublicationYear, err = parseNumber(FieldPublicationYear, first(FieldPublicationYear)); err != nil {
+		return nil, err
+	}
+	if book.PageCount, err = parseNumber(FieldPageCount, first(FieldPageCount)); err != nil {
+		return nil, err
+	}
+	if book.SeriesNumber, err = parseNumber(FieldSeriesNumber, first(FieldSeriesNumber)); err != nil {
+		return nil, err
+	}
+
+	for _, v := range lookup(FieldSubjects) {
+		for _, subject := range strings.FieldsFunc(v, func(r rune) bool {
+			return strings.ContainsRune(subjectSeparators, r)
+		}) {
+			if subject = strings.TrimSpace(subject); subject != "" {
+				book.Subjects = append(book.Subjects, subject)
+			}
+		}
+	}
+	return book, nil
+}
+
+func parseNumber(field, s string) (int32, error) {
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %q is not a whole number", field, s)
+	}
+	return int32(n), nil
+}
+
+type csvDecoder struct {
+	r      *csv.Reader
+	m      Mapping
+	index  map[string]int
+	header bool
+}
+
+func newCSVDecoder(r io.Reader, m Mapping) *csvDecoder {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	return &csvDecoder{r: cr, m: m}
+}
+
+func (d *csvDecoder) readHeader() error {
+	header, err := d.r.Read()
+	if err == io.EOF {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("csv: header: %w", err)
+	}
+	if len(header) > 0 {
+		// Spreadsheet programs like to start UTF-8 files with a BOM.
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	d.index = columnIndex(header, d.m)
+	for _, field := range []string{FieldTitle, FieldAuthor} {
+		if _, ok := d.index[field]; !ok {
+			return fmt.Errorf("csv: no column for %s among %q", field, header)
+		}
+	}
+	d.header = true
+	return nil
+}
+
+func (d *csvDecoder) Decode() (*models.Book, error) {
+	if !d.header {
+		if err := d.readHeader(); err != nil {
+			return nil, err
+		}
+	}
+
+	row, err := d.r.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, &RecordError{Err: err}
+		}
+		return nil, err
+	}
+
+	book, err := buildRowBook(func(field string) []string {
+		if i, ok := d.index[field]; ok && i < len(row) {
+			return []string{row[i]}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, &RecordError{Err: err}
+	}
+	return book, nil
+}
+
+// maxJSONLine bounds a single JSON Lines record.
+const maxJSONLine = 1 << 20
+
+type jsonLinesDecoder struct {
+	s *bufio.Scanner
+	m Mapping
+}
+
+func newJSONLinesDecoder(r io.Reader, m Mapping) *jsonLinesDecoder {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64<<10), maxJSONLine)
+	return &jsonLinesDecoder{s: s, m: m}
+}
+
+func (d *jsonLinesDecoder) Decode() (*models.Book, error) {
+	var line []byte
+	for len(line) == 0 {
+		if !d.s.Scan() {
+			if err := d.s.Err(); err != nil {
+				return nil, fmt.Errorf("jsonl: %w", err)
+			}
+			return nil, io.EOF
+		}
+		line = bytes.TrimSpace(d.s.Bytes())
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(line, &object); err != nil {
+		return nil, &RecordError{Err: fmt.Errorf("jsonl: %w", err)}
+	}
+	keys := make(map[string]json.RawMessage, len(object))
+	for key, value := range object {
+		keys[columnName(key)] = value
+	}
+
+	var valueErr error
+	book, err := buildRowBook(func(field string) []string {
+		for _, source := range d.m[field] {
+			raw, ok := keys[columnName(source)]
+			if !ok {
+				continue
+			}
+			values, err := jsonValues(raw)
+			if err != nil {
+				valueErr = fmt.Errorf("%s: %w", source, err)
+				return nil
+			}
+			return values
+		}
+		return nil
+	})
+	if err == nil {
+		err = valueErr
+	}
+	if err != nil {
+		return nil, &RecordError{Err: err}
+	}
+	return book, nil
+}
+
+// jsonValues accepts a string, a number, null or an array of those.
+func jsonValues(raw json.RawMessage) ([]string, error) {
+	var value interface{}
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	if err := d.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var values []string
+	var add func(v interface{}, nested bool) error
+	add = func(v interface{}, nested bool) error {
+		switch v := v.(type) {
+		case nil:
+		case string:
+			values = append(values, v)
+		case json.Number:
+			values = append(values, v.String())
+		case []interface{}:
+			if nested {
+				return errors.New("nested arrays are not supported")
+			}
+			for _, item := range v {
+				if err := add(item, true); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("unsupported value %s", raw)
+		}
+		return nil
+	}
+	if err := add(value, false); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
@@ -0,0 +1,199 @@
+package certs
+
+import (
+	"bookService/config"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// Reloader keeps the server certificate and client CA pool in memory and
+// re-reads them when the files on disk change, so rotated certificates are
+// picked up by new connections without a restart.
+type Reloader struct {
+	log *slog.Logger
+	cfg config.TLSConfig
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	clientCA *x509.CertPool
+	modTimes map[string]time.Time
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewReloader(log *slog.Logger, cfg config.TLSConfig) (*Reloader, error) {
+	const op = "certs.NewReloader"
+
+	r := &Reloader{
+		log:      log,
+		cfg:      cfg,
+		modTimes: make(map[string]time.Time),
+	}
+	if err := r.load(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return r, nil
+}
+
+// Start polls the certificate files for changes until Stop is called. It
+// does nothing when cfg.ReloadInterval is not positive: the certificates
+// loaded at startup are then kept.
+func (r *Reloader) Start() {
+	if r.cfg.ReloadInterval <= 0 {
+		r.log.Info("certificate reloading is disabled")
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+
+	go func() {
+		defer close(r.done)
+		r.watch(ctx)
+	}()
+}
+
+func (r *Reloader) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+		<-r.done
+	}
+}
+
+func (r *Reloader) watch(ctx context.Context) {
+	const op = "certs.watch"
+	log := r.log.With(slog.String("op", op))
+
+	ticker := time.NewTicker(r.cfg.ReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !r.changed() {
+			continue
+		}
+		if err := r.load(); err != nil {
+			log.Error("failed to reload certificates, keeping previous ones", slog.String("error", err.Error()))
+			continue
+		}
+		log.Info("certificates reloaded")
+	}
+}
+
+// ServerConfig returns a tls.Config that resolves the certificate and client
+// CA on every handshake.
+func (r *Reloader) ServerConfig() *tls.Config {
+	base := &tls.Config{MinVersion: tls.VersionTLS12}
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		cfg := &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{*r.cert},
+		}
+		if r.clientCA != nil {
+			cfg.ClientCAs = r.clientCA
+			cfg.ClientAuth = tls.VerifyClientCertIfGiven
+			if r.cfg.RequireClientCert {
+				cfg.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+		}
+		return cfg, nil
+	}
+	return base
+}
+
+// PinnedClientConfig returns a tls.Config for in-process clients such as the
+// HTTP gateway. It trusts exactly the certificate this server is currently
+// serving instead of a CA, which also tracks reloads.
+func (r *Reloader) PinnedClientConfig(clientCert *tls.Certificate) *tls.Config {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// Verification is done against the pinned certificate below.
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			if len(rawCerts) == 0 || len(r.cert.Certificate) == 0 {
+				return errors.New("no server certificate")
+			}
+			if string(rawCerts[0]) != string(r.cert.Certificate[0]) {
+				return errors.New("server certificate does not match pinned certificate")
+			}
+			return nil
+		},
+	}
+	if clientCert != nil {
+		cfg.Certificates = []tls.Certificate{*clientCert}
+	}
+	return cfg
+}
+
+func (r *Reloader) load() error {
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load key pair: %w", err)
+	}
+
+	var pool *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("read client ca: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.New("client ca file contains no certificates")
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCA = pool
+	for _, path := range r.files() {
+		if info, err := os.Stat(path); err == nil {
+			r.modTimes[path] = info.ModTime()
+		}
+	}
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *Reloader) changed() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, path := range r.files() {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if !info.ModTime().Equal(r.modTimes[path]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+	return files
+}
@@ -2,10 +2,16 @@ package interceptors
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"strings"
 )
 
 const (
@@ -13,34 +19,148 @@ const (
 	userRole  = "user_role"
 )
 
-func AuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	if isPublicMethod(info.FullMethod) {
-		return handler(ctx, req)
+// NewAuthInterceptor returns the auth interceptor. clientRoles maps the CN or
+// a SAN of a verified mTLS client certificate to a role; such callers are
+// authenticated by their certificate and don't need the x-user-role header.
+func NewAuthInterceptor(clientRoles map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		newCtx, err := authorize(ctx, info.FullMethod, clientRoles)
+		if err != nil {
+			return nil, err
+		}
+		return handler(newCtx, req)
 	}
+}
 
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return nil, status.Error(codes.Unauthenticated, "metadata is not provided")
+// NewAuthStreamInterceptor applies the same checks as NewAuthInterceptor to
+// streaming calls.
+func NewAuthStreamInterceptor(clientRoles map[string]string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		newCtx, err := authorize(ss.Context(), info.FullMethod, clientRoles)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: newCtx})
 	}
+}
+
+// callerCtxKey carries the caller identity recorded by withCaller.
+type callerCtxKey struct{}
+
+func authorize(ctx context.Context, method string, clientRoles map[string]string) (context.Context, error) {
+	ctx = withCaller(ctx)
+	if isPublicMethod(method) {
+		return ctx, nil
+	}
+
+	role, ok := peerRole(ctx, clientRoles)
+	if !ok {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "metadata is not provided")
+		}
 
-	roles := md.Get("x-user-role")
-	if len(roles) == 0 {
-		return nil, status.Error(codes.PermissionDenied, "role not provided")
+		roles := md.Get("x-user-role")
+		if len(roles) == 0 {
+			return nil, status.Error(codes.PermissionDenied, "role not provided")
+		}
+		role = roles[0]
 	}
 
-	if isAdminMethod(info.FullMethod) && roles[0] != adminRole {
+	if isAdminMethod(method) && role != adminRole {
 		return nil, status.Error(codes.PermissionDenied, "admin role required")
 	}
 
-	newCtx := context.WithValue(ctx, userRole, roles[0])
+	return context.WithValue(ctx, userRole, role), nil
+}
+
+// withCaller records whom the call is made for: the user named by
+// x-user-id or else the API key sent as x-api-key, which only a digest of
+// is kept. Like x-user-role, these come from the edge in front of the
+// service, which authenticates the caller.
+func withCaller(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	if ids := md.Get("x-user-id"); len(ids) > 0 && ids[0] != "" {
+		return context.WithValue(ctx, callerCtxKey{}, "user:"+ids[0])
+	}
+	if keys := md.Get("x-api-key"); len(keys) > 0 && keys[0] != "" {
+		sum := sha256.Sum256([]byte(keys[0]))
+		return context.WithValue(ctx, callerCtxKey{}, "key:"+hex.EncodeToString(sum[:8]))
+	}
+	return ctx
+}
+
+// authenticatedCaller returns the identity recorded by withCaller, if any.
+func authenticatedCaller(ctx context.Context) (string, bool) {
+	caller, ok := ctx.Value(callerCtxKey{}).(string)
+	return caller, ok
+}
+
+func peerRole(ctx context.Context, clientRoles map[string]string) (string, bool) {
+	if len(clientRoles) == 0 {
+		return "", false
+	}
+	cert := clientCert(ctx)
+	if cert == nil {
+		return "", false
+	}
+	if role, ok := clientRoles[cert.Subject.CommonName]; ok {
+		return role, true
+	}
+	for _, name := range cert.DNSNames {
+		if role, ok := clientRoles[name]; ok {
+			return role, true
+		}
+	}
+	for _, uri := range cert.URIs {
+		if role, ok := clientRoles[uri.String()]; ok {
+			return role, true
+		}
+	}
+	return "", false
+}
+
+// clientCert returns the client certificate verified during the TLS
+// handshake, or nil.
+func clientCert(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return tlsInfo.State.VerifiedChains[0][0]
+}
 
-	return handler(newCtx, req)
+// reflectionServices are the prefixes of the server reflection methods,
+// which only describe the API and are open so that tools like grpcurl can
+// list it.
+var reflectionServices = []string{
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
 }
 
 func isPublicMethod(method string) bool {
+	for _, prefix := range reflectionServices {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
 	publicMethods := []string{
 		"/bookService.BookService/GetBook",
 		"/bookService.BookService/ListBooks",
+		"/bookService.BookService/GetBookFacets",
+		"/bookService.BookService/GetCover",
+		"/bookService.BookService/ListSeries",
+		"/bookService.BookService/GetSeries",
+		"/bookService.BookService/GetShelf",
+		"/bookService.GenreService/ListGenres",
+		"/bookService.RecommendationService/GetSimilarBooks",
 	}
 	for _, m := range publicMethods {
 		if m == method {
@@ -53,8 +173,20 @@ func isPublicMethod(method string) bool {
 func isAdminMethod(method string) bool {
 	adminMethods := []string{
 		"/bookService.BookService/AddBook",
+		"/bookService.BookService/AddBooks",
 		"/bookService.BookService/UpdateBook",
 		"/bookService.BookService/DeleteBook",
+		"/bookService.BookService/UploadCover",
+		"/bookService.WebhookService/CreateWebhook",
+		"/bookService.WebhookService/ListWebhooks",
+		"/bookService.WebhookService/DeleteWebhook",
+		"/bookService.WebhookService/ListWebhookDeliveries",
+		"/bookService.GenreService/CreateGenre",
+		"/bookService.GenreService/UpdateGenre",
+		"/bookService.GenreService/DeleteGenre",
+		"/bookService.GenreService/SetBookGenres",
+		"/bookService.CatalogService/ImportCatalog",
+		"/bookService.CatalogService/ExportCatalog",
 	}
 	for _, m := range adminMethods {
 		if method == m {
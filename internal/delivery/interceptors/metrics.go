@@ -19,7 +19,26 @@ func MetricsInterceptor(
 	start := time.Now()
 
 	resp, err := handler(ctx, req)
+	observe(info.FullMethod, start, err)
 
+	return resp, err
+}
+
+func MetricsStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+
+	err := handler(srv, ss)
+	observe(info.FullMethod, start, err)
+
+	return err
+}
+
+func observe(methodName string, start time.Time, err error) {
 	statusCode := codes.OK.String()
 	if err != nil {
 		if st, ok := status.FromError(err); ok {
@@ -27,9 +46,6 @@ func MetricsInterceptor(
 		}
 	}
 
-	methodName := info.FullMethod
 	metrics.GRPCRequestsTotal.WithLabelValues(methodName, statusCode).Inc()
 	metrics.GRPCDuration.WithLabelValues(methodName).Observe(time.Since(start).Seconds())
-
-	return resp, err
 }
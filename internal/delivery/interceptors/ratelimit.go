@@ -0,0 +1,147 @@
+package interceptors
+
+import (
+	"bookService/config"
+	"bookService/internal/ratelimit"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const retryAfterHeader = "retry-after"
+
+// NewRateLimitInterceptor limits calls per caller and method. It must run
+// after the auth interceptor. Callers are identified by the user ID or API
+// key auth recorded, or else by their IP: for calls arriving from loopback,
+// which is how the HTTP gateway dials in, the IP of the gateway's HTTP
+// client. Only service clients calling over mTLS directly are keyed by
+// their certificate, so REST callers never share the gateway's.
+func NewRateLimitInterceptor(log *slog.Logger, limiter ratelimit.Limiter, cfg config.RateLimitConfig) grpc.UnaryServerInterceptor {
+	allow := newRateLimiter(log, limiter, cfg)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := allow(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// NewRateLimitStreamInterceptor counts each streaming call once, when it
+// starts.
+func NewRateLimitStreamInterceptor(log *slog.Logger, limiter ratelimit.Limiter, cfg config.RateLimitConfig) grpc.StreamServerInterceptor {
+	allow := newRateLimiter(log, limiter, cfg)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := allow(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func newRateLimiter(log *slog.Logger, limiter ratelimit.Limiter, cfg config.RateLimitConfig) func(ctx context.Context, method string) error {
+	methods := make(map[string]ratelimit.Limit, len(cfg.Methods))
+	for method, l := range cfg.Methods {
+		methods[method] = ratelimit.Limit{Rate: l.Rate, Burst: l.Burst}
+	}
+	def := ratelimit.Limit{Rate: cfg.Default.Rate, Burst: cfg.Default.Burst}
+
+	return func(ctx context.Context, method string) error {
+		limit, ok := methods[method]
+		if !ok {
+			limit = def
+		}
+		if limit.Rate <= 0 {
+			return nil
+		}
+
+		key := method + ":" + callerKey(ctx)
+		allowed, retryAfter, err := limiter.Allow(ctx, key, limit)
+		if err != nil {
+			// Failing open: an unavailable limiter must not take the API down.
+			log.Warn("rate limiter error", slog.String("method", method), slog.String("error", err.Error()))
+			return nil
+		}
+		if !allowed {
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			if seconds < 1 {
+				seconds = 1
+			}
+			_ = grpc.SetHeader(ctx, metadata.Pairs(retryAfterHeader, strconv.Itoa(seconds)))
+			return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry after %ds", seconds)
+		}
+		return nil
+	}
+}
+
+func callerKey(ctx context.Context) string {
+	if caller, ok := authenticatedCaller(ctx); ok {
+		return caller
+	}
+
+	ip, loopback := peerIP(ctx)
+	if loopback {
+		if fwd := forwardedIP(ctx); fwd != "" {
+			return "ip:" + fwd
+		}
+	} else if cert := clientCert(ctx); cert != nil {
+		sum := sha256.Sum256(cert.Raw)
+		return "cert:" + hex.EncodeToString(sum[:8])
+	}
+	if ip == "" {
+		return "unknown"
+	}
+	return "ip:" + ip
+}
+
+// clientIP returns the address of the caller, or for calls arriving from
+// loopback (the HTTP gateway) the address of its HTTP client.
+func clientIP(ctx context.Context) string {
+	ip, loopback := peerIP(ctx)
+	if loopback {
+		if fwd := forwardedIP(ctx); fwd != "" {
+			return fwd
+		}
+	}
+	return ip
+}
+
+// peerIP returns the address of the peer and whether it is loopback.
+func peerIP(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "", false
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	ip := net.ParseIP(host)
+	return host, ip != nil && ip.IsLoopback()
+}
+
+// forwardedIP returns the address the gateway appended to x-forwarded-for.
+// Only the last hop is used: the ones before it come from the client and
+// can be anything.
+func forwardedIP(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	fwd := md.Get("x-forwarded-for")
+	if len(fwd) == 0 || fwd[0] == "" {
+		return ""
+	}
+	hops := strings.Split(fwd[len(fwd)-1], ",")
+	return strings.TrimSpace(hops[len(hops)-1])
+}
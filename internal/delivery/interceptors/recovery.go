@@ -0,0 +1,67 @@
+package interceptors
+
+import (
+	"bookService/internal/logging"
+	"bookService/internal/metrics"
+	"bookService/internal/reporting"
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewRecoveryInterceptor turns a panic in the handlers chained after it into
+// an Internal error, instead of letting it take the process down. The panic
+// is logged with its stack, counted and passed to reporter, which may be nil.
+func NewRecoveryInterceptor(log *slog.Logger, reporter reporting.ErrorReporter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(ctx, log, reporter, info.FullMethod, p)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func NewRecoveryStreamInterceptor(log *slog.Logger, reporter reporting.ErrorReporter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(ss.Context(), log, reporter, info.FullMethod, p)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func recovered(ctx context.Context, log *slog.Logger, reporter reporting.ErrorReporter, method string, p interface{}) error {
+	stack := string(debug.Stack())
+	message := fmt.Sprint(p)
+
+	log = logging.FromContext(ctx, log)
+	log.Error("panic recovered",
+		slog.String("panic", message),
+		slog.String("stack", stack),
+	)
+	metrics.GRPCPanicsTotal.WithLabelValues(method).Inc()
+
+	if reporter != nil {
+		err := reporter.Report(ctx, reporting.Event{
+			Time:      time.Now().UTC(),
+			Method:    method,
+			RequestID: logging.RequestID(ctx),
+			Message:   message,
+			Stack:     stack,
+		})
+		if err != nil {
+			log.Warn("failed to report panic", slog.String("error", err.Error()))
+		}
+	}
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,172 @@
+package interceptors
+
+import (
+	"bookService/config"
+	"bookService/internal/logging"
+	"bookService/internal/tracing"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	RequestIDHeader = "x-request-id"
+
+	maxRequestIDLength = 128
+	redacted           = "[REDACTED]"
+)
+
+// NewRequestInterceptor gives every call a request ID, taken from the
+// x-request-id header when the caller sent a usable one, and returns it in
+// the response headers. Handlers find a logger carrying the ID through
+// logging.FromContext. Once the call is over it logs an access line.
+func NewRequestInterceptor(log *slog.Logger, cfg config.AccessLogConfig) grpc.UnaryServerInterceptor {
+	redact := redactedFields(cfg.Redact)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		ctx, reqLog := startRequest(ctx, log, info.FullMethod)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, logging.RequestID(ctx)))
+
+		resp, err := handler(ctx, req)
+
+		if cfg.Enabled {
+			attrs := accessAttrs(ctx, start, userID(ctx, req), err)
+			if msg, ok := req.(proto.Message); ok && cfg.Payloads {
+				attrs = append(attrs, slog.Any("request", redactMessage(msg, redact)))
+			}
+			reqLog.LogAttrs(ctx, accessLevel(err), "request finished", attrs...)
+		}
+		return resp, err
+	}
+}
+
+// NewRequestStreamInterceptor is NewRequestInterceptor for streaming calls,
+// whose messages are never logged.
+func NewRequestStreamInterceptor(log *slog.Logger, cfg config.AccessLogConfig) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, reqLog := startRequest(ss.Context(), log, info.FullMethod)
+		_ = ss.SetHeader(metadata.Pairs(RequestIDHeader, logging.RequestID(ctx)))
+
+		err := handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+
+		if cfg.Enabled {
+			attrs := accessAttrs(ctx, start, userID(ctx, nil), err)
+			reqLog.LogAttrs(ctx, accessLevel(err), "request finished", attrs...)
+		}
+		return err
+	}
+}
+
+func startRequest(ctx context.Context, log *slog.Logger, method string) (context.Context, *slog.Logger) {
+	id := incomingRequestID(ctx)
+	if id == "" {
+		id = uuid.NewString()
+	}
+	reqLog := tracing.Logger(ctx, log).With(
+		slog.String("request_id", id),
+		slog.String("method", method),
+	)
+	ctx = logging.WithRequestID(ctx, id)
+	return logging.WithLogger(ctx, reqLog), reqLog
+}
+
+// incomingRequestID returns the request ID sent by the caller, unless it is
+// too long or holds anything but printable ASCII, as it ends up in logs.
+func incomingRequestID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	ids := md.Get(RequestIDHeader)
+	if len(ids) == 0 || len(ids[0]) > maxRequestIDLength {
+		return ""
+	}
+	for _, c := range ids[0] {
+		if c <= ' ' || c > '~' {
+			return ""
+		}
+	}
+	return ids[0]
+}
+
+// userID identifies the caller by the x-user-id header, or else by the
+// user_id field of the request.
+func userID(ctx context.Context, req interface{}) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get("x-user-id"); len(ids) > 0 && ids[0] != "" {
+			return ids[0]
+		}
+	}
+	if r, ok := req.(interface{ GetUserId() string }); ok {
+		return r.GetUserId()
+	}
+	return ""
+}
+
+func accessAttrs(ctx context.Context, start time.Time, user string, err error) []slog.Attr {
+	return []slog.Attr{
+		slog.String("code", status.Code(err).String()),
+		slog.Duration("latency", time.Since(start)),
+		slog.String("peer", clientIP(ctx)),
+		slog.String("user_id", user),
+	}
+}
+
+// accessLevel logs calls that failed on the server side as errors.
+func accessLevel(err error) slog.Level {
+	switch status.Code(err) {
+	case codes.Internal, codes.Unknown, codes.DataLoss:
+		return slog.LevelError
+	}
+	return slog.LevelInfo
+}
+
+func redactedFields(fields []string) map[string]bool {
+	set := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		set[f] = true
+	}
+	return set
+}
+
+// redactMessage returns msg as generic JSON values with the redacted fields
+// masked at any depth.
+func redactMessage(msg proto.Message, redact map[string]bool) interface{} {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil
+	}
+	return redactValue(v, redact)
+}
+
+func redactValue(v interface{}, redact map[string]bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if redact[k] {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactValue(field, redact)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i], redact)
+		}
+	}
+	return v
+}
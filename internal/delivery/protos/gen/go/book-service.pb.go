@@ -7,8 +7,11 @@
 package gen
 
 import (
+	_ "bookService/internal/delivery/protos/gen/go/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,32 +24,3903 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type BookSortField int32
+
+const (
+	BookSortField_BOOK_SORT_FIELD_UNSPECIFIED      BookSortField = 0
+	BookSortField_BOOK_SORT_FIELD_TITLE            BookSortField = 1
+	BookSortField_BOOK_SORT_FIELD_AUTHOR           BookSortField = 2
+	BookSortField_BOOK_SORT_FIELD_PUBLICATION_YEAR BookSortField = 3
+	BookSortField_BOOK_SORT_FIELD_CREATED_AT       BookSortField = 4
+	BookSortField_BOOK_SORT_FIELD_RATING           BookSortField = 5
+)
+
+// Enum value maps for BookSortField.
+var (
+	BookSortField_name = map[int32]string{
+		0: "BOOK_SORT_FIELD_UNSPECIFIED",
+		1: "BOOK_SORT_FIELD_TITLE",
+		2: "BOOK_SORT_FIELD_AUTHOR",
+		3: "BOOK_SORT_FIELD_PUBLICATION_YEAR",
+		4: "BOOK_SORT_FIELD_CREATED_AT",
+		5: "BOOK_SORT_FIELD_RATING",
+	}
+	BookSortField_value = map[string]int32{
+		"BOOK_SORT_FIELD_UNSPECIFIED":      0,
+		"BOOK_SORT_FIELD_TITLE":            1,
+		"BOOK_SORT_FIELD_AUTHOR":           2,
+		"BOOK_SORT_FIELD_PUBLICATION_YEAR": 3,
+		"BOOK_SORT_FIELD_CREATED_AT":       4,
+		"BOOK_SORT_FIELD_RATING":           5,
+	}
+)
+
+func (x BookSortField) Enum() *BookSortField {
+	p := new(BookSortField)
+	*p = x
+	return p
+}
+
+func (x BookSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BookSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_book_service_proto_enumTypes[0].Descriptor()
+}
+
+func (BookSortField) Type() protoreflect.EnumType {
+	return &file_book_service_proto_enumTypes[0]
+}
+
+func (x BookSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BookSortField.Descriptor instead.
+func (BookSortField) EnumDescriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{0}
+}
+
+type SortDirection int32
+
+const (
+	SortDirection_SORT_DIRECTION_UNSPECIFIED SortDirection = 0
+	SortDirection_SORT_DIRECTION_ASC         SortDirection = 1
+	SortDirection_SORT_DIRECTION_DESC        SortDirection = 2
+)
+
+// Enum value maps for SortDirection.
+var (
+	SortDirection_name = map[int32]string{
+		0: "SORT_DIRECTION_UNSPECIFIED",
+		1: "SORT_DIRECTION_ASC",
+		2: "SORT_DIRECTION_DESC",
+	}
+	SortDirection_value = map[string]int32{
+		"SORT_DIRECTION_UNSPECIFIED": 0,
+		"SORT_DIRECTION_ASC":         1,
+		"SORT_DIRECTION_DESC":        2,
+	}
+)
+
+func (x SortDirection) Enum() *SortDirection {
+	p := new(SortDirection)
+	*p = x
+	return p
+}
+
+func (x SortDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_book_service_proto_enumTypes[1].Descriptor()
+}
+
+func (SortDirection) Type() protoreflect.EnumType {
+	return &file_book_service_proto_enumTypes[1]
+}
+
+func (x SortDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortDirection.Descriptor instead.
+func (SortDirection) EnumDescriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{1}
+}
+
+type ShelfVisibility int32
+
+const (
+	// Same as SHELF_VISIBILITY_PRIVATE.
+	ShelfVisibility_SHELF_VISIBILITY_UNSPECIFIED ShelfVisibility = 0
+	ShelfVisibility_SHELF_VISIBILITY_PRIVATE     ShelfVisibility = 1
+	// Readable through GetShelf with the share token.
+	ShelfVisibility_SHELF_VISIBILITY_LINK   ShelfVisibility = 2
+	ShelfVisibility_SHELF_VISIBILITY_PUBLIC ShelfVisibility = 3
+)
+
+// Enum value maps for ShelfVisibility.
+var (
+	ShelfVisibility_name = map[int32]string{
+		0: "SHELF_VISIBILITY_UNSPECIFIED",
+		1: "SHELF_VISIBILITY_PRIVATE",
+		2: "SHELF_VISIBILITY_LINK",
+		3: "SHELF_VISIBILITY_PUBLIC",
+	}
+	ShelfVisibility_value = map[string]int32{
+		"SHELF_VISIBILITY_UNSPECIFIED": 0,
+		"SHELF_VISIBILITY_PRIVATE":     1,
+		"SHELF_VISIBILITY_LINK":        2,
+		"SHELF_VISIBILITY_PUBLIC":      3,
+	}
+)
+
+func (x ShelfVisibility) Enum() *ShelfVisibility {
+	p := new(ShelfVisibility)
+	*p = x
+	return p
+}
+
+func (x ShelfVisibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ShelfVisibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_book_service_proto_enumTypes[2].Descriptor()
+}
+
+func (ShelfVisibility) Type() protoreflect.EnumType {
+	return &file_book_service_proto_enumTypes[2]
+}
+
+func (x ShelfVisibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ShelfVisibility.Descriptor instead.
+func (ShelfVisibility) EnumDescriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{2}
+}
+
+type CatalogFormat int32
+
+const (
+	CatalogFormat_CATALOG_FORMAT_UNSPECIFIED CatalogFormat = 0
+	// ISO 2709 transmission format.
+	CatalogFormat_CATALOG_FORMAT_MARC21  CatalogFormat = 1
+	CatalogFormat_CATALOG_FORMAT_MARCXML CatalogFormat = 2
+	// Simple Dublin Core in the oai_dc schema.
+	CatalogFormat_CATALOG_FORMAT_DUBLIN_CORE CatalogFormat = 3
+	// Comma-separated values with a header row. Import only.
+	CatalogFormat_CATALOG_FORMAT_CSV CatalogFormat = 4
+	// One JSON object per line. Import only.
+	CatalogFormat_CATALOG_FORMAT_JSONL CatalogFormat = 5
+)
+
+// Enum value maps for CatalogFormat.
+var (
+	CatalogFormat_name = map[int32]string{
+		0: "CATALOG_FORMAT_UNSPECIFIED",
+		1: "CATALOG_FORMAT_MARC21",
+		2: "CATALOG_FORMAT_MARCXML",
+		3: "CATALOG_FORMAT_DUBLIN_CORE",
+		4: "CATALOG_FORMAT_CSV",
+		5: "CATALOG_FORMAT_JSONL",
+	}
+	CatalogFormat_value = map[string]int32{
+		"CATALOG_FORMAT_UNSPECIFIED": 0,
+		"CATALOG_FORMAT_MARC21":      1,
+		"CATALOG_FORMAT_MARCXML":     2,
+		"CATALOG_FORMAT_DUBLIN_CORE": 3,
+		"CATALOG_FORMAT_CSV":         4,
+		"CATALOG_FORMAT_JSONL":       5,
+	}
+)
+
+func (x CatalogFormat) Enum() *CatalogFormat {
+	p := new(CatalogFormat)
+	*p = x
+	return p
+}
+
+func (x CatalogFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CatalogFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_book_service_proto_enumTypes[3].Descriptor()
+}
+
+func (CatalogFormat) Type() protoreflect.EnumType {
+	return &file_book_service_proto_enumTypes[3]
+}
+
+func (x CatalogFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CatalogFormat.Descriptor instead.
+func (CatalogFormat) EnumDescriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{3}
+}
+
+// DuplicatePolicy says what an import does with a record that matches a
+// book already in the catalog.
+type DuplicatePolicy int32
+
+const (
+	// Same as DUPLICATE_POLICY_SKIP.
+	DuplicatePolicy_DUPLICATE_POLICY_UNSPECIFIED DuplicatePolicy = 0
+	DuplicatePolicy_DUPLICATE_POLICY_SKIP        DuplicatePolicy = 1
+	// Overwrite the fields the record sets; the others are kept.
+	DuplicatePolicy_DUPLICATE_POLICY_UPDATE DuplicatePolicy = 2
+)
+
+// Enum value maps for DuplicatePolicy.
+var (
+	DuplicatePolicy_name = map[int32]string{
+		0: "DUPLICATE_POLICY_UNSPECIFIED",
+		1: "DUPLICATE_POLICY_SKIP",
+		2: "DUPLICATE_POLICY_UPDATE",
+	}
+	DuplicatePolicy_value = map[string]int32{
+		"DUPLICATE_POLICY_UNSPECIFIED": 0,
+		"DUPLICATE_POLICY_SKIP":        1,
+		"DUPLICATE_POLICY_UPDATE":      2,
+	}
+)
+
+func (x DuplicatePolicy) Enum() *DuplicatePolicy {
+	p := new(DuplicatePolicy)
+	*p = x
+	return p
+}
+
+func (x DuplicatePolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DuplicatePolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_book_service_proto_enumTypes[4].Descriptor()
+}
+
+func (DuplicatePolicy) Type() protoreflect.EnumType {
+	return &file_book_service_proto_enumTypes[4]
+}
+
+func (x DuplicatePolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DuplicatePolicy.Descriptor instead.
+func (DuplicatePolicy) EnumDescriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{4}
+}
+
+type RecommendationReason int32
+
+const (
+	RecommendationReason_RECOMMENDATION_REASON_UNSPECIFIED RecommendationReason = 0
+	// Readers who shelved the book, or the user's books, also shelved this.
+	RecommendationReason_RECOMMENDATION_REASON_CO_SHELVED  RecommendationReason = 1
+	RecommendationReason_RECOMMENDATION_REASON_SAME_AUTHOR RecommendationReason = 2
+	RecommendationReason_RECOMMENDATION_REASON_SAME_GENRE  RecommendationReason = 3
+	// Among the most shelved books, for users and books with nothing to go on.
+	RecommendationReason_RECOMMENDATION_REASON_POPULAR RecommendationReason = 4
+)
+
+// Enum value maps for RecommendationReason.
+var (
+	RecommendationReason_name = map[int32]string{
+		0: "RECOMMENDATION_REASON_UNSPECIFIED",
+		1: "RECOMMENDATION_REASON_CO_SHELVED",
+		2: "RECOMMENDATION_REASON_SAME_AUTHOR",
+		3: "RECOMMENDATION_REASON_SAME_GENRE",
+		4: "RECOMMENDATION_REASON_POPULAR",
+	}
+	RecommendationReason_value = map[string]int32{
+		"RECOMMENDATION_REASON_UNSPECIFIED": 0,
+		"RECOMMENDATION_REASON_CO_SHELVED":  1,
+		"RECOMMENDATION_REASON_SAME_AUTHOR": 2,
+		"RECOMMENDATION_REASON_SAME_GENRE":  3,
+		"RECOMMENDATION_REASON_POPULAR":     4,
+	}
+)
+
+func (x RecommendationReason) Enum() *RecommendationReason {
+	p := new(RecommendationReason)
+	*p = x
+	return p
+}
+
+func (x RecommendationReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecommendationReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_book_service_proto_enumTypes[5].Descriptor()
+}
+
+func (RecommendationReason) Type() protoreflect.EnumType {
+	return &file_book_service_proto_enumTypes[5]
+}
+
+func (x RecommendationReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecommendationReason.Descriptor instead.
+func (RecommendationReason) EnumDescriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{5}
+}
+
 type Book struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	BookId          string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
 	Title           string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
 	Author          string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
 	PublicationYear *int32                 `protobuf:"varint,4,opt,name=publication_year,json=publicationYear,proto3,oneof" json:"publication_year,omitempty"`
-	Genre           *string                `protobuf:"bytes,5,opt,name=genre,proto3,oneof" json:"genre,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Primary genre.
+	Genre *string `protobuf:"bytes,5,opt,name=genre,proto3,oneof" json:"genre,omitempty"`
+	// Every genre the book is tagged with, including the primary one.
+	Genres    []string               `protobuf:"bytes,6,rep,name=genres,proto3" json:"genres,omitempty"`
+	Rating    *float64               `protobuf:"fixed64,7,opt,name=rating,proto3,oneof" json:"rating,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// HTTP paths of the cover image and its thumbnail, set when the book has
+	// a cover.
+	CoverUrl     *string `protobuf:"bytes,9,opt,name=cover_url,json=coverUrl,proto3,oneof" json:"cover_url,omitempty"`
+	ThumbnailUrl *string `protobuf:"bytes,10,opt,name=thumbnail_url,json=thumbnailUrl,proto3,oneof" json:"thumbnail_url,omitempty"`
+	Publisher    *string `protobuf:"bytes,11,opt,name=publisher,proto3,oneof" json:"publisher,omitempty"`
+	// BCP-47 language tag, e.g. "en" or "pt-BR".
+	Language     *string  `protobuf:"bytes,12,opt,name=language,proto3,oneof" json:"language,omitempty"`
+	PageCount    *int32   `protobuf:"varint,13,opt,name=page_count,json=pageCount,proto3,oneof" json:"page_count,omitempty"`
+	Edition      *string  `protobuf:"bytes,14,opt,name=edition,proto3,oneof" json:"edition,omitempty"`
+	Description  *string  `protobuf:"bytes,15,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Subjects     []string `protobuf:"bytes,16,rep,name=subjects,proto3" json:"subjects,omitempty"`
+	SeriesName   *string  `protobuf:"bytes,17,opt,name=series_name,json=seriesName,proto3,oneof" json:"series_name,omitempty"`
+	SeriesNumber *int32   `protobuf:"varint,18,opt,name=series_number,json=seriesNumber,proto3,oneof" json:"series_number,omitempty"`
+	SeriesId     *string  `protobuf:"bytes,19,opt,name=series_id,json=seriesId,proto3,oneof" json:"series_id,omitempty"`
+	// Normalized ISBN-13.
+	Isbn          *string `protobuf:"bytes,20,opt,name=isbn,proto3,oneof" json:"isbn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Book) Reset() {
+	*x = Book{}
+	mi := &file_book_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Book) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Book) ProtoMessage() {}
+
+func (x *Book) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Book.ProtoReflect.Descriptor instead.
+func (*Book) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Book) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+func (x *Book) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Book) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Book) GetPublicationYear() int32 {
+	if x != nil && x.PublicationYear != nil {
+		return *x.PublicationYear
+	}
+	return 0
+}
+
+func (x *Book) GetGenre() string {
+	if x != nil && x.Genre != nil {
+		return *x.Genre
+	}
+	return ""
+}
+
+func (x *Book) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *Book) GetRating() float64 {
+	if x != nil && x.Rating != nil {
+		return *x.Rating
+	}
+	return 0
+}
+
+func (x *Book) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Book) GetCoverUrl() string {
+	if x != nil && x.CoverUrl != nil {
+		return *x.CoverUrl
+	}
+	return ""
+}
+
+func (x *Book) GetThumbnailUrl() string {
+	if x != nil && x.ThumbnailUrl != nil {
+		return *x.ThumbnailUrl
+	}
+	return ""
+}
+
+func (x *Book) GetPublisher() string {
+	if x != nil && x.Publisher != nil {
+		return *x.Publisher
+	}
+	return ""
+}
+
+func (x *Book) GetLanguage() string {
+	if x != nil && x.Language != nil {
+		return *x.Language
+	}
+	return ""
+}
+
+func (x *Book) GetPageCount() int32 {
+	if x != nil && x.PageCount != nil {
+		return *x.PageCount
+	}
+	return 0
+}
+
+func (x *Book) GetEdition() string {
+	if x != nil && x.Edition != nil {
+		return *x.Edition
+	}
+	return ""
+}
+
+func (x *Book) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Book) GetSubjects() []string {
+	if x != nil {
+		return x.Subjects
+	}
+	return nil
+}
+
+func (x *Book) GetSeriesName() string {
+	if x != nil && x.SeriesName != nil {
+		return *x.SeriesName
+	}
+	return ""
+}
+
+func (x *Book) GetSeriesNumber() int32 {
+	if x != nil && x.SeriesNumber != nil {
+		return *x.SeriesNumber
+	}
+	return 0
+}
+
+func (x *Book) GetSeriesId() string {
+	if x != nil && x.SeriesId != nil {
+		return *x.SeriesId
+	}
+	return ""
+}
+
+func (x *Book) GetIsbn() string {
+	if x != nil && x.Isbn != nil {
+		return *x.Isbn
+	}
+	return ""
+}
+
+type AddBookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Limits match the column sizes of the books table.
+	Title           string   `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Author          string   `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	PublicationYear *int32   `protobuf:"varint,3,opt,name=publication_year,json=publicationYear,proto3,oneof" json:"publication_year,omitempty"`
+	Genre           *string  `protobuf:"bytes,4,opt,name=genre,proto3,oneof" json:"genre,omitempty"`
+	Rating          *float64 `protobuf:"fixed64,5,opt,name=rating,proto3,oneof" json:"rating,omitempty"`
+	Publisher       *string  `protobuf:"bytes,6,opt,name=publisher,proto3,oneof" json:"publisher,omitempty"`
+	Language        *string  `protobuf:"bytes,7,opt,name=language,proto3,oneof" json:"language,omitempty"`
+	PageCount       *int32   `protobuf:"varint,8,opt,name=page_count,json=pageCount,proto3,oneof" json:"page_count,omitempty"`
+	Edition         *string  `protobuf:"bytes,9,opt,name=edition,proto3,oneof" json:"edition,omitempty"`
+	Description     *string  `protobuf:"bytes,10,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Subjects        []string `protobuf:"bytes,11,rep,name=subjects,proto3" json:"subjects,omitempty"`
+	SeriesName      *string  `protobuf:"bytes,12,opt,name=series_name,json=seriesName,proto3,oneof" json:"series_name,omitempty"`
+	SeriesNumber    *int32   `protobuf:"varint,13,opt,name=series_number,json=seriesNumber,proto3,oneof" json:"series_number,omitempty"`
+	// ISBN-10 or ISBN-13, with or without hyphens; stored as ISBN-13.
+	Isbn          *string `protobuf:"bytes,14,opt,name=isbn,proto3,oneof" json:"isbn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddBookRequest) Reset() {
+	*x = AddBookRequest{}
+	mi := &file_book_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBookRequest) ProtoMessage() {}
+
+func (x *AddBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBookRequest.ProtoReflect.Descriptor instead.
+func (*AddBookRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AddBookRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *AddBookRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *AddBookRequest) GetPublicationYear() int32 {
+	if x != nil && x.PublicationYear != nil {
+		return *x.PublicationYear
+	}
+	return 0
+}
+
+func (x *AddBookRequest) GetGenre() string {
+	if x != nil && x.Genre != nil {
+		return *x.Genre
+	}
+	return ""
+}
+
+func (x *AddBookRequest) GetRating() float64 {
+	if x != nil && x.Rating != nil {
+		return *x.Rating
+	}
+	return 0
+}
+
+func (x *AddBookRequest) GetPublisher() string {
+	if x != nil && x.Publisher != nil {
+		return *x.Publisher
+	}
+	return ""
+}
+
+func (x *AddBookRequest) GetLanguage() string {
+	if x != nil && x.Language != nil {
+		return *x.Language
+	}
+	return ""
+}
+
+func (x *AddBookRequest) GetPageCount() int32 {
+	if x != nil && x.PageCount != nil {
+		return *x.PageCount
+	}
+	return 0
+}
+
+func (x *AddBookRequest) GetEdition() string {
+	if x != nil && x.Edition != nil {
+		return *x.Edition
+	}
+	return ""
+}
+
+func (x *AddBookRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *AddBookRequest) GetSubjects() []string {
+	if x != nil {
+		return x.Subjects
+	}
+	return nil
+}
+
+func (x *AddBookRequest) GetSeriesName() string {
+	if x != nil && x.SeriesName != nil {
+		return *x.SeriesName
+	}
+	return ""
+}
+
+func (x *AddBookRequest) GetSeriesNumber() int32 {
+	if x != nil && x.SeriesNumber != nil {
+		return *x.SeriesNumber
+	}
+	return 0
+}
+
+func (x *AddBookRequest) GetIsbn() string {
+	if x != nil && x.Isbn != nil {
+		return *x.Isbn
+	}
+	return ""
+}
+
+type AddBooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Books         []*AddBookRequest      `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddBooksRequest) Reset() {
+	*x = AddBooksRequest{}
+	mi := &file_book_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBooksRequest) ProtoMessage() {}
+
+func (x *AddBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBooksRequest.ProtoReflect.Descriptor instead.
+func (*AddBooksRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AddBooksRequest) GetBooks() []*AddBookRequest {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+type AddBooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Books         []*Book                `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddBooksResponse) Reset() {
+	*x = AddBooksResponse{}
+	mi := &file_book_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBooksResponse) ProtoMessage() {}
+
+func (x *AddBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBooksResponse.ProtoReflect.Descriptor instead.
+func (*AddBooksResponse) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AddBooksResponse) GetBooks() []*Book {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+type GetBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookRequest) Reset() {
+	*x = GetBookRequest{}
+	mi := &file_book_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookRequest) ProtoMessage() {}
+
+func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookRequest.ProtoReflect.Descriptor instead.
+func (*GetBookRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetBookRequest) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+type UpdateBookRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BookId          string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	Title           *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Author          *string                `protobuf:"bytes,3,opt,name=author,proto3,oneof" json:"author,omitempty"`
+	PublicationYear *int32                 `protobuf:"varint,4,opt,name=publication_year,json=publicationYear,proto3,oneof" json:"publication_year,omitempty"`
+	Genre           *string                `protobuf:"bytes,5,opt,name=genre,proto3,oneof" json:"genre,omitempty"`
+	Rating          *float64               `protobuf:"fixed64,6,opt,name=rating,proto3,oneof" json:"rating,omitempty"`
+	Publisher       *string                `protobuf:"bytes,7,opt,name=publisher,proto3,oneof" json:"publisher,omitempty"`
+	Language        *string                `protobuf:"bytes,8,opt,name=language,proto3,oneof" json:"language,omitempty"`
+	PageCount       *int32                 `protobuf:"varint,9,opt,name=page_count,json=pageCount,proto3,oneof" json:"page_count,omitempty"`
+	Edition         *string                `protobuf:"bytes,10,opt,name=edition,proto3,oneof" json:"edition,omitempty"`
+	Description     *string                `protobuf:"bytes,11,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Subjects        []string               `protobuf:"bytes,12,rep,name=subjects,proto3" json:"subjects,omitempty"`
+	// Replace subjects with the list above, which may be empty.
+	SetSubjects   bool    `protobuf:"varint,13,opt,name=set_subjects,json=setSubjects,proto3" json:"set_subjects,omitempty"`
+	SeriesName    *string `protobuf:"bytes,14,opt,name=series_name,json=seriesName,proto3,oneof" json:"series_name,omitempty"`
+	SeriesNumber  *int32  `protobuf:"varint,15,opt,name=series_number,json=seriesNumber,proto3,oneof" json:"series_number,omitempty"`
+	Isbn          *string `protobuf:"bytes,16,opt,name=isbn,proto3,oneof" json:"isbn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBookRequest) Reset() {
+	*x = UpdateBookRequest{}
+	mi := &file_book_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBookRequest) ProtoMessage() {}
+
+func (x *UpdateBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBookRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBookRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateBookRequest) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetAuthor() string {
+	if x != nil && x.Author != nil {
+		return *x.Author
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetPublicationYear() int32 {
+	if x != nil && x.PublicationYear != nil {
+		return *x.PublicationYear
+	}
+	return 0
+}
+
+func (x *UpdateBookRequest) GetGenre() string {
+	if x != nil && x.Genre != nil {
+		return *x.Genre
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetRating() float64 {
+	if x != nil && x.Rating != nil {
+		return *x.Rating
+	}
+	return 0
+}
+
+func (x *UpdateBookRequest) GetPublisher() string {
+	if x != nil && x.Publisher != nil {
+		return *x.Publisher
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetLanguage() string {
+	if x != nil && x.Language != nil {
+		return *x.Language
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetPageCount() int32 {
+	if x != nil && x.PageCount != nil {
+		return *x.PageCount
+	}
+	return 0
+}
+
+func (x *UpdateBookRequest) GetEdition() string {
+	if x != nil && x.Edition != nil {
+		return *x.Edition
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetSubjects() []string {
+	if x != nil {
+		return x.Subjects
+	}
+	return nil
+}
+
+func (x *UpdateBookRequest) GetSetSubjects() bool {
+	if x != nil {
+		return x.SetSubjects
+	}
+	return false
+}
+
+func (x *UpdateBookRequest) GetSeriesName() string {
+	if x != nil && x.SeriesName != nil {
+		return *x.SeriesName
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetSeriesNumber() int32 {
+	if x != nil && x.SeriesNumber != nil {
+		return *x.SeriesNumber
+	}
+	return 0
+}
+
+func (x *UpdateBookRequest) GetIsbn() string {
+	if x != nil && x.Isbn != nil {
+		return *x.Isbn
+	}
+	return ""
+}
+
+type DeleteBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBookRequest) Reset() {
+	*x = DeleteBookRequest{}
+	mi := &file_book_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBookRequest) ProtoMessage() {}
+
+func (x *DeleteBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBookRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteBookRequest) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+type ListBooksRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Author          *string                `protobuf:"bytes,1,opt,name=author,proto3,oneof" json:"author,omitempty"`
+	PublicationYear *int32                 `protobuf:"varint,2,opt,name=publication_year,json=publicationYear,proto3,oneof" json:"publication_year,omitempty"`
+	Genre           *string                `protobuf:"bytes,3,opt,name=genre,proto3,oneof" json:"genre,omitempty"`
+	// Books matching any of the listed values; combined with the single-value
+	// filter above.
+	Authors []string `protobuf:"bytes,4,rep,name=authors,proto3" json:"authors,omitempty"`
+	Genres  []string `protobuf:"bytes,5,rep,name=genres,proto3" json:"genres,omitempty"`
+	// Inclusive publication year range.
+	PublicationYearFrom *int32 `protobuf:"varint,6,opt,name=publication_year_from,json=publicationYearFrom,proto3,oneof" json:"publication_year_from,omitempty"`
+	PublicationYearTo   *int32 `protobuf:"varint,7,opt,name=publication_year_to,json=publicationYearTo,proto3,oneof" json:"publication_year_to,omitempty"`
+	// Case-insensitive title prefix.
+	TitlePrefix   *string       `protobuf:"bytes,8,opt,name=title_prefix,json=titlePrefix,proto3,oneof" json:"title_prefix,omitempty"`
+	SortBy        BookSortField `protobuf:"varint,9,opt,name=sort_by,json=sortBy,proto3,enum=bookService.BookSortField" json:"sort_by,omitempty"`
+	SortDirection SortDirection `protobuf:"varint,10,opt,name=sort_direction,json=sortDirection,proto3,enum=bookService.SortDirection" json:"sort_direction,omitempty"`
+	// Matches the tag and its subtags: "en" also matches "en-GB".
+	Language      *string `protobuf:"bytes,11,opt,name=language,proto3,oneof" json:"language,omitempty"`
+	Series        *string `protobuf:"bytes,12,opt,name=series,proto3,oneof" json:"series,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBooksRequest) Reset() {
+	*x = ListBooksRequest{}
+	mi := &file_book_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBooksRequest) ProtoMessage() {}
+
+func (x *ListBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBooksRequest.ProtoReflect.Descriptor instead.
+func (*ListBooksRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListBooksRequest) GetAuthor() string {
+	if x != nil && x.Author != nil {
+		return *x.Author
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetPublicationYear() int32 {
+	if x != nil && x.PublicationYear != nil {
+		return *x.PublicationYear
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetGenre() string {
+	if x != nil && x.Genre != nil {
+		return *x.Genre
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetAuthors() []string {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *ListBooksRequest) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *ListBooksRequest) GetPublicationYearFrom() int32 {
+	if x != nil && x.PublicationYearFrom != nil {
+		return *x.PublicationYearFrom
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetPublicationYearTo() int32 {
+	if x != nil && x.PublicationYearTo != nil {
+		return *x.PublicationYearTo
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetTitlePrefix() string {
+	if x != nil && x.TitlePrefix != nil {
+		return *x.TitlePrefix
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetSortBy() BookSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return BookSortField_BOOK_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *ListBooksRequest) GetSortDirection() SortDirection {
+	if x != nil {
+		return x.SortDirection
+	}
+	return SortDirection_SORT_DIRECTION_UNSPECIFIED
+}
+
+func (x *ListBooksRequest) GetLanguage() string {
+	if x != nil && x.Language != nil {
+		return *x.Language
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetSeries() string {
+	if x != nil && x.Series != nil {
+		return *x.Series
+	}
+	return ""
+}
+
+type ListBooksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Books []*Book                `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`
+	// Only filled by GetUserBooks with group_by_series.
+	SeriesProgress []*SeriesProgress `protobuf:"bytes,2,rep,name=series_progress,json=seriesProgress,proto3" json:"series_progress,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListBooksResponse) Reset() {
+	*x = ListBooksResponse{}
+	mi := &file_book_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBooksResponse) ProtoMessage() {}
+
+func (x *ListBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBooksResponse.ProtoReflect.Descriptor instead.
+func (*ListBooksResponse) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListBooksResponse) GetBooks() []*Book {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+func (x *ListBooksResponse) GetSeriesProgress() []*SeriesProgress {
+	if x != nil {
+		return x.SeriesProgress
+	}
+	return nil
+}
+
+// GetBookFacetsRequest takes the filters of ListBooksRequest. Each facet's
+// counts ignore the filters on that facet.
+type GetBookFacetsRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Author              *string                `protobuf:"bytes,1,opt,name=author,proto3,oneof" json:"author,omitempty"`
+	PublicationYear     *int32                 `protobuf:"varint,2,opt,name=publication_year,json=publicationYear,proto3,oneof" json:"publication_year,omitempty"`
+	Genre               *string                `protobuf:"bytes,3,opt,name=genre,proto3,oneof" json:"genre,omitempty"`
+	Authors             []string               `protobuf:"bytes,4,rep,name=authors,proto3" json:"authors,omitempty"`
+	Genres              []string               `protobuf:"bytes,5,rep,name=genres,proto3" json:"genres,omitempty"`
+	PublicationYearFrom *int32                 `protobuf:"varint,6,opt,name=publication_year_from,json=publicationYearFrom,proto3,oneof" json:"publication_year_from,omitempty"`
+	PublicationYearTo   *int32                 `protobuf:"varint,7,opt,name=publication_year_to,json=publicationYearTo,proto3,oneof" json:"publication_year_to,omitempty"`
+	TitlePrefix         *string                `protobuf:"bytes,8,opt,name=title_prefix,json=titlePrefix,proto3,oneof" json:"title_prefix,omitempty"`
+	// Maximum number of genre and author values; 20 when unset.
+	Limit *int32 `protobuf:"varint,9,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	// Matches the tag and its subtags: "en" also matches "en-GB".
+	Language      *string `protobuf:"bytes,10,opt,name=language,proto3,oneof" json:"language,omitempty"`
+	Series        *string `protobuf:"bytes,11,opt,name=series,proto3,oneof" json:"series,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookFacetsRequest) Reset() {
+	*x = GetBookFacetsRequest{}
+	mi := &file_book_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookFacetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookFacetsRequest) ProtoMessage() {}
+
+func (x *GetBookFacetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookFacetsRequest.ProtoReflect.Descriptor instead.
+func (*GetBookFacetsRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetBookFacetsRequest) GetAuthor() string {
+	if x != nil && x.Author != nil {
+		return *x.Author
+	}
+	return ""
+}
+
+func (x *GetBookFacetsRequest) GetPublicationYear() int32 {
+	if x != nil && x.PublicationYear != nil {
+		return *x.PublicationYear
+	}
+	return 0
+}
+
+func (x *GetBookFacetsRequest) GetGenre() string {
+	if x != nil && x.Genre != nil {
+		return *x.Genre
+	}
+	return ""
+}
+
+func (x *GetBookFacetsRequest) GetAuthors() []string {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *GetBookFacetsRequest) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *GetBookFacetsRequest) GetPublicationYearFrom() int32 {
+	if x != nil && x.PublicationYearFrom != nil {
+		return *x.PublicationYearFrom
+	}
+	return 0
+}
+
+func (x *GetBookFacetsRequest) GetPublicationYearTo() int32 {
+	if x != nil && x.PublicationYearTo != nil {
+		return *x.PublicationYearTo
+	}
+	return 0
+}
+
+func (x *GetBookFacetsRequest) GetTitlePrefix() string {
+	if x != nil && x.TitlePrefix != nil {
+		return *x.TitlePrefix
+	}
+	return ""
+}
+
+func (x *GetBookFacetsRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *GetBookFacetsRequest) GetLanguage() string {
+	if x != nil && x.Language != nil {
+		return *x.Language
+	}
+	return ""
+}
+
+func (x *GetBookFacetsRequest) GetSeries() string {
+	if x != nil && x.Series != nil {
+		return *x.Series
+	}
+	return ""
+}
+
+type FacetCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FacetCount) Reset() {
+	*x = FacetCount{}
+	mi := &file_book_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FacetCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetCount) ProtoMessage() {}
+
+func (x *FacetCount) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetCount.ProtoReflect.Descriptor instead.
+func (*FacetCount) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FacetCount) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *FacetCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type BookFacets struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Genres  []*FacetCount          `protobuf:"bytes,1,rep,name=genres,proto3" json:"genres,omitempty"`
+	Authors []*FacetCount          `protobuf:"bytes,2,rep,name=authors,proto3" json:"authors,omitempty"`
+	// Values are the first year of the decade, e.g. "1990".
+	Decades       []*FacetCount `protobuf:"bytes,3,rep,name=decades,proto3" json:"decades,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookFacets) Reset() {
+	*x = BookFacets{}
+	mi := &file_book_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookFacets) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookFacets) ProtoMessage() {}
+
+func (x *BookFacets) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookFacets.ProtoReflect.Descriptor instead.
+func (*BookFacets) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BookFacets) GetGenres() []*FacetCount {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *BookFacets) GetAuthors() []*FacetCount {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *BookFacets) GetDecades() []*FacetCount {
+	if x != nil {
+		return x.Decades
+	}
+	return nil
+}
+
+type UserBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BookId        string                 `protobuf:"bytes,2,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserBookRequest) Reset() {
+	*x = UserBookRequest{}
+	mi := &file_book_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserBookRequest) ProtoMessage() {}
+
+func (x *UserBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserBookRequest.ProtoReflect.Descriptor instead.
+func (*UserBookRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UserBookRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserBookRequest) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+type GetUserBooksRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Author          *string                `protobuf:"bytes,2,opt,name=author,proto3,oneof" json:"author,omitempty"`
+	PublicationYear *int32                 `protobuf:"varint,3,opt,name=publication_year,json=publicationYear,proto3,oneof" json:"publication_year,omitempty"`
+	Genre           *string                `protobuf:"bytes,4,opt,name=genre,proto3,oneof" json:"genre,omitempty"`
+	// Books matching any of the listed values; combined with the single-value
+	// filter above.
+	Authors []string `protobuf:"bytes,5,rep,name=authors,proto3" json:"authors,omitempty"`
+	Genres  []string `protobuf:"bytes,6,rep,name=genres,proto3" json:"genres,omitempty"`
+	// Inclusive publication year range.
+	PublicationYearFrom *int32 `protobuf:"varint,7,opt,name=publication_year_from,json=publicationYearFrom,proto3,oneof" json:"publication_year_from,omitempty"`
+	PublicationYearTo   *int32 `protobuf:"varint,8,opt,name=publication_year_to,json=publicationYearTo,proto3,oneof" json:"publication_year_to,omitempty"`
+	// Case-insensitive title prefix.
+	TitlePrefix   *string       `protobuf:"bytes,9,opt,name=title_prefix,json=titlePrefix,proto3,oneof" json:"title_prefix,omitempty"`
+	SortBy        BookSortField `protobuf:"varint,10,opt,name=sort_by,json=sortBy,proto3,enum=bookService.BookSortField" json:"sort_by,omitempty"`
+	SortDirection SortDirection `protobuf:"varint,11,opt,name=sort_direction,json=sortDirection,proto3,enum=bookService.SortDirection" json:"sort_direction,omitempty"`
+	// Matches the tag and its subtags: "en" also matches "en-GB".
+	Language *string `protobuf:"bytes,12,opt,name=language,proto3,oneof" json:"language,omitempty"`
+	Series   *string `protobuf:"bytes,13,opt,name=series,proto3,oneof" json:"series,omitempty"`
+	// Also report progress through every series the returned books belong to.
+	GroupBySeries bool `protobuf:"varint,14,opt,name=group_by_series,json=groupBySeries,proto3" json:"group_by_series,omitempty"`
+	// List the books on this shelf of the user instead of all their books.
+	ShelfId       *string `protobuf:"bytes,15,opt,name=shelf_id,json=shelfId,proto3,oneof" json:"shelf_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserBooksRequest) Reset() {
+	*x = GetUserBooksRequest{}
+	mi := &file_book_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserBooksRequest) ProtoMessage() {}
+
+func (x *GetUserBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserBooksRequest.ProtoReflect.Descriptor instead.
+func (*GetUserBooksRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetUserBooksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserBooksRequest) GetAuthor() string {
+	if x != nil && x.Author != nil {
+		return *x.Author
+	}
+	return ""
+}
+
+func (x *GetUserBooksRequest) GetPublicationYear() int32 {
+	if x != nil && x.PublicationYear != nil {
+		return *x.PublicationYear
+	}
+	return 0
+}
+
+func (x *GetUserBooksRequest) GetGenre() string {
+	if x != nil && x.Genre != nil {
+		return *x.Genre
+	}
+	return ""
+}
+
+func (x *GetUserBooksRequest) GetAuthors() []string {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *GetUserBooksRequest) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *GetUserBooksRequest) GetPublicationYearFrom() int32 {
+	if x != nil && x.PublicationYearFrom != nil {
+		return *x.PublicationYearFrom
+	}
+	return 0
+}
+
+func (x *GetUserBooksRequest) GetPublicationYearTo() int32 {
+	if x != nil && x.PublicationYearTo != nil {
+		return *x.PublicationYearTo
+	}
+	return 0
+}
+
+func (x *GetUserBooksRequest) GetTitlePrefix() string {
+	if x != nil && x.TitlePrefix != nil {
+		return *x.TitlePrefix
+	}
+	return ""
+}
+
+func (x *GetUserBooksRequest) GetSortBy() BookSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return BookSortField_BOOK_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *GetUserBooksRequest) GetSortDirection() SortDirection {
+	if x != nil {
+		return x.SortDirection
+	}
+	return SortDirection_SORT_DIRECTION_UNSPECIFIED
+}
+
+func (x *GetUserBooksRequest) GetLanguage() string {
+	if x != nil && x.Language != nil {
+		return *x.Language
+	}
+	return ""
+}
+
+func (x *GetUserBooksRequest) GetSeries() string {
+	if x != nil && x.Series != nil {
+		return *x.Series
+	}
+	return ""
+}
+
+func (x *GetUserBooksRequest) GetGroupBySeries() bool {
+	if x != nil {
+		return x.GroupBySeries
+	}
+	return false
+}
+
+func (x *GetUserBooksRequest) GetShelfId() string {
+	if x != nil && x.ShelfId != nil {
+		return *x.ShelfId
+	}
+	return ""
+}
+
+type UploadCoverRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*UploadCoverRequest_BookId
+	//	*UploadCoverRequest_Chunk
+	Data          isUploadCoverRequest_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadCoverRequest) Reset() {
+	*x = UploadCoverRequest{}
+	mi := &file_book_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadCoverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadCoverRequest) ProtoMessage() {}
+
+func (x *UploadCoverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadCoverRequest.ProtoReflect.Descriptor instead.
+func (*UploadCoverRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UploadCoverRequest) GetData() isUploadCoverRequest_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadCoverRequest) GetBookId() string {
+	if x != nil {
+		if x, ok := x.Data.(*UploadCoverRequest_BookId); ok {
+			return x.BookId
+		}
+	}
+	return ""
+}
+
+func (x *UploadCoverRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*UploadCoverRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadCoverRequest_Data interface {
+	isUploadCoverRequest_Data()
+}
+
+type UploadCoverRequest_BookId struct {
+	BookId string `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3,oneof"`
+}
+
+type UploadCoverRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadCoverRequest_BookId) isUploadCoverRequest_Data() {}
+
+func (*UploadCoverRequest_Chunk) isUploadCoverRequest_Data() {}
+
+type Cover struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	CoverUrl      string                 `protobuf:"bytes,4,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`
+	ThumbnailUrl  string                 `protobuf:"bytes,5,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cover) Reset() {
+	*x = Cover{}
+	mi := &file_book_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cover) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cover) ProtoMessage() {}
+
+func (x *Cover) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cover.ProtoReflect.Descriptor instead.
+func (*Cover) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Cover) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+func (x *Cover) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Cover) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Cover) GetCoverUrl() string {
+	if x != nil {
+		return x.CoverUrl
+	}
+	return ""
+}
+
+func (x *Cover) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+type GetCoverRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	Thumbnail     bool                   `protobuf:"varint,2,opt,name=thumbnail,proto3" json:"thumbnail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCoverRequest) Reset() {
+	*x = GetCoverRequest{}
+	mi := &file_book_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCoverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCoverRequest) ProtoMessage() {}
+
+func (x *GetCoverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCoverRequest.ProtoReflect.Descriptor instead.
+func (*GetCoverRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetCoverRequest) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+func (x *GetCoverRequest) GetThumbnail() bool {
+	if x != nil {
+		return x.Thumbnail
+	}
+	return false
+}
+
+// CoverChunk carries content_type and size in the first message only.
+type CoverChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContentType   string                 `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoverChunk) Reset() {
+	*x = CoverChunk{}
+	mi := &file_book_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoverChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoverChunk) ProtoMessage() {}
+
+func (x *CoverChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoverChunk.ProtoReflect.Descriptor instead.
+func (*CoverChunk) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CoverChunk) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *CoverChunk) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *CoverChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type Series struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	SeriesId    string                 `protobuf:"bytes,1,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	BookCount   int32                  `protobuf:"varint,4,opt,name=book_count,json=bookCount,proto3" json:"book_count,omitempty"`
+	// Volumes in reading order; only returned by GetSeries.
+	Books         []*Book `protobuf:"bytes,5,rep,name=books,proto3" json:"books,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Series) Reset() {
+	*x = Series{}
+	mi := &file_book_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Series) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Series) ProtoMessage() {}
+
+func (x *Series) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Series.ProtoReflect.Descriptor instead.
+func (*Series) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Series) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+func (x *Series) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Series) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Series) GetBookCount() int32 {
+	if x != nil {
+		return x.BookCount
+	}
+	return 0
+}
+
+func (x *Series) GetBooks() []*Book {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+type SeriesProgress struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	SeriesId string                 `protobuf:"bytes,1,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Number of volumes on the shelf and in the whole series.
+	Owned int32 `protobuf:"varint,3,opt,name=owned,proto3" json:"owned,omitempty"`
+	Total int32 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	// Owned volumes in reading order.
+	BookIds       []string `protobuf:"bytes,5,rep,name=book_ids,json=bookIds,proto3" json:"book_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeriesProgress) Reset() {
+	*x = SeriesProgress{}
+	mi := &file_book_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeriesProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeriesProgress) ProtoMessage() {}
+
+func (x *SeriesProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeriesProgress.ProtoReflect.Descriptor instead.
+func (*SeriesProgress) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SeriesProgress) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+func (x *SeriesProgress) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SeriesProgress) GetOwned() int32 {
+	if x != nil {
+		return x.Owned
+	}
+	return 0
+}
+
+func (x *SeriesProgress) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SeriesProgress) GetBookIds() []string {
+	if x != nil {
+		return x.BookIds
+	}
+	return nil
+}
+
+type ListSeriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSeriesRequest) Reset() {
+	*x = ListSeriesRequest{}
+	mi := &file_book_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSeriesRequest) ProtoMessage() {}
+
+func (x *ListSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSeriesRequest.ProtoReflect.Descriptor instead.
+func (*ListSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{20}
+}
+
+type ListSeriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Series        []*Series              `protobuf:"bytes,1,rep,name=series,proto3" json:"series,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSeriesResponse) Reset() {
+	*x = ListSeriesResponse{}
+	mi := &file_book_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSeriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSeriesResponse) ProtoMessage() {}
+
+func (x *ListSeriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSeriesResponse.ProtoReflect.Descriptor instead.
+func (*ListSeriesResponse) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListSeriesResponse) GetSeries() []*Series {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+type GetSeriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeriesId      string                 `protobuf:"bytes,1,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSeriesRequest) Reset() {
+	*x = GetSeriesRequest{}
+	mi := &file_book_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSeriesRequest) ProtoMessage() {}
+
+func (x *GetSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSeriesRequest.ProtoReflect.Descriptor instead.
+func (*GetSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetSeriesRequest) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+type AddSeriesToUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SeriesId      string                 `protobuf:"bytes,2,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddSeriesToUserRequest) Reset() {
+	*x = AddSeriesToUserRequest{}
+	mi := &file_book_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddSeriesToUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSeriesToUserRequest) ProtoMessage() {}
+
+func (x *AddSeriesToUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSeriesToUserRequest.ProtoReflect.Descriptor instead.
+func (*AddSeriesToUserRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AddSeriesToUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AddSeriesToUserRequest) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+type AddSeriesToUserResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Books that were not on the shelf before.
+	BookIds       []string `protobuf:"bytes,1,rep,name=book_ids,json=bookIds,proto3" json:"book_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddSeriesToUserResponse) Reset() {
+	*x = AddSeriesToUserResponse{}
+	mi := &file_book_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddSeriesToUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSeriesToUserResponse) ProtoMessage() {}
+
+func (x *AddSeriesToUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSeriesToUserResponse.ProtoReflect.Descriptor instead.
+func (*AddSeriesToUserResponse) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AddSeriesToUserResponse) GetBookIds() []string {
+	if x != nil {
+		return x.BookIds
+	}
+	return nil
+}
+
+type Shelf struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ShelfId    string                 `protobuf:"bytes,1,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	UserId     string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name       string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Visibility ShelfVisibility        `protobuf:"varint,4,opt,name=visibility,proto3,enum=bookService.ShelfVisibility" json:"visibility,omitempty"`
+	// Set for link-shared shelves and only returned to the owner.
+	ShareToken *string                `protobuf:"bytes,5,opt,name=share_token,json=shareToken,proto3,oneof" json:"share_token,omitempty"`
+	BookCount  int32                  `protobuf:"varint,6,opt,name=book_count,json=bookCount,proto3" json:"book_count,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Books in shelf order; only filled by GetShelf.
+	Books         []*Book `protobuf:"bytes,9,rep,name=books,proto3" json:"books,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Shelf) Reset() {
+	*x = Shelf{}
+	mi := &file_book_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Shelf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shelf) ProtoMessage() {}
+
+func (x *Shelf) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shelf.ProtoReflect.Descriptor instead.
+func (*Shelf) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Shelf) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+func (x *Shelf) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Shelf) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Shelf) GetVisibility() ShelfVisibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return ShelfVisibility_SHELF_VISIBILITY_UNSPECIFIED
+}
+
+func (x *Shelf) GetShareToken() string {
+	if x != nil && x.ShareToken != nil {
+		return *x.ShareToken
+	}
+	return ""
+}
+
+func (x *Shelf) GetBookCount() int32 {
+	if x != nil {
+		return x.BookCount
+	}
+	return 0
+}
+
+func (x *Shelf) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Shelf) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Shelf) GetBooks() []*Book {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+type CreateShelfRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Visibility    ShelfVisibility        `protobuf:"varint,3,opt,name=visibility,proto3,enum=bookService.ShelfVisibility" json:"visibility,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShelfRequest) Reset() {
+	*x = CreateShelfRequest{}
+	mi := &file_book_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShelfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShelfRequest) ProtoMessage() {}
+
+func (x *CreateShelfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShelfRequest.ProtoReflect.Descriptor instead.
+func (*CreateShelfRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CreateShelfRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateShelfRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateShelfRequest) GetVisibility() ShelfVisibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return ShelfVisibility_SHELF_VISIBILITY_UNSPECIFIED
+}
+
+type RenameShelfRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShelfId       string                 `protobuf:"bytes,2,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameShelfRequest) Reset() {
+	*x = RenameShelfRequest{}
+	mi := &file_book_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameShelfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameShelfRequest) ProtoMessage() {}
+
+func (x *RenameShelfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameShelfRequest.ProtoReflect.Descriptor instead.
+func (*RenameShelfRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RenameShelfRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RenameShelfRequest) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+func (x *RenameShelfRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SetShelfVisibilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShelfId       string                 `protobuf:"bytes,2,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	Visibility    ShelfVisibility        `protobuf:"varint,3,opt,name=visibility,proto3,enum=bookService.ShelfVisibility" json:"visibility,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetShelfVisibilityRequest) Reset() {
+	*x = SetShelfVisibilityRequest{}
+	mi := &file_book_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetShelfVisibilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetShelfVisibilityRequest) ProtoMessage() {}
+
+func (x *SetShelfVisibilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetShelfVisibilityRequest.ProtoReflect.Descriptor instead.
+func (*SetShelfVisibilityRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SetShelfVisibilityRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetShelfVisibilityRequest) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+func (x *SetShelfVisibilityRequest) GetVisibility() ShelfVisibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return ShelfVisibility_SHELF_VISIBILITY_UNSPECIFIED
+}
+
+type DeleteShelfRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShelfId       string                 `protobuf:"bytes,2,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteShelfRequest) Reset() {
+	*x = DeleteShelfRequest{}
+	mi := &file_book_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteShelfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteShelfRequest) ProtoMessage() {}
+
+func (x *DeleteShelfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteShelfRequest.ProtoReflect.Descriptor instead.
+func (*DeleteShelfRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteShelfRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteShelfRequest) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+type DeleteShelfResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShelfId       string                 `protobuf:"bytes,1,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteShelfResponse) Reset() {
+	*x = DeleteShelfResponse{}
+	mi := &file_book_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteShelfResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteShelfResponse) ProtoMessage() {}
+
+func (x *DeleteShelfResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteShelfResponse.ProtoReflect.Descriptor instead.
+func (*DeleteShelfResponse) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeleteShelfResponse) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+type ListShelvesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShelvesRequest) Reset() {
+	*x = ListShelvesRequest{}
+	mi := &file_book_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShelvesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShelvesRequest) ProtoMessage() {}
+
+func (x *ListShelvesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShelvesRequest.ProtoReflect.Descriptor instead.
+func (*ListShelvesRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListShelvesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListShelvesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shelves       []*Shelf               `protobuf:"bytes,1,rep,name=shelves,proto3" json:"shelves,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShelvesResponse) Reset() {
+	*x = ListShelvesResponse{}
+	mi := &file_book_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShelvesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShelvesResponse) ProtoMessage() {}
+
+func (x *ListShelvesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShelvesResponse.ProtoReflect.Descriptor instead.
+func (*ListShelvesResponse) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListShelvesResponse) GetShelves() []*Shelf {
+	if x != nil {
+		return x.Shelves
+	}
+	return nil
+}
+
+type ShelfBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShelfId       string                 `protobuf:"bytes,2,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	BookId        string                 `protobuf:"bytes,3,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShelfBookRequest) Reset() {
+	*x = ShelfBookRequest{}
+	mi := &file_book_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShelfBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShelfBookRequest) ProtoMessage() {}
+
+func (x *ShelfBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShelfBookRequest.ProtoReflect.Descriptor instead.
+func (*ShelfBookRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ShelfBookRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShelfBookRequest) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+func (x *ShelfBookRequest) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+type ReorderShelfRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShelfId       string                 `protobuf:"bytes,2,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	BookIds       []string               `protobuf:"bytes,3,rep,name=book_ids,json=bookIds,proto3" json:"book_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderShelfRequest) Reset() {
+	*x = ReorderShelfRequest{}
+	mi := &file_book_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderShelfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderShelfRequest) ProtoMessage() {}
+
+func (x *ReorderShelfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderShelfRequest.ProtoReflect.Descriptor instead.
+func (*ReorderShelfRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ReorderShelfRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReorderShelfRequest) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+func (x *ReorderShelfRequest) GetBookIds() []string {
+	if x != nil {
+		return x.BookIds
+	}
+	return nil
+}
+
+type GetShelfRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShelfId       string                 `protobuf:"bytes,1,opt,name=shelf_id,json=shelfId,proto3" json:"shelf_id,omitempty"`
+	ShareToken    *string                `protobuf:"bytes,2,opt,name=share_token,json=shareToken,proto3,oneof" json:"share_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShelfRequest) Reset() {
+	*x = GetShelfRequest{}
+	mi := &file_book_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShelfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShelfRequest) ProtoMessage() {}
+
+func (x *GetShelfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShelfRequest.ProtoReflect.Descriptor instead.
+func (*GetShelfRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetShelfRequest) GetShelfId() string {
+	if x != nil {
+		return x.ShelfId
+	}
+	return ""
+}
+
+func (x *GetShelfRequest) GetShareToken() string {
+	if x != nil && x.ShareToken != nil {
+		return *x.ShareToken
+	}
+	return ""
+}
+
+type DeleteBookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBookResponse) Reset() {
+	*x = DeleteBookResponse{}
+	mi := &file_book_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBookResponse) ProtoMessage() {}
+
+func (x *DeleteBookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBookResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBookResponse) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DeleteBookResponse) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+type AddUserBookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddUserBookResponse) Reset() {
+	*x = AddUserBookResponse{}
+	mi := &file_book_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddUserBookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddUserBookResponse) ProtoMessage() {}
+
+func (x *AddUserBookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddUserBookResponse.ProtoReflect.Descriptor instead.
+func (*AddUserBookResponse) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *AddUserBookResponse) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+type RemoveBookFromUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveBookFromUserResponse) Reset() {
+	*x = RemoveBookFromUserResponse{}
+	mi := &file_book_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveBookFromUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveBookFromUserResponse) ProtoMessage() {}
+
+func (x *RemoveBookFromUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveBookFromUserResponse.ProtoReflect.Descriptor instead.
+func (*RemoveBookFromUserResponse) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RemoveBookFromUserResponse) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+type Webhook struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId  string                 `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Url        string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes []string               `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	// Only returned by CreateWebhook.
+	Secret        string                 `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_book_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Webhook) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *Webhook) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Webhook) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateWebhookRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Url        string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes []string               `protobuf:"bytes,2,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	// Generated by the server when empty.
+	Secret        *string `protobuf:"bytes,3,opt,name=secret,proto3,oneof" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookRequest) Reset() {
+	*x = CreateWebhookRequest{}
+	mi := &file_book_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookRequest) ProtoMessage() {}
+
+func (x *CreateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CreateWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *CreateWebhookRequest) GetSecret() string {
+	if x != nil && x.Secret != nil {
+		return *x.Secret
+	}
+	return ""
+}
+
+type ListWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksRequest) Reset() {
+	*x = ListWebhooksRequest{}
+	mi := &file_book_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksRequest) ProtoMessage() {}
+
+func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{41}
+}
+
+type ListWebhooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhooks      []*Webhook             `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksResponse) Reset() {
+	*x = ListWebhooksResponse{}
+	mi := &file_book_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksResponse) ProtoMessage() {}
+
+func (x *ListWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListWebhooksResponse) GetWebhooks() []*Webhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+type DeleteWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     string                 `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookRequest) Reset() {
+	*x = DeleteWebhookRequest{}
+	mi := &file_book_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DeleteWebhookRequest) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+type DeleteWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     string                 `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookResponse) Reset() {
+	*x = DeleteWebhookResponse{}
+	mi := &file_book_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookResponse) ProtoMessage() {}
+
+func (x *DeleteWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *DeleteWebhookResponse) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+type WebhookDelivery struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryId string                 `protobuf:"bytes,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	WebhookId  string                 `protobuf:"bytes,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	EventType  string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// pending, delivered, dead
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts      int32                  `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError     string                 `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	NextAttemptAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeliveredAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=delivered_at,json=deliveredAt,proto3,oneof" json:"delivered_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_book_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *WebhookDelivery) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return nil
+}
+
+type ListWebhookDeliveriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     string                 `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Status        *string                `protobuf:"bytes,2,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	Limit         *int32                 `protobuf:"varint,3,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_book_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListWebhookDeliveriesRequest) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type ListWebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_book_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+type Genre struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	GenreId  string                 `protobuf:"bytes,1,opt,name=genre_id,json=genreId,proto3" json:"genre_id,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId *string                `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	// Lower-case alternative spellings that resolve to this genre.
+	Aliases       []string `protobuf:"bytes,4,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Genre) Reset() {
+	*x = Genre{}
+	mi := &file_book_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Genre) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Genre) ProtoMessage() {}
+
+func (x *Genre) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Genre.ProtoReflect.Descriptor instead.
+func (*Genre) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *Genre) GetGenreId() string {
+	if x != nil {
+		return x.GenreId
+	}
+	return ""
+}
+
+func (x *Genre) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Genre) GetParentId() string {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return ""
+}
+
+func (x *Genre) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+type CreateGenreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId      *string                `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	Aliases       []string               `protobuf:"bytes,3,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGenreRequest) Reset() {
+	*x = CreateGenreRequest{}
+	mi := &file_book_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGenreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGenreRequest) ProtoMessage() {}
+
+func (x *CreateGenreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGenreRequest.ProtoReflect.Descriptor instead.
+func (*CreateGenreRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CreateGenreRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateGenreRequest) GetParentId() string {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return ""
+}
+
+func (x *CreateGenreRequest) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+type UpdateGenreRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	GenreId  string                 `protobuf:"bytes,1,opt,name=genre_id,json=genreId,proto3" json:"genre_id,omitempty"`
+	Name     *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	ParentId *string                `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	// Makes the genre top-level; takes precedence over parent_id.
+	ClearParent bool     `protobuf:"varint,4,opt,name=clear_parent,json=clearParent,proto3" json:"clear_parent,omitempty"`
+	Aliases     []string `protobuf:"bytes,5,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// Aliases are replaced only when set, so an empty list can clear them.
+	SetAliases    bool `protobuf:"varint,6,opt,name=set_aliases,json=setAliases,proto3" json:"set_aliases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGenreRequest) Reset() {
+	*x = UpdateGenreRequest{}
+	mi := &file_book_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGenreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGenreRequest) ProtoMessage() {}
+
+func (x *UpdateGenreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGenreRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGenreRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *UpdateGenreRequest) GetGenreId() string {
+	if x != nil {
+		return x.GenreId
+	}
+	return ""
+}
+
+func (x *UpdateGenreRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateGenreRequest) GetParentId() string {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return ""
+}
+
+func (x *UpdateGenreRequest) GetClearParent() bool {
+	if x != nil {
+		return x.ClearParent
+	}
+	return false
+}
+
+func (x *UpdateGenreRequest) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *UpdateGenreRequest) GetSetAliases() bool {
+	if x != nil {
+		return x.SetAliases
+	}
+	return false
+}
+
+type DeleteGenreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GenreId       string                 `protobuf:"bytes,1,opt,name=genre_id,json=genreId,proto3" json:"genre_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGenreRequest) Reset() {
+	*x = DeleteGenreRequest{}
+	mi := &file_book_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGenreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGenreRequest) ProtoMessage() {}
+
+func (x *DeleteGenreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *Book) Reset() {
-	*x = Book{}
-	mi := &file_book_service_proto_msgTypes[0]
+// Deprecated: Use DeleteGenreRequest.ProtoReflect.Descriptor instead.
+func (*DeleteGenreRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DeleteGenreRequest) GetGenreId() string {
+	if x != nil {
+		return x.GenreId
+	}
+	return ""
+}
+
+type DeleteGenreResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GenreId       string                 `protobuf:"bytes,1,opt,name=genre_id,json=genreId,proto3" json:"genre_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGenreResponse) Reset() {
+	*x = DeleteGenreResponse{}
+	mi := &file_book_service_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Book) String() string {
+func (x *DeleteGenreResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Book) ProtoMessage() {}
+func (*DeleteGenreResponse) ProtoMessage() {}
 
-func (x *Book) ProtoReflect() protoreflect.Message {
-	mi := &file_book_service_proto_msgTypes[0]
+func (x *DeleteGenreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -57,71 +3931,121 @@ func (x *Book) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Book.ProtoReflect.Descriptor instead.
-func (*Book) Descriptor() ([]byte, []int) {
-	return file_book_service_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use DeleteGenreResponse.ProtoReflect.Descriptor instead.
+func (*DeleteGenreResponse) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *Book) GetBookId() string {
+func (x *DeleteGenreResponse) GetGenreId() string {
 	if x != nil {
-		return x.BookId
+		return x.GenreId
 	}
 	return ""
 }
 
-func (x *Book) GetTitle() string {
+type ListGenresRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGenresRequest) Reset() {
+	*x = ListGenresRequest{}
+	mi := &file_book_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGenresRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGenresRequest) ProtoMessage() {}
+
+func (x *ListGenresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[53]
 	if x != nil {
-		return x.Title
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Book) GetAuthor() string {
+// Deprecated: Use ListGenresRequest.ProtoReflect.Descriptor instead.
+func (*ListGenresRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{53}
+}
+
+type ListGenresResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Genres        []*Genre               `protobuf:"bytes,1,rep,name=genres,proto3" json:"genres,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGenresResponse) Reset() {
+	*x = ListGenresResponse{}
+	mi := &file_book_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGenresResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGenresResponse) ProtoMessage() {}
+
+func (x *ListGenresResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[54]
 	if x != nil {
-		return x.Author
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Book) GetPublicationYear() int32 {
-	if x != nil && x.PublicationYear != nil {
-		return *x.PublicationYear
-	}
-	return 0
+// Deprecated: Use ListGenresResponse.ProtoReflect.Descriptor instead.
+func (*ListGenresResponse) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *Book) GetGenre() string {
-	if x != nil && x.Genre != nil {
-		return *x.Genre
+func (x *ListGenresResponse) GetGenres() []*Genre {
+	if x != nil {
+		return x.Genres
 	}
-	return ""
+	return nil
 }
 
-type AddBookRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Title           string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Author          string                 `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
-	PublicationYear *int32                 `protobuf:"varint,3,opt,name=publication_year,json=publicationYear,proto3,oneof" json:"publication_year,omitempty"`
-	Genre           *string                `protobuf:"bytes,4,opt,name=genre,proto3,oneof" json:"genre,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type SetBookGenresRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	GenreIds      []string               `protobuf:"bytes,2,rep,name=genre_ids,json=genreIds,proto3" json:"genre_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddBookRequest) Reset() {
-	*x = AddBookRequest{}
-	mi := &file_book_service_proto_msgTypes[1]
+func (x *SetBookGenresRequest) Reset() {
+	*x = SetBookGenresRequest{}
+	mi := &file_book_service_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddBookRequest) String() string {
+func (x *SetBookGenresRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddBookRequest) ProtoMessage() {}
+func (*SetBookGenresRequest) ProtoMessage() {}
 
-func (x *AddBookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_book_service_proto_msgTypes[1]
+func (x *SetBookGenresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -132,61 +4056,104 @@ func (x *AddBookRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddBookRequest.ProtoReflect.Descriptor instead.
-func (*AddBookRequest) Descriptor() ([]byte, []int) {
-	return file_book_service_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use SetBookGenresRequest.ProtoReflect.Descriptor instead.
+func (*SetBookGenresRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *AddBookRequest) GetTitle() string {
+func (x *SetBookGenresRequest) GetBookId() string {
 	if x != nil {
-		return x.Title
+		return x.BookId
 	}
 	return ""
 }
 
-func (x *AddBookRequest) GetAuthor() string {
+func (x *SetBookGenresRequest) GetGenreIds() []string {
 	if x != nil {
-		return x.Author
+		return x.GenreIds
 	}
-	return ""
+	return nil
 }
 
-func (x *AddBookRequest) GetPublicationYear() int32 {
-	if x != nil && x.PublicationYear != nil {
-		return *x.PublicationYear
+type SetBookGenresResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	BookId string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	// Names of the genres the book is now tagged with.
+	Genres        []string `protobuf:"bytes,2,rep,name=genres,proto3" json:"genres,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBookGenresResponse) Reset() {
+	*x = SetBookGenresResponse{}
+	mi := &file_book_service_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBookGenresResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBookGenresResponse) ProtoMessage() {}
+
+func (x *SetBookGenresResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *AddBookRequest) GetGenre() string {
-	if x != nil && x.Genre != nil {
-		return *x.Genre
+// Deprecated: Use SetBookGenresResponse.ProtoReflect.Descriptor instead.
+func (*SetBookGenresResponse) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SetBookGenresResponse) GetBookId() string {
+	if x != nil {
+		return x.BookId
 	}
 	return ""
 }
 
-type GetBookRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+func (x *SetBookGenresResponse) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+type ImportCatalogRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*ImportCatalogRequest_Options
+	//	*ImportCatalogRequest_Chunk
+	Data          isImportCatalogRequest_Data `protobuf_oneof:"data"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetBookRequest) Reset() {
-	*x = GetBookRequest{}
-	mi := &file_book_service_proto_msgTypes[2]
+func (x *ImportCatalogRequest) Reset() {
+	*x = ImportCatalogRequest{}
+	mi := &file_book_service_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBookRequest) String() string {
+func (x *ImportCatalogRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBookRequest) ProtoMessage() {}
+func (*ImportCatalogRequest) ProtoMessage() {}
 
-func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_book_service_proto_msgTypes[2]
+func (x *ImportCatalogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -197,44 +4164,82 @@ func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBookRequest.ProtoReflect.Descriptor instead.
-func (*GetBookRequest) Descriptor() ([]byte, []int) {
-	return file_book_service_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ImportCatalogRequest.ProtoReflect.Descriptor instead.
+func (*ImportCatalogRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *GetBookRequest) GetBookId() string {
+func (x *ImportCatalogRequest) GetData() isImportCatalogRequest_Data {
 	if x != nil {
-		return x.BookId
+		return x.Data
 	}
-	return ""
+	return nil
 }
 
-type UpdateBookRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	BookId          string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
-	Title           *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
-	Author          *string                `protobuf:"bytes,3,opt,name=author,proto3,oneof" json:"author,omitempty"`
-	PublicationYear *int32                 `protobuf:"varint,4,opt,name=publication_year,json=publicationYear,proto3,oneof" json:"publication_year,omitempty"`
-	Genre           *string                `protobuf:"bytes,5,opt,name=genre,proto3,oneof" json:"genre,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *ImportCatalogRequest) GetOptions() *ImportOptions {
+	if x != nil {
+		if x, ok := x.Data.(*ImportCatalogRequest_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
 }
 
-func (x *UpdateBookRequest) Reset() {
-	*x = UpdateBookRequest{}
-	mi := &file_book_service_proto_msgTypes[3]
+func (x *ImportCatalogRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*ImportCatalogRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isImportCatalogRequest_Data interface {
+	isImportCatalogRequest_Data()
+}
+
+type ImportCatalogRequest_Options struct {
+	Options *ImportOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportCatalogRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*ImportCatalogRequest_Options) isImportCatalogRequest_Data() {}
+
+func (*ImportCatalogRequest_Chunk) isImportCatalogRequest_Data() {}
+
+type ImportOptions struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Format CatalogFormat          `protobuf:"varint,1,opt,name=format,proto3,enum=bookService.CatalogFormat" json:"format,omitempty"`
+	// Validate and match every record without writing anything.
+	DryRun      bool            `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	OnDuplicate DuplicatePolicy `protobuf:"varint,3,opt,name=on_duplicate,json=onDuplicate,proto3,enum=bookService.DuplicatePolicy" json:"on_duplicate,omitempty"`
+	// CSV header or JSON key -> book field, e.g. "Book Title" -> "title".
+	// Replaces the configured columns of the fields it names.
+	Columns map[string]string `protobuf:"bytes,4,rep,name=columns,proto3" json:"columns,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Number of records to pass over, to resume an interrupted import.
+	SkipRecords   int32 `protobuf:"varint,5,opt,name=skip_records,json=skipRecords,proto3" json:"skip_records,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportOptions) Reset() {
+	*x = ImportOptions{}
+	mi := &file_book_service_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateBookRequest) String() string {
+func (x *ImportOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateBookRequest) ProtoMessage() {}
+func (*ImportOptions) ProtoMessage() {}
 
-func (x *UpdateBookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_book_service_proto_msgTypes[3]
+func (x *ImportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -245,68 +4250,76 @@ func (x *UpdateBookRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateBookRequest.ProtoReflect.Descriptor instead.
-func (*UpdateBookRequest) Descriptor() ([]byte, []int) {
-	return file_book_service_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ImportOptions.ProtoReflect.Descriptor instead.
+func (*ImportOptions) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *UpdateBookRequest) GetBookId() string {
+func (x *ImportOptions) GetFormat() CatalogFormat {
 	if x != nil {
-		return x.BookId
+		return x.Format
 	}
-	return ""
+	return CatalogFormat_CATALOG_FORMAT_UNSPECIFIED
 }
 
-func (x *UpdateBookRequest) GetTitle() string {
-	if x != nil && x.Title != nil {
-		return *x.Title
+func (x *ImportOptions) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
 	}
-	return ""
+	return false
 }
 
-func (x *UpdateBookRequest) GetAuthor() string {
-	if x != nil && x.Author != nil {
-		return *x.Author
+func (x *ImportOptions) GetOnDuplicate() DuplicatePolicy {
+	if x != nil {
+		return x.OnDuplicate
 	}
-	return ""
+	return DuplicatePolicy_DUPLICATE_POLICY_UNSPECIFIED
 }
 
-func (x *UpdateBookRequest) GetPublicationYear() int32 {
-	if x != nil && x.PublicationYear != nil {
-		return *x.PublicationYear
+func (x *ImportOptions) GetColumns() map[string]string {
+	if x != nil {
+		return x.Columns
 	}
-	return 0
+	return nil
 }
 
-func (x *UpdateBookRequest) GetGenre() string {
-	if x != nil && x.Genre != nil {
-		return *x.Genre
+func (x *ImportOptions) GetSkipRecords() int32 {
+	if x != nil {
+		return x.SkipRecords
 	}
-	return ""
+	return 0
 }
 
-type DeleteBookRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+type ImportRecordResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 1-based position of the record in the file.
+	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// created, updated, skipped or failed
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// The created book, or the existing book the record duplicates. Empty for
+	// a book a dry run would create.
+	BookId        *string `protobuf:"bytes,3,opt,name=book_id,json=bookId,proto3,oneof" json:"book_id,omitempty"`
+	Title         *string `protobuf:"bytes,4,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Error         *string `protobuf:"bytes,5,opt,name=error,proto3,oneof" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteBookRequest) Reset() {
-	*x = DeleteBookRequest{}
-	mi := &file_book_service_proto_msgTypes[4]
+func (x *ImportRecordResult) Reset() {
+	*x = ImportRecordResult{}
+	mi := &file_book_service_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteBookRequest) String() string {
+func (x *ImportRecordResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteBookRequest) ProtoMessage() {}
+func (*ImportRecordResult) ProtoMessage() {}
 
-func (x *DeleteBookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_book_service_proto_msgTypes[4]
+func (x *ImportRecordResult) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -317,42 +4330,76 @@ func (x *DeleteBookRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteBookRequest.ProtoReflect.Descriptor instead.
-func (*DeleteBookRequest) Descriptor() ([]byte, []int) {
-	return file_book_service_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ImportRecordResult.ProtoReflect.Descriptor instead.
+func (*ImportRecordResult) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ImportRecordResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ImportRecordResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ImportRecordResult) GetBookId() string {
+	if x != nil && x.BookId != nil {
+		return *x.BookId
+	}
+	return ""
+}
+
+func (x *ImportRecordResult) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
 }
 
-func (x *DeleteBookRequest) GetBookId() string {
-	if x != nil {
-		return x.BookId
+func (x *ImportRecordResult) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
 	}
 	return ""
 }
 
-type ListBooksRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Author          *string                `protobuf:"bytes,1,opt,name=author,proto3,oneof" json:"author,omitempty"`
-	PublicationYear *int32                 `protobuf:"varint,2,opt,name=publication_year,json=publicationYear,proto3,oneof" json:"publication_year,omitempty"`
-	Genre           *string                `protobuf:"bytes,3,opt,name=genre,proto3,oneof" json:"genre,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type ImportReport struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Created int32                  `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Skipped int32                  `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Failed  int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Records []*ImportRecordResult  `protobuf:"bytes,4,rep,name=records,proto3" json:"records,omitempty"`
+	// Set when nothing was written and the counts are what would happen.
+	DryRun  bool  `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Updated int32 `protobuf:"varint,6,opt,name=updated,proto3" json:"updated,omitempty"`
+	// Records read, including the ones passed over by skip_records.
+	Processed     int32 `protobuf:"varint,7,opt,name=processed,proto3" json:"processed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListBooksRequest) Reset() {
-	*x = ListBooksRequest{}
-	mi := &file_book_service_proto_msgTypes[5]
+func (x *ImportReport) Reset() {
+	*x = ImportReport{}
+	mi := &file_book_service_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListBooksRequest) String() string {
+func (x *ImportReport) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListBooksRequest) ProtoMessage() {}
+func (*ImportReport) ProtoMessage() {}
 
-func (x *ListBooksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_book_service_proto_msgTypes[5]
+func (x *ImportReport) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -363,54 +4410,84 @@ func (x *ListBooksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListBooksRequest.ProtoReflect.Descriptor instead.
-func (*ListBooksRequest) Descriptor() ([]byte, []int) {
-	return file_book_service_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ImportReport.ProtoReflect.Descriptor instead.
+func (*ImportReport) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *ListBooksRequest) GetAuthor() string {
-	if x != nil && x.Author != nil {
-		return *x.Author
+func (x *ImportReport) GetCreated() int32 {
+	if x != nil {
+		return x.Created
 	}
-	return ""
+	return 0
 }
 
-func (x *ListBooksRequest) GetPublicationYear() int32 {
-	if x != nil && x.PublicationYear != nil {
-		return *x.PublicationYear
+func (x *ImportReport) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
 	}
 	return 0
 }
 
-func (x *ListBooksRequest) GetGenre() string {
-	if x != nil && x.Genre != nil {
-		return *x.Genre
+func (x *ImportReport) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
 	}
-	return ""
+	return 0
 }
 
-type ListBooksResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Books         []*Book                `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`
+func (x *ImportReport) GetRecords() []*ImportRecordResult {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *ImportReport) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportReport) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *ImportReport) GetProcessed() int32 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+type ExportCatalogRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Format CatalogFormat          `protobuf:"varint,1,opt,name=format,proto3,enum=bookService.CatalogFormat" json:"format,omitempty"`
+	// Exports every book when unset.
+	Filter        *ListBooksRequest `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListBooksResponse) Reset() {
-	*x = ListBooksResponse{}
-	mi := &file_book_service_proto_msgTypes[6]
+func (x *ExportCatalogRequest) Reset() {
+	*x = ExportCatalogRequest{}
+	mi := &file_book_service_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListBooksResponse) String() string {
+func (x *ExportCatalogRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListBooksResponse) ProtoMessage() {}
+func (*ExportCatalogRequest) ProtoMessage() {}
 
-func (x *ListBooksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_book_service_proto_msgTypes[6]
+func (x *ExportCatalogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -421,41 +4498,47 @@ func (x *ListBooksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListBooksResponse.ProtoReflect.Descriptor instead.
-func (*ListBooksResponse) Descriptor() ([]byte, []int) {
-	return file_book_service_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ExportCatalogRequest.ProtoReflect.Descriptor instead.
+func (*ExportCatalogRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *ListBooksResponse) GetBooks() []*Book {
+func (x *ExportCatalogRequest) GetFormat() CatalogFormat {
 	if x != nil {
-		return x.Books
+		return x.Format
+	}
+	return CatalogFormat_CATALOG_FORMAT_UNSPECIFIED
+}
+
+func (x *ExportCatalogRequest) GetFilter() *ListBooksRequest {
+	if x != nil {
+		return x.Filter
 	}
 	return nil
 }
 
-type UserBookRequest struct {
+type ExportCatalogChunk struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	BookId        string                 `protobuf:"bytes,2,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserBookRequest) Reset() {
-	*x = UserBookRequest{}
-	mi := &file_book_service_proto_msgTypes[7]
+func (x *ExportCatalogChunk) Reset() {
+	*x = ExportCatalogChunk{}
+	mi := &file_book_service_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserBookRequest) String() string {
+func (x *ExportCatalogChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserBookRequest) ProtoMessage() {}
+func (*ExportCatalogChunk) ProtoMessage() {}
 
-func (x *UserBookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_book_service_proto_msgTypes[7]
+func (x *ExportCatalogChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -466,50 +4549,42 @@ func (x *UserBookRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserBookRequest.ProtoReflect.Descriptor instead.
-func (*UserBookRequest) Descriptor() ([]byte, []int) {
-	return file_book_service_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *UserBookRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
+// Deprecated: Use ExportCatalogChunk.ProtoReflect.Descriptor instead.
+func (*ExportCatalogChunk) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *UserBookRequest) GetBookId() string {
+func (x *ExportCatalogChunk) GetData() []byte {
 	if x != nil {
-		return x.BookId
+		return x.Data
 	}
-	return ""
+	return nil
 }
 
-type GetUserBooksRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Author          *string                `protobuf:"bytes,2,opt,name=author,proto3,oneof" json:"author,omitempty"`
-	PublicationYear *int32                 `protobuf:"varint,3,opt,name=publication_year,json=publicationYear,proto3,oneof" json:"publication_year,omitempty"`
-	Genre           *string                `protobuf:"bytes,4,opt,name=genre,proto3,oneof" json:"genre,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type GetSimilarBooksRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	BookId string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	// 10 when unset.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserBooksRequest) Reset() {
-	*x = GetUserBooksRequest{}
-	mi := &file_book_service_proto_msgTypes[8]
+func (x *GetSimilarBooksRequest) Reset() {
+	*x = GetSimilarBooksRequest{}
+	mi := &file_book_service_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserBooksRequest) String() string {
+func (x *GetSimilarBooksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserBooksRequest) ProtoMessage() {}
+func (*GetSimilarBooksRequest) ProtoMessage() {}
 
-func (x *GetUserBooksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_book_service_proto_msgTypes[8]
+func (x *GetSimilarBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -520,61 +4595,49 @@ func (x *GetUserBooksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserBooksRequest.ProtoReflect.Descriptor instead.
-func (*GetUserBooksRequest) Descriptor() ([]byte, []int) {
-	return file_book_service_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GetSimilarBooksRequest.ProtoReflect.Descriptor instead.
+func (*GetSimilarBooksRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *GetUserBooksRequest) GetUserId() string {
+func (x *GetSimilarBooksRequest) GetBookId() string {
 	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *GetUserBooksRequest) GetAuthor() string {
-	if x != nil && x.Author != nil {
-		return *x.Author
+		return x.BookId
 	}
 	return ""
 }
 
-func (x *GetUserBooksRequest) GetPublicationYear() int32 {
-	if x != nil && x.PublicationYear != nil {
-		return *x.PublicationYear
+func (x *GetSimilarBooksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
 	}
 	return 0
 }
 
-func (x *GetUserBooksRequest) GetGenre() string {
-	if x != nil && x.Genre != nil {
-		return *x.Genre
-	}
-	return ""
-}
-
-type DeleteBookResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+type GetRecommendationsForUserRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// 10 when unset.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteBookResponse) Reset() {
-	*x = DeleteBookResponse{}
-	mi := &file_book_service_proto_msgTypes[9]
+func (x *GetRecommendationsForUserRequest) Reset() {
+	*x = GetRecommendationsForUserRequest{}
+	mi := &file_book_service_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteBookResponse) String() string {
+func (x *GetRecommendationsForUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteBookResponse) ProtoMessage() {}
+func (*GetRecommendationsForUserRequest) ProtoMessage() {}
 
-func (x *DeleteBookResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_book_service_proto_msgTypes[9]
+func (x *GetRecommendationsForUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -585,40 +4648,50 @@ func (x *DeleteBookResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteBookResponse.ProtoReflect.Descriptor instead.
-func (*DeleteBookResponse) Descriptor() ([]byte, []int) {
-	return file_book_service_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use GetRecommendationsForUserRequest.ProtoReflect.Descriptor instead.
+func (*GetRecommendationsForUserRequest) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *DeleteBookResponse) GetBookId() string {
+func (x *GetRecommendationsForUserRequest) GetUserId() string {
 	if x != nil {
-		return x.BookId
+		return x.UserId
 	}
 	return ""
 }
 
-type AddUserBookResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+func (x *GetRecommendationsForUserRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Recommendation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Book  *Book                  `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"`
+	// Normalized co-occurrence from 0 to 1; 0 for the fallback reasons.
+	Score         float64              `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Reason        RecommendationReason `protobuf:"varint,3,opt,name=reason,proto3,enum=bookService.RecommendationReason" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddUserBookResponse) Reset() {
-	*x = AddUserBookResponse{}
-	mi := &file_book_service_proto_msgTypes[10]
+func (x *Recommendation) Reset() {
+	*x = Recommendation{}
+	mi := &file_book_service_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddUserBookResponse) String() string {
+func (x *Recommendation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddUserBookResponse) ProtoMessage() {}
+func (*Recommendation) ProtoMessage() {}
 
-func (x *AddUserBookResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_book_service_proto_msgTypes[10]
+func (x *Recommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -629,40 +4702,54 @@ func (x *AddUserBookResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddUserBookResponse.ProtoReflect.Descriptor instead.
-func (*AddUserBookResponse) Descriptor() ([]byte, []int) {
-	return file_book_service_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use Recommendation.ProtoReflect.Descriptor instead.
+func (*Recommendation) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *AddUserBookResponse) GetBookId() string {
+func (x *Recommendation) GetBook() *Book {
 	if x != nil {
-		return x.BookId
+		return x.Book
 	}
-	return ""
+	return nil
 }
 
-type RemoveBookFromUserResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Recommendation) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
 }
 
-func (x *RemoveBookFromUserResponse) Reset() {
-	*x = RemoveBookFromUserResponse{}
-	mi := &file_book_service_proto_msgTypes[11]
+func (x *Recommendation) GetReason() RecommendationReason {
+	if x != nil {
+		return x.Reason
+	}
+	return RecommendationReason_RECOMMENDATION_REASON_UNSPECIFIED
+}
+
+type RecommendationsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Recommendations []*Recommendation      `protobuf:"bytes,1,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RecommendationsResponse) Reset() {
+	*x = RecommendationsResponse{}
+	mi := &file_book_service_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveBookFromUserResponse) String() string {
+func (x *RecommendationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveBookFromUserResponse) ProtoMessage() {}
+func (*RecommendationsResponse) ProtoMessage() {}
 
-func (x *RemoveBookFromUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_book_service_proto_msgTypes[11]
+func (x *RecommendationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_book_service_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -673,89 +4760,551 @@ func (x *RemoveBookFromUserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveBookFromUserResponse.ProtoReflect.Descriptor instead.
-func (*RemoveBookFromUserResponse) Descriptor() ([]byte, []int) {
-	return file_book_service_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use RecommendationsResponse.ProtoReflect.Descriptor instead.
+func (*RecommendationsResponse) Descriptor() ([]byte, []int) {
+	return file_book_service_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *RemoveBookFromUserResponse) GetBookId() string {
+func (x *RecommendationsResponse) GetRecommendations() []*Recommendation {
 	if x != nil {
-		return x.BookId
+		return x.Recommendations
 	}
-	return ""
+	return nil
 }
 
 var File_book_service_proto protoreflect.FileDescriptor
 
 const file_book_service_proto_rawDesc = "" +
 	"\n" +
-	"\x12book-service.proto\x12\vbookService\"\xb7\x01\n" +
+	"\x12book-service.proto\x12\vbookService\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17validate/validate.proto\"\xf2\x06\n" +
 	"\x04Book\x12\x17\n" +
 	"\abook_id\x18\x01 \x01(\tR\x06bookId\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
 	"\x06author\x18\x03 \x01(\tR\x06author\x12.\n" +
 	"\x10publication_year\x18\x04 \x01(\x05H\x00R\x0fpublicationYear\x88\x01\x01\x12\x19\n" +
-	"\x05genre\x18\x05 \x01(\tH\x01R\x05genre\x88\x01\x01B\x13\n" +
+	"\x05genre\x18\x05 \x01(\tH\x01R\x05genre\x88\x01\x01\x12\x16\n" +
+	"\x06genres\x18\x06 \x03(\tR\x06genres\x12\x1b\n" +
+	"\x06rating\x18\a \x01(\x01H\x02R\x06rating\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12 \n" +
+	"\tcover_url\x18\t \x01(\tH\x03R\bcoverUrl\x88\x01\x01\x12(\n" +
+	"\rthumbnail_url\x18\n" +
+	" \x01(\tH\x04R\fthumbnailUrl\x88\x01\x01\x12!\n" +
+	"\tpublisher\x18\v \x01(\tH\x05R\tpublisher\x88\x01\x01\x12\x1f\n" +
+	"\blanguage\x18\f \x01(\tH\x06R\blanguage\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_count\x18\r \x01(\x05H\aR\tpageCount\x88\x01\x01\x12\x1d\n" +
+	"\aedition\x18\x0e \x01(\tH\bR\aedition\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x0f \x01(\tH\tR\vdescription\x88\x01\x01\x12\x1a\n" +
+	"\bsubjects\x18\x10 \x03(\tR\bsubjects\x12$\n" +
+	"\vseries_name\x18\x11 \x01(\tH\n" +
+	"R\n" +
+	"seriesName\x88\x01\x01\x12(\n" +
+	"\rseries_number\x18\x12 \x01(\x05H\vR\fseriesNumber\x88\x01\x01\x12 \n" +
+	"\tseries_id\x18\x13 \x01(\tH\fR\bseriesId\x88\x01\x01\x12\x17\n" +
+	"\x04isbn\x18\x14 \x01(\tH\rR\x04isbn\x88\x01\x01B\x13\n" +
 	"\x11_publication_yearB\b\n" +
-	"\x06_genre\"\xa8\x01\n" +
-	"\x0eAddBookRequest\x12\x14\n" +
-	"\x05title\x18\x01 \x01(\tR\x05title\x12\x16\n" +
-	"\x06author\x18\x02 \x01(\tR\x06author\x12.\n" +
-	"\x10publication_year\x18\x03 \x01(\x05H\x00R\x0fpublicationYear\x88\x01\x01\x12\x19\n" +
-	"\x05genre\x18\x04 \x01(\tH\x01R\x05genre\x88\x01\x01B\x13\n" +
+	"\x06_genreB\t\n" +
+	"\a_ratingB\f\n" +
+	"\n" +
+	"_cover_urlB\x10\n" +
+	"\x0e_thumbnail_urlB\f\n" +
+	"\n" +
+	"_publisherB\v\n" +
+	"\t_languageB\r\n" +
+	"\v_page_countB\n" +
+	"\n" +
+	"\b_editionB\x0e\n" +
+	"\f_descriptionB\x0e\n" +
+	"\f_series_nameB\x10\n" +
+	"\x0e_series_numberB\f\n" +
+	"\n" +
+	"_series_idB\a\n" +
+	"\x05_isbn\"\x89\x06\n" +
+	"\x0eAddBookRequest\x12\x1f\n" +
+	"\x05title\x18\x01 \x01(\tB\t\x92\x82\x19\x05\b\x01\x18\xff\x01R\x05title\x12!\n" +
+	"\x06author\x18\x02 \x01(\tB\t\x92\x82\x19\x05\b\x01\x18\xff\x01R\x06author\x128\n" +
+	"\x10publication_year\x18\x03 \x01(\x05B\b\x92\x82\x19\x048\x01H\x01H\x00R\x0fpublicationYear\x88\x01\x01\x12!\n" +
+	"\x05genre\x18\x04 \x01(\tB\x06\x92\x82\x19\x02\x18dH\x01R\x05genre\x88\x01\x01\x123\n" +
+	"\x06rating\x18\x05 \x01(\x01B\x16\x92\x82\x19\x12Y\x00\x00\x00\x00\x00\x00\x00\x00a\x00\x00\x00\x00\x00\x00\x14@H\x02R\x06rating\x88\x01\x01\x12*\n" +
+	"\tpublisher\x18\x06 \x01(\tB\a\x92\x82\x19\x03\x18\xff\x01H\x03R\tpublisher\x88\x01\x01\x12)\n" +
+	"\blanguage\x18\a \x01(\tB\b\x92\x82\x19\x04\x18#p\x01H\x04R\blanguage\x88\x01\x01\x12.\n" +
+	"\n" +
+	"page_count\x18\b \x01(\x05B\n" +
+	"\x92\x82\x19\x068\x01@\xa0\x8d\x06H\x05R\tpageCount\x88\x01\x01\x12%\n" +
+	"\aedition\x18\t \x01(\tB\x06\x92\x82\x19\x02\x18dH\x06R\aedition\x88\x01\x01\x12.\n" +
+	"\vdescription\x18\n" +
+	" \x01(\tB\a\x92\x82\x19\x03\x18\x90NH\aR\vdescription\x88\x01\x01\x12$\n" +
+	"\bsubjects\x18\v \x03(\tB\b\x92\x82\x19\x04\x18dP2R\bsubjects\x12-\n" +
+	"\vseries_name\x18\f \x01(\tB\a\x92\x82\x19\x03\x18\xff\x01H\bR\n" +
+	"seriesName\x88\x01\x01\x120\n" +
+	"\rseries_number\x18\r \x01(\x05B\x06\x92\x82\x19\x028\x01H\tR\fseriesNumber\x88\x01\x01\x12!\n" +
+	"\x04isbn\x18\x0e \x01(\tB\b\x92\x82\x19\x04\x18\x11x\x01H\n" +
+	"R\x04isbn\x88\x01\x01B\x13\n" +
 	"\x11_publication_yearB\b\n" +
-	"\x06_genre\")\n" +
-	"\x0eGetBookRequest\x12\x17\n" +
-	"\abook_id\x18\x01 \x01(\tR\x06bookId\"\xe3\x01\n" +
-	"\x11UpdateBookRequest\x12\x17\n" +
-	"\abook_id\x18\x01 \x01(\tR\x06bookId\x12\x19\n" +
-	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12\x1b\n" +
-	"\x06author\x18\x03 \x01(\tH\x01R\x06author\x88\x01\x01\x12.\n" +
-	"\x10publication_year\x18\x04 \x01(\x05H\x02R\x0fpublicationYear\x88\x01\x01\x12\x19\n" +
-	"\x05genre\x18\x05 \x01(\tH\x03R\x05genre\x88\x01\x01B\b\n" +
+	"\x06_genreB\t\n" +
+	"\a_ratingB\f\n" +
+	"\n" +
+	"_publisherB\v\n" +
+	"\t_languageB\r\n" +
+	"\v_page_countB\n" +
+	"\n" +
+	"\b_editionB\x0e\n" +
+	"\f_descriptionB\x0e\n" +
+	"\f_series_nameB\x10\n" +
+	"\x0e_series_numberB\a\n" +
+	"\x05_isbn\"O\n" +
+	"\x0fAddBooksRequest\x12<\n" +
+	"\x05books\x18\x01 \x03(\v2\x1b.bookService.AddBookRequestB\t\x92\x82\x19\x05\b\x01P\xe8\aR\x05books\";\n" +
+	"\x10AddBooksResponse\x12'\n" +
+	"\x05books\x18\x01 \x03(\v2\x11.bookService.BookR\x05books\"3\n" +
+	"\x0eGetBookRequest\x12!\n" +
+	"\abook_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06bookId\"\xf1\x06\n" +
+	"\x11UpdateBookRequest\x12!\n" +
+	"\abook_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06bookId\x12$\n" +
+	"\x05title\x18\x02 \x01(\tB\t\x92\x82\x19\x05\x10\x01\x18\xff\x01H\x00R\x05title\x88\x01\x01\x12&\n" +
+	"\x06author\x18\x03 \x01(\tB\t\x92\x82\x19\x05\x10\x01\x18\xff\x01H\x01R\x06author\x88\x01\x01\x128\n" +
+	"\x10publication_year\x18\x04 \x01(\x05B\b\x92\x82\x19\x048\x01H\x01H\x02R\x0fpublicationYear\x88\x01\x01\x12!\n" +
+	"\x05genre\x18\x05 \x01(\tB\x06\x92\x82\x19\x02\x18dH\x03R\x05genre\x88\x01\x01\x123\n" +
+	"\x06rating\x18\x06 \x01(\x01B\x16\x92\x82\x19\x12Y\x00\x00\x00\x00\x00\x00\x00\x00a\x00\x00\x00\x00\x00\x00\x14@H\x04R\x06rating\x88\x01\x01\x12*\n" +
+	"\tpublisher\x18\a \x01(\tB\a\x92\x82\x19\x03\x18\xff\x01H\x05R\tpublisher\x88\x01\x01\x12)\n" +
+	"\blanguage\x18\b \x01(\tB\b\x92\x82\x19\x04\x18#p\x01H\x06R\blanguage\x88\x01\x01\x12.\n" +
+	"\n" +
+	"page_count\x18\t \x01(\x05B\n" +
+	"\x92\x82\x19\x068\x01@\xa0\x8d\x06H\aR\tpageCount\x88\x01\x01\x12%\n" +
+	"\aedition\x18\n" +
+	" \x01(\tB\x06\x92\x82\x19\x02\x18dH\bR\aedition\x88\x01\x01\x12.\n" +
+	"\vdescription\x18\v \x01(\tB\a\x92\x82\x19\x03\x18\x90NH\tR\vdescription\x88\x01\x01\x12$\n" +
+	"\bsubjects\x18\f \x03(\tB\b\x92\x82\x19\x04\x18dP2R\bsubjects\x12!\n" +
+	"\fset_subjects\x18\r \x01(\bR\vsetSubjects\x12-\n" +
+	"\vseries_name\x18\x0e \x01(\tB\a\x92\x82\x19\x03\x18\xff\x01H\n" +
+	"R\n" +
+	"seriesName\x88\x01\x01\x120\n" +
+	"\rseries_number\x18\x0f \x01(\x05B\x06\x92\x82\x19\x028\x01H\vR\fseriesNumber\x88\x01\x01\x12!\n" +
+	"\x04isbn\x18\x10 \x01(\tB\b\x92\x82\x19\x04\x18\x11x\x01H\fR\x04isbn\x88\x01\x01B\b\n" +
 	"\x06_titleB\t\n" +
 	"\a_authorB\x13\n" +
 	"\x11_publication_yearB\b\n" +
-	"\x06_genre\",\n" +
-	"\x11DeleteBookRequest\x12\x17\n" +
-	"\abook_id\x18\x01 \x01(\tR\x06bookId\"\xa4\x01\n" +
-	"\x10ListBooksRequest\x12\x1b\n" +
-	"\x06author\x18\x01 \x01(\tH\x00R\x06author\x88\x01\x01\x12.\n" +
-	"\x10publication_year\x18\x02 \x01(\x05H\x01R\x0fpublicationYear\x88\x01\x01\x12\x19\n" +
-	"\x05genre\x18\x03 \x01(\tH\x02R\x05genre\x88\x01\x01B\t\n" +
+	"\x06_genreB\t\n" +
+	"\a_ratingB\f\n" +
+	"\n" +
+	"_publisherB\v\n" +
+	"\t_languageB\r\n" +
+	"\v_page_countB\n" +
+	"\n" +
+	"\b_editionB\x0e\n" +
+	"\f_descriptionB\x0e\n" +
+	"\f_series_nameB\x10\n" +
+	"\x0e_series_numberB\a\n" +
+	"\x05_isbn\"6\n" +
+	"\x11DeleteBookRequest\x12!\n" +
+	"\abook_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06bookId\"\xe7\x05\n" +
+	"\x10ListBooksRequest\x12$\n" +
+	"\x06author\x18\x01 \x01(\tB\a\x92\x82\x19\x03\x18\xff\x01H\x00R\x06author\x88\x01\x01\x128\n" +
+	"\x10publication_year\x18\x02 \x01(\x05B\b\x92\x82\x19\x048\x01H\x01H\x01R\x0fpublicationYear\x88\x01\x01\x12!\n" +
+	"\x05genre\x18\x03 \x01(\tB\x06\x92\x82\x19\x02\x18dH\x02R\x05genre\x88\x01\x01\x12#\n" +
+	"\aauthors\x18\x04 \x03(\tB\t\x92\x82\x19\x05\x18\xff\x01P2R\aauthors\x12 \n" +
+	"\x06genres\x18\x05 \x03(\tB\b\x92\x82\x19\x04\x18dP2R\x06genres\x12?\n" +
+	"\x15publication_year_from\x18\x06 \x01(\x05B\x06\x92\x82\x19\x028\x01H\x03R\x13publicationYearFrom\x88\x01\x01\x12;\n" +
+	"\x13publication_year_to\x18\a \x01(\x05B\x06\x92\x82\x19\x028\x01H\x04R\x11publicationYearTo\x88\x01\x01\x12/\n" +
+	"\ftitle_prefix\x18\b \x01(\tB\a\x92\x82\x19\x03\x18\xff\x01H\x05R\vtitlePrefix\x88\x01\x01\x12;\n" +
+	"\asort_by\x18\t \x01(\x0e2\x1a.bookService.BookSortFieldB\x06\x92\x82\x19\x02h\x01R\x06sortBy\x12I\n" +
+	"\x0esort_direction\x18\n" +
+	" \x01(\x0e2\x1a.bookService.SortDirectionB\x06\x92\x82\x19\x02h\x01R\rsortDirection\x12'\n" +
+	"\blanguage\x18\v \x01(\tB\x06\x92\x82\x19\x02\x18#H\x06R\blanguage\x88\x01\x01\x12$\n" +
+	"\x06series\x18\f \x01(\tB\a\x92\x82\x19\x03\x18\xff\x01H\aR\x06series\x88\x01\x01B\t\n" +
 	"\a_authorB\x13\n" +
 	"\x11_publication_yearB\b\n" +
-	"\x06_genre\"<\n" +
+	"\x06_genreB\x18\n" +
+	"\x16_publication_year_fromB\x16\n" +
+	"\x14_publication_year_toB\x0f\n" +
+	"\r_title_prefixB\v\n" +
+	"\t_languageB\t\n" +
+	"\a_series\"\x82\x01\n" +
 	"\x11ListBooksResponse\x12'\n" +
-	"\x05books\x18\x01 \x03(\v2\x11.bookService.BookR\x05books\"C\n" +
-	"\x0fUserBookRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
-	"\abook_id\x18\x02 \x01(\tR\x06bookId\"\xc0\x01\n" +
-	"\x13GetUserBooksRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
-	"\x06author\x18\x02 \x01(\tH\x00R\x06author\x88\x01\x01\x12.\n" +
-	"\x10publication_year\x18\x03 \x01(\x05H\x01R\x0fpublicationYear\x88\x01\x01\x12\x19\n" +
-	"\x05genre\x18\x04 \x01(\tH\x02R\x05genre\x88\x01\x01B\t\n" +
+	"\x05books\x18\x01 \x03(\v2\x11.bookService.BookR\x05books\x12D\n" +
+	"\x0fseries_progress\x18\x02 \x03(\v2\x1b.bookService.SeriesProgressR\x0eseriesProgress\"\x92\x05\n" +
+	"\x14GetBookFacetsRequest\x12$\n" +
+	"\x06author\x18\x01 \x01(\tB\a\x92\x82\x19\x03\x18\xff\x01H\x00R\x06author\x88\x01\x01\x128\n" +
+	"\x10publication_year\x18\x02 \x01(\x05B\b\x92\x82\x19\x048\x01H\x01H\x01R\x0fpublicationYear\x88\x01\x01\x12!\n" +
+	"\x05genre\x18\x03 \x01(\tB\x06\x92\x82\x19\x02\x18dH\x02R\x05genre\x88\x01\x01\x12#\n" +
+	"\aauthors\x18\x04 \x03(\tB\t\x92\x82\x19\x05\x18\xff\x01P2R\aauthors\x12 \n" +
+	"\x06genres\x18\x05 \x03(\tB\b\x92\x82\x19\x04\x18dP2R\x06genres\x12?\n" +
+	"\x15publication_year_from\x18\x06 \x01(\x05B\x06\x92\x82\x19\x028\x01H\x03R\x13publicationYearFrom\x88\x01\x01\x12;\n" +
+	"\x13publication_year_to\x18\a \x01(\x05B\x06\x92\x82\x19\x028\x01H\x04R\x11publicationYearTo\x88\x01\x01\x12/\n" +
+	"\ftitle_prefix\x18\b \x01(\tB\a\x92\x82\x19\x03\x18\xff\x01H\x05R\vtitlePrefix\x88\x01\x01\x12#\n" +
+	"\x05limit\x18\t \x01(\x05B\b\x92\x82\x19\x048\x01@dH\x06R\x05limit\x88\x01\x01\x12'\n" +
+	"\blanguage\x18\n" +
+	" \x01(\tB\x06\x92\x82\x19\x02\x18#H\aR\blanguage\x88\x01\x01\x12$\n" +
+	"\x06series\x18\v \x01(\tB\a\x92\x82\x19\x03\x18\xff\x01H\bR\x06series\x88\x01\x01B\t\n" +
+	"\a_authorB\x13\n" +
+	"\x11_publication_yearB\b\n" +
+	"\x06_genreB\x18\n" +
+	"\x16_publication_year_fromB\x16\n" +
+	"\x14_publication_year_toB\x0f\n" +
+	"\r_title_prefixB\b\n" +
+	"\x06_limitB\v\n" +
+	"\t_languageB\t\n" +
+	"\a_series\"8\n" +
+	"\n" +
+	"FacetCount\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\xa3\x01\n" +
+	"\n" +
+	"BookFacets\x12/\n" +
+	"\x06genres\x18\x01 \x03(\v2\x17.bookService.FacetCountR\x06genres\x121\n" +
+	"\aauthors\x18\x02 \x03(\v2\x17.bookService.FacetCountR\aauthors\x121\n" +
+	"\adecades\x18\x03 \x03(\v2\x17.bookService.FacetCountR\adecades\"W\n" +
+	"\x0fUserBookRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06userId\x12!\n" +
+	"\abook_id\x18\x02 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06bookId\"\xea\x06\n" +
+	"\x13GetUserBooksRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06userId\x12$\n" +
+	"\x06author\x18\x02 \x01(\tB\a\x92\x82\x19\x03\x18\xff\x01H\x00R\x06author\x88\x01\x01\x128\n" +
+	"\x10publication_year\x18\x03 \x01(\x05B\b\x92\x82\x19\x048\x01H\x01H\x01R\x0fpublicationYear\x88\x01\x01\x12!\n" +
+	"\x05genre\x18\x04 \x01(\tB\x06\x92\x82\x19\x02\x18dH\x02R\x05genre\x88\x01\x01\x12#\n" +
+	"\aauthors\x18\x05 \x03(\tB\t\x92\x82\x19\x05\x18\xff\x01P2R\aauthors\x12 \n" +
+	"\x06genres\x18\x06 \x03(\tB\b\x92\x82\x19\x04\x18dP2R\x06genres\x12?\n" +
+	"\x15publication_year_from\x18\a \x01(\x05B\x06\x92\x82\x19\x028\x01H\x03R\x13publicationYearFrom\x88\x01\x01\x12;\n" +
+	"\x13publication_year_to\x18\b \x01(\x05B\x06\x92\x82\x19\x028\x01H\x04R\x11publicationYearTo\x88\x01\x01\x12/\n" +
+	"\ftitle_prefix\x18\t \x01(\tB\a\x92\x82\x19\x03\x18\xff\x01H\x05R\vtitlePrefix\x88\x01\x01\x12;\n" +
+	"\asort_by\x18\n" +
+	" \x01(\x0e2\x1a.bookService.BookSortFieldB\x06\x92\x82\x19\x02h\x01R\x06sortBy\x12I\n" +
+	"\x0esort_direction\x18\v \x01(\x0e2\x1a.bookService.SortDirectionB\x06\x92\x82\x19\x02h\x01R\rsortDirection\x12'\n" +
+	"\blanguage\x18\f \x01(\tB\x06\x92\x82\x19\x02\x18#H\x06R\blanguage\x88\x01\x01\x12$\n" +
+	"\x06series\x18\r \x01(\tB\a\x92\x82\x19\x03\x18\xff\x01H\aR\x06series\x88\x01\x01\x12&\n" +
+	"\x0fgroup_by_series\x18\x0e \x01(\bR\rgroupBySeries\x12&\n" +
+	"\bshelf_id\x18\x0f \x01(\tB\x06\x92\x82\x19\x02 \x01H\bR\ashelfId\x88\x01\x01B\t\n" +
 	"\a_authorB\x13\n" +
 	"\x11_publication_yearB\b\n" +
-	"\x06_genre\"-\n" +
+	"\x06_genreB\x18\n" +
+	"\x16_publication_year_fromB\x16\n" +
+	"\x14_publication_year_toB\x0f\n" +
+	"\r_title_prefixB\v\n" +
+	"\t_languageB\t\n" +
+	"\a_seriesB\v\n" +
+	"\t_shelf_id\"W\n" +
+	"\x12UploadCoverRequest\x12!\n" +
+	"\abook_id\x18\x01 \x01(\tB\x06\x92\x82\x19\x02 \x01H\x00R\x06bookId\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"\x99\x01\n" +
+	"\x05Cover\x12\x17\n" +
+	"\abook_id\x18\x01 \x01(\tR\x06bookId\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x1b\n" +
+	"\tcover_url\x18\x04 \x01(\tR\bcoverUrl\x12#\n" +
+	"\rthumbnail_url\x18\x05 \x01(\tR\fthumbnailUrl\"R\n" +
+	"\x0fGetCoverRequest\x12!\n" +
+	"\abook_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06bookId\x12\x1c\n" +
+	"\tthumbnail\x18\x02 \x01(\bR\tthumbnail\"W\n" +
+	"\n" +
+	"CoverChunk\x12!\n" +
+	"\fcontent_type\x18\x01 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"\xb8\x01\n" +
+	"\x06Series\x12\x1b\n" +
+	"\tseries_id\x18\x01 \x01(\tR\bseriesId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"book_count\x18\x04 \x01(\x05R\tbookCount\x12'\n" +
+	"\x05books\x18\x05 \x03(\v2\x11.bookService.BookR\x05booksB\x0e\n" +
+	"\f_description\"\x88\x01\n" +
+	"\x0eSeriesProgress\x12\x1b\n" +
+	"\tseries_id\x18\x01 \x01(\tR\bseriesId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05owned\x18\x03 \x01(\x05R\x05owned\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12\x19\n" +
+	"\bbook_ids\x18\x05 \x03(\tR\abookIds\"\x13\n" +
+	"\x11ListSeriesRequest\"A\n" +
+	"\x12ListSeriesResponse\x12+\n" +
+	"\x06series\x18\x01 \x03(\v2\x13.bookService.SeriesR\x06series\"9\n" +
+	"\x10GetSeriesRequest\x12%\n" +
+	"\tseries_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\bseriesId\"b\n" +
+	"\x16AddSeriesToUserRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06userId\x12%\n" +
+	"\tseries_id\x18\x02 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\bseriesId\"4\n" +
+	"\x17AddSeriesToUserResponse\x12\x19\n" +
+	"\bbook_ids\x18\x01 \x03(\tR\abookIds\"\x81\x03\n" +
+	"\x05Shelf\x12\x19\n" +
+	"\bshelf_id\x18\x01 \x01(\tR\ashelfId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12<\n" +
+	"\n" +
+	"visibility\x18\x04 \x01(\x0e2\x1c.bookService.ShelfVisibilityR\n" +
+	"visibility\x12$\n" +
+	"\vshare_token\x18\x05 \x01(\tH\x00R\n" +
+	"shareToken\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"book_count\x18\x06 \x01(\x05R\tbookCount\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12'\n" +
+	"\x05books\x18\t \x03(\v2\x11.bookService.BookR\x05booksB\x0e\n" +
+	"\f_share_token\"\x9b\x01\n" +
+	"\x12CreateShelfRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06userId\x12\x1c\n" +
+	"\x04name\x18\x02 \x01(\tB\b\x92\x82\x19\x04\b\x01\x18dR\x04name\x12D\n" +
+	"\n" +
+	"visibility\x18\x03 \x01(\x0e2\x1c.bookService.ShelfVisibilityB\x06\x92\x82\x19\x02h\x01R\n" +
+	"visibility\"z\n" +
+	"\x12RenameShelfRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06userId\x12#\n" +
+	"\bshelf_id\x18\x02 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\ashelfId\x12\x1c\n" +
+	"\x04name\x18\x03 \x01(\tB\b\x92\x82\x19\x04\b\x01\x18dR\x04name\"\xab\x01\n" +
+	"\x19SetShelfVisibilityRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06userId\x12#\n" +
+	"\bshelf_id\x18\x02 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\ashelfId\x12F\n" +
+	"\n" +
+	"visibility\x18\x03 \x01(\x0e2\x1c.bookService.ShelfVisibilityB\b\x92\x82\x19\x04\b\x01h\x01R\n" +
+	"visibility\"\\\n" +
+	"\x12DeleteShelfRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06userId\x12#\n" +
+	"\bshelf_id\x18\x02 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\ashelfId\"0\n" +
+	"\x13DeleteShelfResponse\x12\x19\n" +
+	"\bshelf_id\x18\x01 \x01(\tR\ashelfId\"7\n" +
+	"\x12ListShelvesRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06userId\"C\n" +
+	"\x13ListShelvesResponse\x12,\n" +
+	"\ashelves\x18\x01 \x03(\v2\x12.bookService.ShelfR\ashelves\"}\n" +
+	"\x10ShelfBookRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06userId\x12#\n" +
+	"\bshelf_id\x18\x02 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\ashelfId\x12!\n" +
+	"\abook_id\x18\x03 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06bookId\"\x83\x01\n" +
+	"\x13ReorderShelfRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06userId\x12#\n" +
+	"\bshelf_id\x18\x02 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\ashelfId\x12$\n" +
+	"\bbook_ids\x18\x03 \x03(\tB\t\x92\x82\x19\x05 \x01P\x90NR\abookIds\"t\n" +
+	"\x0fGetShelfRequest\x12#\n" +
+	"\bshelf_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\ashelfId\x12,\n" +
+	"\vshare_token\x18\x02 \x01(\tB\x06\x92\x82\x19\x02\x18@H\x00R\n" +
+	"shareToken\x88\x01\x01B\x0e\n" +
+	"\f_share_token\"-\n" +
 	"\x12DeleteBookResponse\x12\x17\n" +
 	"\abook_id\x18\x01 \x01(\tR\x06bookId\".\n" +
 	"\x13AddUserBookResponse\x12\x17\n" +
 	"\abook_id\x18\x01 \x01(\tR\x06bookId\"5\n" +
 	"\x1aRemoveBookFromUserResponse\x12\x17\n" +
-	"\abook_id\x18\x01 \x01(\tR\x06bookId2\xdf\x04\n" +
-	"\vBookService\x129\n" +
-	"\aAddBook\x12\x1b.bookService.AddBookRequest\x1a\x11.bookService.Book\x129\n" +
-	"\aGetBook\x12\x1b.bookService.GetBookRequest\x1a\x11.bookService.Book\x12?\n" +
+	"\abook_id\x18\x01 \x01(\tR\x06bookId\"\xae\x01\n" +
+	"\aWebhook\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\tR\twebhookId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x03 \x03(\tR\n" +
+	"eventTypes\x12\x16\n" +
+	"\x06secret\x18\x04 \x01(\tR\x06secret\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xbd\x01\n" +
+	"\x14CreateWebhookRequest\x12\x1d\n" +
+	"\x03url\x18\x01 \x01(\tB\v\x92\x82\x19\a\b\x01\x18\x80\x10(\x01R\x03url\x12S\n" +
+	"\vevent_types\x18\x02 \x03(\tB2\x92\x82\x19.\b\x012\fbook.created2\fbook.updated2\fbook.deletedP\x03R\n" +
+	"eventTypes\x12&\n" +
+	"\x06secret\x18\x03 \x01(\tB\t\x92\x82\x19\x05\x10\x10\x18\xff\x01H\x00R\x06secret\x88\x01\x01B\t\n" +
+	"\a_secret\"\x15\n" +
+	"\x13ListWebhooksRequest\"H\n" +
+	"\x14ListWebhooksResponse\x120\n" +
+	"\bwebhooks\x18\x01 \x03(\v2\x14.bookService.WebhookR\bwebhooks\"?\n" +
+	"\x14DeleteWebhookRequest\x12'\n" +
 	"\n" +
-	"UpdateBook\x12\x1e.bookService.UpdateBookRequest\x1a\x11.bookService.Book\x12M\n" +
+	"webhook_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\twebhookId\"6\n" +
+	"\x15DeleteWebhookResponse\x12\x1d\n" +
 	"\n" +
-	"DeleteBook\x12\x1e.bookService.DeleteBookRequest\x1a\x1f.bookService.DeleteBookResponse\x12J\n" +
-	"\tListBooks\x12\x1d.bookService.ListBooksRequest\x1a\x1e.bookService.ListBooksResponse\x12O\n" +
-	"\rAddBookToUser\x12\x1c.bookService.UserBookRequest\x1a .bookService.AddUserBookResponse\x12[\n" +
-	"\x12RemoveBookFromUser\x12\x1c.bookService.UserBookRequest\x1a'.bookService.RemoveBookFromUserResponse\x12P\n" +
-	"\fGetUserBooks\x12 .bookService.GetUserBooksRequest\x1a\x1e.bookService.ListBooksResponseB*Z(bookService/internal/delivery/protos/genb\x06proto3"
+	"webhook_id\x18\x01 \x01(\tR\twebhookId\"\x97\x03\n" +
+	"\x0fWebhookDelivery\x12\x1f\n" +
+	"\vdelivery_id\x18\x01 \x01(\tR\n" +
+	"deliveryId\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\tR\twebhookId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\tR\teventType\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1a\n" +
+	"\battempts\x18\x05 \x01(\x05R\battempts\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x06 \x01(\tR\tlastError\x12B\n" +
+	"\x0fnext_attempt_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rnextAttemptAt\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12B\n" +
+	"\fdelivered_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampH\x00R\vdeliveredAt\x88\x01\x01B\x0f\n" +
+	"\r_delivered_at\"\xbe\x01\n" +
+	"\x1cListWebhookDeliveriesRequest\x12'\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\twebhookId\x12;\n" +
+	"\x06status\x18\x02 \x01(\tB\x1e\x92\x82\x19\x1a2\apending2\tdelivered2\x04deadH\x00R\x06status\x88\x01\x01\x12#\n" +
+	"\x05limit\x18\x03 \x01(\x05B\b\x92\x82\x19\x048\x01@dH\x01R\x05limit\x88\x01\x01B\t\n" +
+	"\a_statusB\b\n" +
+	"\x06_limit\"]\n" +
+	"\x1dListWebhookDeliveriesResponse\x12<\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x1c.bookService.WebhookDeliveryR\n" +
+	"deliveries\"\x80\x01\n" +
+	"\x05Genre\x12\x19\n" +
+	"\bgenre_id\x18\x01 \x01(\tR\agenreId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\tparent_id\x18\x03 \x01(\tH\x00R\bparentId\x88\x01\x01\x12\x18\n" +
+	"\aaliases\x18\x04 \x03(\tR\aaliasesB\f\n" +
+	"\n" +
+	"_parent_id\"\x8e\x01\n" +
+	"\x12CreateGenreRequest\x12\x1c\n" +
+	"\x04name\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01\x18dR\x04name\x12(\n" +
+	"\tparent_id\x18\x02 \x01(\tB\x06\x92\x82\x19\x02 \x01H\x00R\bparentId\x88\x01\x01\x12\"\n" +
+	"\aaliases\x18\x03 \x03(\tB\b\x92\x82\x19\x04\x18dP\x14R\aaliasesB\f\n" +
+	"\n" +
+	"_parent_id\"\x85\x02\n" +
+	"\x12UpdateGenreRequest\x12#\n" +
+	"\bgenre_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\agenreId\x12!\n" +
+	"\x04name\x18\x02 \x01(\tB\b\x92\x82\x19\x04\x10\x01\x18dH\x00R\x04name\x88\x01\x01\x12(\n" +
+	"\tparent_id\x18\x03 \x01(\tB\x06\x92\x82\x19\x02 \x01H\x01R\bparentId\x88\x01\x01\x12!\n" +
+	"\fclear_parent\x18\x04 \x01(\bR\vclearParent\x12\"\n" +
+	"\aaliases\x18\x05 \x03(\tB\b\x92\x82\x19\x04\x18dP\x14R\aaliases\x12\x1f\n" +
+	"\vset_aliases\x18\x06 \x01(\bR\n" +
+	"setAliasesB\a\n" +
+	"\x05_nameB\f\n" +
+	"\n" +
+	"_parent_id\"9\n" +
+	"\x12DeleteGenreRequest\x12#\n" +
+	"\bgenre_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\agenreId\"0\n" +
+	"\x13DeleteGenreResponse\x12\x19\n" +
+	"\bgenre_id\x18\x01 \x01(\tR\agenreId\"\x13\n" +
+	"\x11ListGenresRequest\"@\n" +
+	"\x12ListGenresResponse\x12*\n" +
+	"\x06genres\x18\x01 \x03(\v2\x12.bookService.GenreR\x06genres\"`\n" +
+	"\x14SetBookGenresRequest\x12!\n" +
+	"\abook_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06bookId\x12%\n" +
+	"\tgenre_ids\x18\x02 \x03(\tB\b\x92\x82\x19\x04 \x01P\x14R\bgenreIds\"H\n" +
+	"\x15SetBookGenresResponse\x12\x17\n" +
+	"\abook_id\x18\x01 \x01(\tR\x06bookId\x12\x16\n" +
+	"\x06genres\x18\x02 \x03(\tR\x06genres\"n\n" +
+	"\x14ImportCatalogRequest\x126\n" +
+	"\aoptions\x18\x01 \x01(\v2\x1a.bookService.ImportOptionsH\x00R\aoptions\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"\xd9\x02\n" +
+	"\rImportOptions\x12<\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x1a.bookService.CatalogFormatB\b\x92\x82\x19\x04\b\x01h\x01R\x06format\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12G\n" +
+	"\fon_duplicate\x18\x03 \x01(\x0e2\x1c.bookService.DuplicatePolicyB\x06\x92\x82\x19\x02h\x01R\vonDuplicate\x12A\n" +
+	"\acolumns\x18\x04 \x03(\v2'.bookService.ImportOptions.ColumnsEntryR\acolumns\x12)\n" +
+	"\fskip_records\x18\x05 \x01(\x05B\x06\x92\x82\x19\x028\x00R\vskipRecords\x1a:\n" +
+	"\fColumnsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb6\x01\n" +
+	"\x12ImportRecordResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1c\n" +
+	"\abook_id\x18\x03 \x01(\tH\x00R\x06bookId\x88\x01\x01\x12\x19\n" +
+	"\x05title\x18\x04 \x01(\tH\x01R\x05title\x88\x01\x01\x12\x19\n" +
+	"\x05error\x18\x05 \x01(\tH\x02R\x05error\x88\x01\x01B\n" +
+	"\n" +
+	"\b_book_idB\b\n" +
+	"\x06_titleB\b\n" +
+	"\x06_error\"\xe6\x01\n" +
+	"\fImportReport\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\x05R\acreated\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x05R\askipped\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\x129\n" +
+	"\arecords\x18\x04 \x03(\v2\x1f.bookService.ImportRecordResultR\arecords\x12\x17\n" +
+	"\adry_run\x18\x05 \x01(\bR\x06dryRun\x12\x18\n" +
+	"\aupdated\x18\x06 \x01(\x05R\aupdated\x12\x1c\n" +
+	"\tprocessed\x18\a \x01(\x05R\tprocessed\"\x8b\x01\n" +
+	"\x14ExportCatalogRequest\x12<\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x1a.bookService.CatalogFormatB\b\x92\x82\x19\x04\b\x01h\x01R\x06format\x125\n" +
+	"\x06filter\x18\x02 \x01(\v2\x1d.bookService.ListBooksRequestR\x06filter\"(\n" +
+	"\x12ExportCatalogChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"[\n" +
+	"\x16GetSimilarBooksRequest\x12!\n" +
+	"\abook_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06bookId\x12\x1e\n" +
+	"\x05limit\x18\x02 \x01(\x05B\b\x92\x82\x19\x048\x00@dR\x05limit\"e\n" +
+	" GetRecommendationsForUserRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\x92\x82\x19\x04\b\x01 \x01R\x06userId\x12\x1e\n" +
+	"\x05limit\x18\x02 \x01(\x05B\b\x92\x82\x19\x048\x00@dR\x05limit\"\x88\x01\n" +
+	"\x0eRecommendation\x12%\n" +
+	"\x04book\x18\x01 \x01(\v2\x11.bookService.BookR\x04book\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x129\n" +
+	"\x06reason\x18\x03 \x01(\x0e2!.bookService.RecommendationReasonR\x06reason\"`\n" +
+	"\x17RecommendationsResponse\x12E\n" +
+	"\x0frecommendations\x18\x01 \x03(\v2\x1b.bookService.RecommendationR\x0frecommendations*\xc9\x01\n" +
+	"\rBookSortField\x12\x1f\n" +
+	"\x1bBOOK_SORT_FIELD_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15BOOK_SORT_FIELD_TITLE\x10\x01\x12\x1a\n" +
+	"\x16BOOK_SORT_FIELD_AUTHOR\x10\x02\x12$\n" +
+	" BOOK_SORT_FIELD_PUBLICATION_YEAR\x10\x03\x12\x1e\n" +
+	"\x1aBOOK_SORT_FIELD_CREATED_AT\x10\x04\x12\x1a\n" +
+	"\x16BOOK_SORT_FIELD_RATING\x10\x05*`\n" +
+	"\rSortDirection\x12\x1e\n" +
+	"\x1aSORT_DIRECTION_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12SORT_DIRECTION_ASC\x10\x01\x12\x17\n" +
+	"\x13SORT_DIRECTION_DESC\x10\x02*\x89\x01\n" +
+	"\x0fShelfVisibility\x12 \n" +
+	"\x1cSHELF_VISIBILITY_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18SHELF_VISIBILITY_PRIVATE\x10\x01\x12\x19\n" +
+	"\x15SHELF_VISIBILITY_LINK\x10\x02\x12\x1b\n" +
+	"\x17SHELF_VISIBILITY_PUBLIC\x10\x03*\xb8\x01\n" +
+	"\rCatalogFormat\x12\x1e\n" +
+	"\x1aCATALOG_FORMAT_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15CATALOG_FORMAT_MARC21\x10\x01\x12\x1a\n" +
+	"\x16CATALOG_FORMAT_MARCXML\x10\x02\x12\x1e\n" +
+	"\x1aCATALOG_FORMAT_DUBLIN_CORE\x10\x03\x12\x16\n" +
+	"\x12CATALOG_FORMAT_CSV\x10\x04\x12\x18\n" +
+	"\x14CATALOG_FORMAT_JSONL\x10\x05*k\n" +
+	"\x0fDuplicatePolicy\x12 \n" +
+	"\x1cDUPLICATE_POLICY_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15DUPLICATE_POLICY_SKIP\x10\x01\x12\x1b\n" +
+	"\x17DUPLICATE_POLICY_UPDATE\x10\x02*\xd3\x01\n" +
+	"\x14RecommendationReason\x12%\n" +
+	"!RECOMMENDATION_REASON_UNSPECIFIED\x10\x00\x12$\n" +
+	" RECOMMENDATION_REASON_CO_SHELVED\x10\x01\x12%\n" +
+	"!RECOMMENDATION_REASON_SAME_AUTHOR\x10\x02\x12$\n" +
+	" RECOMMENDATION_REASON_SAME_GENRE\x10\x03\x12!\n" +
+	"\x1dRECOMMENDATION_REASON_POPULAR\x10\x042\xdd\x14\n" +
+	"\vBookService\x12O\n" +
+	"\aAddBook\x12\x1b.bookService.AddBookRequest\x1a\x11.bookService.Book\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/books\x12f\n" +
+	"\bAddBooks\x12\x1c.bookService.AddBooksRequest\x1a\x1d.bookService.AddBooksResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/books:batchAdd\x12V\n" +
+	"\aGetBook\x12\x1b.bookService.GetBookRequest\x1a\x11.bookService.Book\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/books/{book_id}\x12_\n" +
+	"\n" +
+	"UpdateBook\x12\x1e.bookService.UpdateBookRequest\x1a\x11.bookService.Book\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*2\x13/v1/books/{book_id}\x12j\n" +
+	"\n" +
+	"DeleteBook\x12\x1e.bookService.DeleteBookRequest\x1a\x1f.bookService.DeleteBookResponse\"\x1b\x82\xd3\xe4\x93\x02\x15*\x13/v1/books/{book_id}\x12]\n" +
+	"\tListBooks\x12\x1d.bookService.ListBooksRequest\x1a\x1e.bookService.ListBooksResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/books\x12e\n" +
+	"\rGetBookFacets\x12!.bookService.GetBookFacetsRequest\x1a\x17.bookService.BookFacets\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/books:facets\x12u\n" +
+	"\rAddBookToUser\x12\x1c.bookService.UserBookRequest\x1a .bookService.AddUserBookResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/users/{user_id}/books\x12\x88\x01\n" +
+	"\x12RemoveBookFromUser\x12\x1c.bookService.UserBookRequest\x1a'.bookService.RemoveBookFromUserResponse\"+\x82\xd3\xe4\x93\x02%*#/v1/users/{user_id}/books/{book_id}\x12s\n" +
+	"\fGetUserBooks\x12 .bookService.GetUserBooksRequest\x1a\x1e.bookService.ListBooksResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/users/{user_id}/books\x12j\n" +
+	"\vCreateShelf\x12\x1f.bookService.CreateShelfRequest\x1a\x12.bookService.Shelf\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/users/{user_id}/shelves\x12|\n" +
+	"\vRenameShelf\x12\x1f.bookService.RenameShelfRequest\x1a\x12.bookService.Shelf\"8\x82\xd3\xe4\x93\x022:\x01*\"-/v1/users/{user_id}/shelves/{shelf_id}:rename\x12\x91\x01\n" +
+	"\x12SetShelfVisibility\x12&.bookService.SetShelfVisibilityRequest\x1a\x12.bookService.Shelf\"?\x82\xd3\xe4\x93\x029:\x01*\"4/v1/users/{user_id}/shelves/{shelf_id}:setVisibility\x12\x80\x01\n" +
+	"\vDeleteShelf\x12\x1f.bookService.DeleteShelfRequest\x1a .bookService.DeleteShelfResponse\".\x82\xd3\xe4\x93\x02(*&/v1/users/{user_id}/shelves/{shelf_id}\x12u\n" +
+	"\vListShelves\x12\x1f.bookService.ListShelvesRequest\x1a .bookService.ListShelvesResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/users/{user_id}/shelves\x12|\n" +
+	"\x0eAddBookToShelf\x12\x1d.bookService.ShelfBookRequest\x1a\x12.bookService.Shelf\"7\x82\xd3\xe4\x93\x021:\x01*\",/v1/users/{user_id}/shelves/{shelf_id}/books\x12\x88\x01\n" +
+	"\x13RemoveBookFromShelf\x12\x1d.bookService.ShelfBookRequest\x1a\x12.bookService.Shelf\">\x82\xd3\xe4\x93\x028*6/v1/users/{user_id}/shelves/{shelf_id}/books/{book_id}\x12\x7f\n" +
+	"\fReorderShelf\x12 .bookService.ReorderShelfRequest\x1a\x12.bookService.Shelf\"9\x82\xd3\xe4\x93\x023:\x01*\"./v1/users/{user_id}/shelves/{shelf_id}:reorder\x12\\\n" +
+	"\bGetShelf\x12\x1c.bookService.GetShelfRequest\x1a\x12.bookService.Shelf\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/shelves/{shelf_id}\x12a\n" +
+	"\n" +
+	"ListSeries\x12\x1e.bookService.ListSeriesRequest\x1a\x1f.bookService.ListSeriesResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/series\x12_\n" +
+	"\tGetSeries\x12\x1d.bookService.GetSeriesRequest\x1a\x13.bookService.Series\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/series/{series_id}\x12\x83\x01\n" +
+	"\x0fAddSeriesToUser\x12#.bookService.AddSeriesToUserRequest\x1a$.bookService.AddSeriesToUserResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/users/{user_id}/series\x12D\n" +
+	"\vUploadCover\x12\x1f.bookService.UploadCoverRequest\x1a\x12.bookService.Cover(\x01\x12C\n" +
+	"\bGetCover\x12\x1c.bookService.GetCoverRequest\x1a\x17.bookService.CoverChunk0\x012\xf8\x03\n" +
+	"\x0eWebhookService\x12a\n" +
+	"\rCreateWebhook\x12!.bookService.CreateWebhookRequest\x1a\x14.bookService.Webhook\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/webhooks\x12i\n" +
+	"\fListWebhooks\x12 .bookService.ListWebhooksRequest\x1a!.bookService.ListWebhooksResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/webhooks\x12y\n" +
+	"\rDeleteWebhook\x12!.bookService.DeleteWebhookRequest\x1a\".bookService.DeleteWebhookResponse\"!\x82\xd3\xe4\x93\x02\x1b*\x19/v1/webhooks/{webhook_id}\x12\x9c\x01\n" +
+	"\x15ListWebhookDeliveries\x12).bookService.ListWebhookDeliveriesRequest\x1a*.bookService.ListWebhookDeliveriesResponse\",\x82\xd3\xe4\x93\x02&\x12$/v1/webhooks/{webhook_id}/deliveries2\xa2\x04\n" +
+	"\fGenreService\x12Y\n" +
+	"\vCreateGenre\x12\x1f.bookService.CreateGenreRequest\x1a\x12.bookService.Genre\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
+	"/v1/genres\x12d\n" +
+	"\vUpdateGenre\x12\x1f.bookService.UpdateGenreRequest\x1a\x12.bookService.Genre\" \x82\xd3\xe4\x93\x02\x1a:\x01*2\x15/v1/genres/{genre_id}\x12o\n" +
+	"\vDeleteGenre\x12\x1f.bookService.DeleteGenreRequest\x1a .bookService.DeleteGenreResponse\"\x1d\x82\xd3\xe4\x93\x02\x17*\x15/v1/genres/{genre_id}\x12a\n" +
+	"\n" +
+	"ListGenres\x12\x1e.bookService.ListGenresRequest\x1a\x1f.bookService.ListGenresResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/genres\x12}\n" +
+	"\rSetBookGenres\x12!.bookService.SetBookGenresRequest\x1a\".bookService.SetBookGenresResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\x1a\x1a/v1/books/{book_id}/genres2\xb8\x01\n" +
+	"\x0eCatalogService\x12O\n" +
+	"\rImportCatalog\x12!.bookService.ImportCatalogRequest\x1a\x19.bookService.ImportReport(\x01\x12U\n" +
+	"\rExportCatalog\x12!.bookService.ExportCatalogRequest\x1a\x1f.bookService.ExportCatalogChunk0\x012\xbb\x02\n" +
+	"\x15RecommendationService\x12\x81\x01\n" +
+	"\x0fGetSimilarBooks\x12#.bookService.GetSimilarBooksRequest\x1a$.bookService.RecommendationsResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/books/{book_id}/similar\x12\x9d\x01\n" +
+	"\x19GetRecommendationsForUser\x12-.bookService.GetRecommendationsForUserRequest\x1a$.bookService.RecommendationsResponse\"+\x82\xd3\xe4\x93\x02%\x12#/v1/users/{user_id}/recommendationsB*Z(bookService/internal/delivery/protos/genb\x06proto3"
 
 var (
 	file_book_service_proto_rawDescOnce sync.Once
@@ -769,44 +5318,203 @@ func file_book_service_proto_rawDescGZIP() []byte {
 	return file_book_service_proto_rawDescData
 }
 
-var file_book_service_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_book_service_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_book_service_proto_msgTypes = make([]protoimpl.MessageInfo, 68)
 var file_book_service_proto_goTypes = []any{
-	(*Book)(nil),                       // 0: bookService.Book
-	(*AddBookRequest)(nil),             // 1: bookService.AddBookRequest
-	(*GetBookRequest)(nil),             // 2: bookService.GetBookRequest
-	(*UpdateBookRequest)(nil),          // 3: bookService.UpdateBookRequest
-	(*DeleteBookRequest)(nil),          // 4: bookService.DeleteBookRequest
-	(*ListBooksRequest)(nil),           // 5: bookService.ListBooksRequest
-	(*ListBooksResponse)(nil),          // 6: bookService.ListBooksResponse
-	(*UserBookRequest)(nil),            // 7: bookService.UserBookRequest
-	(*GetUserBooksRequest)(nil),        // 8: bookService.GetUserBooksRequest
-	(*DeleteBookResponse)(nil),         // 9: bookService.DeleteBookResponse
-	(*AddUserBookResponse)(nil),        // 10: bookService.AddUserBookResponse
-	(*RemoveBookFromUserResponse)(nil), // 11: bookService.RemoveBookFromUserResponse
+	(BookSortField)(0),                       // 0: bookService.BookSortField
+	(SortDirection)(0),                       // 1: bookService.SortDirection
+	(ShelfVisibility)(0),                     // 2: bookService.ShelfVisibility
+	(CatalogFormat)(0),                       // 3: bookService.CatalogFormat
+	(DuplicatePolicy)(0),                     // 4: bookService.DuplicatePolicy
+	(RecommendationReason)(0),                // 5: bookService.RecommendationReason
+	(*Book)(nil),                             // 6: bookService.Book
+	(*AddBookRequest)(nil),                   // 7: bookService.AddBookRequest
+	(*AddBooksRequest)(nil),                  // 8: bookService.AddBooksRequest
+	(*AddBooksResponse)(nil),                 // 9: bookService.AddBooksResponse
+	(*GetBookRequest)(nil),                   // 10: bookService.GetBookRequest
+	(*UpdateBookRequest)(nil),                // 11: bookService.UpdateBookRequest
+	(*DeleteBookRequest)(nil),                // 12: bookService.DeleteBookRequest
+	(*ListBooksRequest)(nil),                 // 13: bookService.ListBooksRequest
+	(*ListBooksResponse)(nil),                // 14: bookService.ListBooksResponse
+	(*GetBookFacetsRequest)(nil),             // 15: bookService.GetBookFacetsRequest
+	(*FacetCount)(nil),                       // 16: bookService.FacetCount
+	(*BookFacets)(nil),                       // 17: bookService.BookFacets
+	(*UserBookRequest)(nil),                  // 18: bookService.UserBookRequest
+	(*GetUserBooksRequest)(nil),              // 19: bookService.GetUserBooksRequest
+	(*UploadCoverRequest)(nil),               // 20: bookService.UploadCoverRequest
+	(*Cover)(nil),                            // 21: bookService.Cover
+	(*GetCoverRequest)(nil),                  // 22: bookService.GetCoverRequest
+	(*CoverChunk)(nil),                       // 23: bookService.CoverChunk
+	(*Series)(nil),                           // 24: bookService.Series
+	(*SeriesProgress)(nil),                   // 25: bookService.SeriesProgress
+	(*ListSeriesRequest)(nil),                // 26: bookService.ListSeriesRequest
+	(*ListSeriesResponse)(nil),               // 27: bookService.ListSeriesResponse
+	(*GetSeriesRequest)(nil),                 // 28: bookService.GetSeriesRequest
+	(*AddSeriesToUserRequest)(nil),           // 29: bookService.AddSeriesToUserRequest
+	(*AddSeriesToUserResponse)(nil),          // 30: bookService.AddSeriesToUserResponse
+	(*Shelf)(nil),                            // 31: bookService.Shelf
+	(*CreateShelfRequest)(nil),               // 32: bookService.CreateShelfRequest
+	(*RenameShelfRequest)(nil),               // 33: bookService.RenameShelfRequest
+	(*SetShelfVisibilityRequest)(nil),        // 34: bookService.SetShelfVisibilityRequest
+	(*DeleteShelfRequest)(nil),               // 35: bookService.DeleteShelfRequest
+	(*DeleteShelfResponse)(nil),              // 36: bookService.DeleteShelfResponse
+	(*ListShelvesRequest)(nil),               // 37: bookService.ListShelvesRequest
+	(*ListShelvesResponse)(nil),              // 38: bookService.ListShelvesResponse
+	(*ShelfBookRequest)(nil),                 // 39: bookService.ShelfBookRequest
+	(*ReorderShelfRequest)(nil),              // 40: bookService.ReorderShelfRequest
+	(*GetShelfRequest)(nil),                  // 41: bookService.GetShelfRequest
+	(*DeleteBookResponse)(nil),               // 42: bookService.DeleteBookResponse
+	(*AddUserBookResponse)(nil),              // 43: bookService.AddUserBookResponse
+	(*RemoveBookFromUserResponse)(nil),       // 44: bookService.RemoveBookFromUserResponse
+	(*Webhook)(nil),                          // 45: bookService.Webhook
+	(*CreateWebhookRequest)(nil),             // 46: bookService.CreateWebhookRequest
+	(*ListWebhooksRequest)(nil),              // 47: bookService.ListWebhooksRequest
+	(*ListWebhooksResponse)(nil),             // 48: bookService.ListWebhooksResponse
+	(*DeleteWebhookRequest)(nil),             // 49: bookService.DeleteWebhookRequest
+	(*DeleteWebhookResponse)(nil),            // 50: bookService.DeleteWebhookResponse
+	(*WebhookDelivery)(nil),                  // 51: bookService.WebhookDelivery
+	(*ListWebhookDeliveriesRequest)(nil),     // 52: bookService.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil),    // 53: bookService.ListWebhookDeliveriesResponse
+	(*Genre)(nil),                            // 54: bookService.Genre
+	(*CreateGenreRequest)(nil),               // 55: bookService.CreateGenreRequest
+	(*UpdateGenreRequest)(nil),               // 56: bookService.UpdateGenreRequest
+	(*DeleteGenreRequest)(nil),               // 57: bookService.DeleteGenreRequest
+	(*DeleteGenreResponse)(nil),              // 58: bookService.DeleteGenreResponse
+	(*ListGenresRequest)(nil),                // 59: bookService.ListGenresRequest
+	(*ListGenresResponse)(nil),               // 60: bookService.ListGenresResponse
+	(*SetBookGenresRequest)(nil),             // 61: bookService.SetBookGenresRequest
+	(*SetBookGenresResponse)(nil),            // 62: bookService.SetBookGenresResponse
+	(*ImportCatalogRequest)(nil),             // 63: bookService.ImportCatalogRequest
+	(*ImportOptions)(nil),                    // 64: bookService.ImportOptions
+	(*ImportRecordResult)(nil),               // 65: bookService.ImportRecordResult
+	(*ImportReport)(nil),                     // 66: bookService.ImportReport
+	(*ExportCatalogRequest)(nil),             // 67: bookService.ExportCatalogRequest
+	(*ExportCatalogChunk)(nil),               // 68: bookService.ExportCatalogChunk
+	(*GetSimilarBooksRequest)(nil),           // 69: bookService.GetSimilarBooksRequest
+	(*GetRecommendationsForUserRequest)(nil), // 70: bookService.GetRecommendationsForUserRequest
+	(*Recommendation)(nil),                   // 71: bookService.Recommendation
+	(*RecommendationsResponse)(nil),          // 72: bookService.RecommendationsResponse
+	nil,                                      // 73: bookService.ImportOptions.ColumnsEntry
+	(*timestamppb.Timestamp)(nil),            // 74: google.protobuf.Timestamp
 }
 var file_book_service_proto_depIdxs = []int32{
-	0,  // 0: bookService.ListBooksResponse.books:type_name -> bookService.Book
-	1,  // 1: bookService.BookService.AddBook:input_type -> bookService.AddBookRequest
-	2,  // 2: bookService.BookService.GetBook:input_type -> bookService.GetBookRequest
-	3,  // 3: bookService.BookService.UpdateBook:input_type -> bookService.UpdateBookRequest
-	4,  // 4: bookService.BookService.DeleteBook:input_type -> bookService.DeleteBookRequest
-	5,  // 5: bookService.BookService.ListBooks:input_type -> bookService.ListBooksRequest
-	7,  // 6: bookService.BookService.AddBookToUser:input_type -> bookService.UserBookRequest
-	7,  // 7: bookService.BookService.RemoveBookFromUser:input_type -> bookService.UserBookRequest
-	8,  // 8: bookService.BookService.GetUserBooks:input_type -> bookService.GetUserBooksRequest
-	0,  // 9: bookService.BookService.AddBook:output_type -> bookService.Book
-	0,  // 10: bookService.BookService.GetBook:output_type -> bookService.Book
-	0,  // 11: bookService.BookService.UpdateBook:output_type -> bookService.Book
-	9,  // 12: bookService.BookService.DeleteBook:output_type -> bookService.DeleteBookResponse
-	6,  // 13: bookService.BookService.ListBooks:output_type -> bookService.ListBooksResponse
-	10, // 14: bookService.BookService.AddBookToUser:output_type -> bookService.AddUserBookResponse
-	11, // 15: bookService.BookService.RemoveBookFromUser:output_type -> bookService.RemoveBookFromUserResponse
-	6,  // 16: bookService.BookService.GetUserBooks:output_type -> bookService.ListBooksResponse
-	9,  // [9:17] is the sub-list for method output_type
-	1,  // [1:9] is the sub-list for method input_type
-	1,  // [1:1] is the sub-list for extension type_name
-	1,  // [1:1] is the sub-list for extension extendee
-	0,  // [0:1] is the sub-list for field type_name
+	74, // 0: bookService.Book.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 1: bookService.AddBooksRequest.books:type_name -> bookService.AddBookRequest
+	6,  // 2: bookService.AddBooksResponse.books:type_name -> bookService.Book
+	0,  // 3: bookService.ListBooksRequest.sort_by:type_name -> bookService.BookSortField
+	1,  // 4: bookService.ListBooksRequest.sort_direction:type_name -> bookService.SortDirection
+	6,  // 5: bookService.ListBooksResponse.books:type_name -> bookService.Book
+	25, // 6: bookService.ListBooksResponse.series_progress:type_name -> bookService.SeriesProgress
+	16, // 7: bookService.BookFacets.genres:type_name -> bookService.FacetCount
+	16, // 8: bookService.BookFacets.authors:type_name -> bookService.FacetCount
+	16, // 9: bookService.BookFacets.decades:type_name -> bookService.FacetCount
+	0,  // 10: bookService.GetUserBooksRequest.sort_by:type_name -> bookService.BookSortField
+	1,  // 11: bookService.GetUserBooksRequest.sort_direction:type_name -> bookService.SortDirection
+	6,  // 12: bookService.Series.books:type_name -> bookService.Book
+	24, // 13: bookService.ListSeriesResponse.series:type_name -> bookService.Series
+	2,  // 14: bookService.Shelf.visibility:type_name -> bookService.ShelfVisibility
+	74, // 15: bookService.Shelf.created_at:type_name -> google.protobuf.Timestamp
+	74, // 16: bookService.Shelf.updated_at:type_name -> google.protobuf.Timestamp
+	6,  // 17: bookService.Shelf.books:type_name -> bookService.Book
+	2,  // 18: bookService.CreateShelfRequest.visibility:type_name -> bookService.ShelfVisibility
+	2,  // 19: bookService.SetShelfVisibilityRequest.visibility:type_name -> bookService.ShelfVisibility
+	31, // 20: bookService.ListShelvesResponse.shelves:type_name -> bookService.Shelf
+	74, // 21: bookService.Webhook.created_at:type_name -> google.protobuf.Timestamp
+	45, // 22: bookService.ListWebhooksResponse.webhooks:type_name -> bookService.Webhook
+	74, // 23: bookService.WebhookDelivery.next_attempt_at:type_name -> google.protobuf.Timestamp
+	74, // 24: bookService.WebhookDelivery.created_at:type_name -> google.protobuf.Timestamp
+	74, // 25: bookService.WebhookDelivery.delivered_at:type_name -> google.protobuf.Timestamp
+	51, // 26: bookService.ListWebhookDeliveriesResponse.deliveries:type_name -> bookService.WebhookDelivery
+	54, // 27: bookService.ListGenresResponse.genres:type_name -> bookService.Genre
+	64, // 28: bookService.ImportCatalogRequest.options:type_name -> bookService.ImportOptions
+	3,  // 29: bookService.ImportOptions.format:type_name -> bookService.CatalogFormat
+	4,  // 30: bookService.ImportOptions.on_duplicate:type_name -> bookService.DuplicatePolicy
+	73, // 31: bookService.ImportOptions.columns:type_name -> bookService.ImportOptions.ColumnsEntry
+	65, // 32: bookService.ImportReport.records:type_name -> bookService.ImportRecordResult
+	3,  // 33: bookService.ExportCatalogRequest.format:type_name -> bookService.CatalogFormat
+	13, // 34: bookService.ExportCatalogRequest.filter:type_name -> bookService.ListBooksRequest
+	6,  // 35: bookService.Recommendation.book:type_name -> bookService.Book
+	5,  // 36: bookService.Recommendation.reason:type_name -> bookService.RecommendationReason
+	71, // 37: bookService.RecommendationsResponse.recommendations:type_name -> bookService.Recommendation
+	7,  // 38: bookService.BookService.AddBook:input_type -> bookService.AddBookRequest
+	8,  // 39: bookService.BookService.AddBooks:input_type -> bookService.AddBooksRequest
+	10, // 40: bookService.BookService.GetBook:input_type -> bookService.GetBookRequest
+	11, // 41: bookService.BookService.UpdateBook:input_type -> bookService.UpdateBookRequest
+	12, // 42: bookService.BookService.DeleteBook:input_type -> bookService.DeleteBookRequest
+	13, // 43: bookService.BookService.ListBooks:input_type -> bookService.ListBooksRequest
+	15, // 44: bookService.BookService.GetBookFacets:input_type -> bookService.GetBookFacetsRequest
+	18, // 45: bookService.BookService.AddBookToUser:input_type -> bookService.UserBookRequest
+	18, // 46: bookService.BookService.RemoveBookFromUser:input_type -> bookService.UserBookRequest
+	19, // 47: bookService.BookService.GetUserBooks:input_type -> bookService.GetUserBooksRequest
+	32, // 48: bookService.BookService.CreateShelf:input_type -> bookService.CreateShelfRequest
+	33, // 49: bookService.BookService.RenameShelf:input_type -> bookService.RenameShelfRequest
+	34, // 50: bookService.BookService.SetShelfVisibility:input_type -> bookService.SetShelfVisibilityRequest
+	35, // 51: bookService.BookService.DeleteShelf:input_type -> bookService.DeleteShelfRequest
+	37, // 52: bookService.BookService.ListShelves:input_type -> bookService.ListShelvesRequest
+	39, // 53: bookService.BookService.AddBookToShelf:input_type -> bookService.ShelfBookRequest
+	39, // 54: bookService.BookService.RemoveBookFromShelf:input_type -> bookService.ShelfBookRequest
+	40, // 55: bookService.BookService.ReorderShelf:input_type -> bookService.ReorderShelfRequest
+	41, // 56: bookService.BookService.GetShelf:input_type -> bookService.GetShelfRequest
+	26, // 57: bookService.BookService.ListSeries:input_type -> bookService.ListSeriesRequest
+	28, // 58: bookService.BookService.GetSeries:input_type -> bookService.GetSeriesRequest
+	29, // 59: bookService.BookService.AddSeriesToUser:input_type -> bookService.AddSeriesToUserRequest
+	20, // 60: bookService.BookService.UploadCover:input_type -> bookService.UploadCoverRequest
+	22, // 61: bookService.BookService.GetCover:input_type -> bookService.GetCoverRequest
+	46, // 62: bookService.WebhookService.CreateWebhook:input_type -> bookService.CreateWebhookRequest
+	47, // 63: bookService.WebhookService.ListWebhooks:input_type -> bookService.ListWebhooksRequest
+	49, // 64: bookService.WebhookService.DeleteWebhook:input_type -> bookService.DeleteWebhookRequest
+	52, // 65: bookService.WebhookService.ListWebhookDeliveries:input_type -> bookService.ListWebhookDeliveriesRequest
+	55, // 66: bookService.GenreService.CreateGenre:input_type -> bookService.CreateGenreRequest
+	56, // 67: bookService.GenreService.UpdateGenre:input_type -> bookService.UpdateGenreRequest
+	57, // 68: bookService.GenreService.DeleteGenre:input_type -> bookService.DeleteGenreRequest
+	59, // 69: bookService.GenreService.ListGenres:input_type -> bookService.ListGenresRequest
+	61, // 70: bookService.GenreService.SetBookGenres:input_type -> bookService.SetBookGenresRequest
+	63, // 71: bookService.CatalogService.ImportCatalog:input_type -> bookService.ImportCatalogRequest
+	67, // 72: bookService.CatalogService.ExportCatalog:input_type -> bookService.ExportCatalogRequest
+	69, // 73: bookService.RecommendationService.GetSimilarBooks:input_type -> bookService.GetSimilarBooksRequest
+	70, // 74: bookService.RecommendationService.GetRecommendationsForUser:input_type -> bookService.GetRecommendationsForUserRequest
+	6,  // 75: bookService.BookService.AddBook:output_type -> bookService.Book
+	9,  // 76: bookService.BookService.AddBooks:output_type -> bookService.AddBooksResponse
+	6,  // 77: bookService.BookService.GetBook:output_type -> bookService.Book
+	6,  // 78: bookService.BookService.UpdateBook:output_type -> bookService.Book
+	42, // 79: bookService.BookService.DeleteBook:output_type -> bookService.DeleteBookResponse
+	14, // 80: bookService.BookService.ListBooks:output_type -> bookService.ListBooksResponse
+	17, // 81: bookService.BookService.GetBookFacets:output_type -> bookService.BookFacets
+	43, // 82: bookService.BookService.AddBookToUser:output_type -> bookService.AddUserBookResponse
+	44, // 83: bookService.BookService.RemoveBookFromUser:output_type -> bookService.RemoveBookFromUserResponse
+	14, // 84: bookService.BookService.GetUserBooks:output_type -> bookService.ListBooksResponse
+	31, // 85: bookService.BookService.CreateShelf:output_type -> bookService.Shelf
+	31, // 86: bookService.BookService.RenameShelf:output_type -> bookService.Shelf
+	31, // 87: bookService.BookService.SetShelfVisibility:output_type -> bookService.Shelf
+	36, // 88: bookService.BookService.DeleteShelf:output_type -> bookService.DeleteShelfResponse
+	38, // 89: bookService.BookService.ListShelves:output_type -> bookService.ListShelvesResponse
+	31, // 90: bookService.BookService.AddBookToShelf:output_type -> bookService.Shelf
+	31, // 91: bookService.BookService.RemoveBookFromShelf:output_type -> bookService.Shelf
+	31, // 92: bookService.BookService.ReorderShelf:output_type -> bookService.Shelf
+	31, // 93: bookService.BookService.GetShelf:output_type -> bookService.Shelf
+	27, // 94: bookService.BookService.ListSeries:output_type -> bookService.ListSeriesResponse
+	24, // 95: bookService.BookService.GetSeries:output_type -> bookService.Series
+	30, // 96: bookService.BookService.AddSeriesToUser:output_type -> bookService.AddSeriesToUserResponse
+	21, // 97: bookService.BookService.UploadCover:output_type -> bookService.Cover
+	23, // 98: bookService.BookService.GetCover:output_type -> bookService.CoverChunk
+	45, // 99: bookService.WebhookService.CreateWebhook:output_type -> bookService.Webhook
+	48, // 100: bookService.WebhookService.ListWebhooks:output_type -> bookService.ListWebhooksResponse
+	50, // 101: bookService.WebhookService.DeleteWebhook:output_type -> bookService.DeleteWebhookResponse
+	53, // 102: bookService.WebhookService.ListWebhookDeliveries:output_type -> bookService.ListWebhookDeliveriesResponse
+	54, // 103: bookService.GenreService.CreateGenre:output_type -> bookService.Genre
+	54, // 104: bookService.GenreService.UpdateGenre:output_type -> bookService.Genre
+	58, // 105: bookService.GenreService.DeleteGenre:output_type -> bookService.DeleteGenreResponse
+	60, // 106: bookService.GenreService.ListGenres:output_type -> bookService.ListGenresResponse
+	62, // 107: bookService.GenreService.SetBookGenres:output_type -> bookService.SetBookGenresResponse
+	66, // 108: bookService.CatalogService.ImportCatalog:output_type -> bookService.ImportReport
+	68, // 109: bookService.CatalogService.ExportCatalog:output_type -> bookService.ExportCatalogChunk
+	72, // 110: bookService.RecommendationService.GetSimilarBooks:output_type -> bookService.RecommendationsResponse
+	72, // 111: bookService.RecommendationService.GetRecommendationsForUser:output_type -> bookService.RecommendationsResponse
+	75, // [75:112] is the sub-list for method output_type
+	38, // [38:75] is the sub-list for method input_type
+	38, // [38:38] is the sub-list for extension type_name
+	38, // [38:38] is the sub-list for extension extendee
+	0,  // [0:38] is the sub-list for field type_name
 }
 
 func init() { file_book_service_proto_init() }
@@ -816,21 +5524,41 @@ func file_book_service_proto_init() {
 	}
 	file_book_service_proto_msgTypes[0].OneofWrappers = []any{}
 	file_book_service_proto_msgTypes[1].OneofWrappers = []any{}
-	file_book_service_proto_msgTypes[3].OneofWrappers = []any{}
 	file_book_service_proto_msgTypes[5].OneofWrappers = []any{}
-	file_book_service_proto_msgTypes[8].OneofWrappers = []any{}
+	file_book_service_proto_msgTypes[7].OneofWrappers = []any{}
+	file_book_service_proto_msgTypes[9].OneofWrappers = []any{}
+	file_book_service_proto_msgTypes[13].OneofWrappers = []any{}
+	file_book_service_proto_msgTypes[14].OneofWrappers = []any{
+		(*UploadCoverRequest_BookId)(nil),
+		(*UploadCoverRequest_Chunk)(nil),
+	}
+	file_book_service_proto_msgTypes[18].OneofWrappers = []any{}
+	file_book_service_proto_msgTypes[25].OneofWrappers = []any{}
+	file_book_service_proto_msgTypes[35].OneofWrappers = []any{}
+	file_book_service_proto_msgTypes[40].OneofWrappers = []any{}
+	file_book_service_proto_msgTypes[45].OneofWrappers = []any{}
+	file_book_service_proto_msgTypes[46].OneofWrappers = []any{}
+	file_book_service_proto_msgTypes[48].OneofWrappers = []any{}
+	file_book_service_proto_msgTypes[49].OneofWrappers = []any{}
+	file_book_service_proto_msgTypes[50].OneofWrappers = []any{}
+	file_book_service_proto_msgTypes[57].OneofWrappers = []any{
+		(*ImportCatalogRequest_Options)(nil),
+		(*ImportCatalogRequest_Chunk)(nil),
+	}
+	file_book_service_proto_msgTypes[59].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_book_service_proto_rawDesc), len(file_book_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   12,
+			NumEnums:      6,
+			NumMessages:   68,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   5,
 		},
 		GoTypes:           file_book_service_proto_goTypes,
 		DependencyIndexes: file_book_service_proto_depIdxs,
+		EnumInfos:         file_book_service_proto_enumTypes,
 		MessageInfos:      file_book_service_proto_msgTypes,
 	}.Build()
 	File_book_service_proto = out.File
@@ -19,14 +19,30 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	BookService_AddBook_FullMethodName            = "/bookService.BookService/AddBook"
-	BookService_GetBook_FullMethodName            = "/bookService.BookService/GetBook"
-	BookService_UpdateBook_FullMethodName         = "/bookService.BookService/UpdateBook"
-	BookService_DeleteBook_FullMethodName         = "/bookService.BookService/DeleteBook"
-	BookService_ListBooks_FullMethodName          = "/bookService.BookService/ListBooks"
-	BookService_AddBookToUser_FullMethodName      = "/bookService.BookService/AddBookToUser"
-	BookService_RemoveBookFromUser_FullMethodName = "/bookService.BookService/RemoveBookFromUser"
-	BookService_GetUserBooks_FullMethodName       = "/bookService.BookService/GetUserBooks"
+	BookService_AddBook_FullMethodName             = "/bookService.BookService/AddBook"
+	BookService_AddBooks_FullMethodName            = "/bookService.BookService/AddBooks"
+	BookService_GetBook_FullMethodName             = "/bookService.BookService/GetBook"
+	BookService_UpdateBook_FullMethodName          = "/bookService.BookService/UpdateBook"
+	BookService_DeleteBook_FullMethodName          = "/bookService.BookService/DeleteBook"
+	BookService_ListBooks_FullMethodName           = "/bookService.BookService/ListBooks"
+	BookService_GetBookFacets_FullMethodName       = "/bookService.BookService/GetBookFacets"
+	BookService_AddBookToUser_FullMethodName       = "/bookService.BookService/AddBookToUser"
+	BookService_RemoveBookFromUser_FullMethodName  = "/bookService.BookService/RemoveBookFromUser"
+	BookService_GetUserBooks_FullMethodName        = "/bookService.BookService/GetUserBooks"
+	BookService_CreateShelf_FullMethodName         = "/bookService.BookService/CreateShelf"
+	BookService_RenameShelf_FullMethodName         = "/bookService.BookService/RenameShelf"
+	BookService_SetShelfVisibility_FullMethodName  = "/bookService.BookService/SetShelfVisibility"
+	BookService_DeleteShelf_FullMethodName         = "/bookService.BookService/DeleteShelf"
+	BookService_ListShelves_FullMethodName         = "/bookService.BookService/ListShelves"
+	BookService_AddBookToShelf_FullMethodName      = "/bookService.BookService/AddBookToShelf"
+	BookService_RemoveBookFromShelf_FullMethodName = "/bookService.BookService/RemoveBookFromShelf"
+	BookService_ReorderShelf_FullMethodName        = "/bookService.BookService/ReorderShelf"
+	BookService_GetShelf_FullMethodName            = "/bookService.BookService/GetShelf"
+	BookService_ListSeries_FullMethodName          = "/bookService.BookService/ListSeries"
+	BookService_GetSeries_FullMethodName           = "/bookService.BookService/GetSeries"
+	BookService_AddSeriesToUser_FullMethodName     = "/bookService.BookService/AddSeriesToUser"
+	BookService_UploadCover_FullMethodName         = "/bookService.BookService/UploadCover"
+	BookService_GetCover_FullMethodName            = "/bookService.BookService/GetCover"
 )
 
 // BookServiceClient is the client API for BookService service.
@@ -34,13 +50,48 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type BookServiceClient interface {
 	AddBook(ctx context.Context, in *AddBookRequest, opts ...grpc.CallOption) (*Book, error)
+	// AddBooks adds up to 1000 books at once; when one of them is rejected,
+	// none is added.
+	AddBooks(ctx context.Context, in *AddBooksRequest, opts ...grpc.CallOption) (*AddBooksResponse, error)
 	GetBook(ctx context.Context, in *GetBookRequest, opts ...grpc.CallOption) (*Book, error)
 	UpdateBook(ctx context.Context, in *UpdateBookRequest, opts ...grpc.CallOption) (*Book, error)
 	DeleteBook(ctx context.Context, in *DeleteBookRequest, opts ...grpc.CallOption) (*DeleteBookResponse, error)
 	ListBooks(ctx context.Context, in *ListBooksRequest, opts ...grpc.CallOption) (*ListBooksResponse, error)
+	// Book counts per genre, author and decade for browse filters.
+	GetBookFacets(ctx context.Context, in *GetBookFacetsRequest, opts ...grpc.CallOption) (*BookFacets, error)
 	AddBookToUser(ctx context.Context, in *UserBookRequest, opts ...grpc.CallOption) (*AddUserBookResponse, error)
 	RemoveBookFromUser(ctx context.Context, in *UserBookRequest, opts ...grpc.CallOption) (*RemoveBookFromUserResponse, error)
+	// GetUserBooks lists the user's books or, with shelf_id, the books on
+	// one of their shelves in shelf order unless sort_by is set.
 	GetUserBooks(ctx context.Context, in *GetUserBooksRequest, opts ...grpc.CallOption) (*ListBooksResponse, error)
+	CreateShelf(ctx context.Context, in *CreateShelfRequest, opts ...grpc.CallOption) (*Shelf, error)
+	RenameShelf(ctx context.Context, in *RenameShelfRequest, opts ...grpc.CallOption) (*Shelf, error)
+	// SetShelfVisibility makes a shelf private, readable by anyone holding
+	// its share link, or public. Every switch to link sharing issues a new
+	// share token, so sharing again revokes earlier links.
+	SetShelfVisibility(ctx context.Context, in *SetShelfVisibilityRequest, opts ...grpc.CallOption) (*Shelf, error)
+	DeleteShelf(ctx context.Context, in *DeleteShelfRequest, opts ...grpc.CallOption) (*DeleteShelfResponse, error)
+	ListShelves(ctx context.Context, in *ListShelvesRequest, opts ...grpc.CallOption) (*ListShelvesResponse, error)
+	// AddBookToShelf puts the book at the end of the shelf. Adding a book
+	// that is already there leaves it in place.
+	AddBookToShelf(ctx context.Context, in *ShelfBookRequest, opts ...grpc.CallOption) (*Shelf, error)
+	RemoveBookFromShelf(ctx context.Context, in *ShelfBookRequest, opts ...grpc.CallOption) (*Shelf, error)
+	// ReorderShelf takes every book of the shelf in the new order.
+	ReorderShelf(ctx context.Context, in *ReorderShelfRequest, opts ...grpc.CallOption) (*Shelf, error)
+	// GetShelf returns a public shelf, or a link-shared one given its share
+	// token, with its books in shelf order. Private shelves are not found.
+	GetShelf(ctx context.Context, in *GetShelfRequest, opts ...grpc.CallOption) (*Shelf, error)
+	ListSeries(ctx context.Context, in *ListSeriesRequest, opts ...grpc.CallOption) (*ListSeriesResponse, error)
+	// GetSeries returns the series with its volumes in reading order.
+	GetSeries(ctx context.Context, in *GetSeriesRequest, opts ...grpc.CallOption) (*Series, error)
+	// AddSeriesToUser shelves every volume of a series in one transaction.
+	AddSeriesToUser(ctx context.Context, in *AddSeriesToUserRequest, opts ...grpc.CallOption) (*AddSeriesToUserResponse, error)
+	// UploadCover takes the book id in the first message and the image bytes
+	// in the following ones. JPEG, PNG, GIF and WebP images are accepted.
+	UploadCover(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadCoverRequest, Cover], error)
+	// GetCover streams the cover image. Over HTTP it is served as raw bytes
+	// from GET /v1/books/{book_id}/cover.
+	GetCover(ctx context.Context, in *GetCoverRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CoverChunk], error)
 }
 
 type bookServiceClient struct {
@@ -61,6 +112,16 @@ func (c *bookServiceClient) AddBook(ctx context.Context, in *AddBookRequest, opt
 	return out, nil
 }
 
+func (c *bookServiceClient) AddBooks(ctx context.Context, in *AddBooksRequest, opts ...grpc.CallOption) (*AddBooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddBooksResponse)
+	err := c.cc.Invoke(ctx, BookService_AddBooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bookServiceClient) GetBook(ctx context.Context, in *GetBookRequest, opts ...grpc.CallOption) (*Book, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Book)
@@ -101,6 +162,16 @@ func (c *bookServiceClient) ListBooks(ctx context.Context, in *ListBooksRequest,
 	return out, nil
 }
 
+func (c *bookServiceClient) GetBookFacets(ctx context.Context, in *GetBookFacetsRequest, opts ...grpc.CallOption) (*BookFacets, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookFacets)
+	err := c.cc.Invoke(ctx, BookService_GetBookFacets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bookServiceClient) AddBookToUser(ctx context.Context, in *UserBookRequest, opts ...grpc.CallOption) (*AddUserBookResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddUserBookResponse)
@@ -131,18 +202,205 @@ func (c *bookServiceClient) GetUserBooks(ctx context.Context, in *GetUserBooksRe
 	return out, nil
 }
 
+func (c *bookServiceClient) CreateShelf(ctx context.Context, in *CreateShelfRequest, opts ...grpc.CallOption) (*Shelf, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Shelf)
+	err := c.cc.Invoke(ctx, BookService_CreateShelf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) RenameShelf(ctx context.Context, in *RenameShelfRequest, opts ...grpc.CallOption) (*Shelf, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Shelf)
+	err := c.cc.Invoke(ctx, BookService_RenameShelf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) SetShelfVisibility(ctx context.Context, in *SetShelfVisibilityRequest, opts ...grpc.CallOption) (*Shelf, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Shelf)
+	err := c.cc.Invoke(ctx, BookService_SetShelfVisibility_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) DeleteShelf(ctx context.Context, in *DeleteShelfRequest, opts ...grpc.CallOption) (*DeleteShelfResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteShelfResponse)
+	err := c.cc.Invoke(ctx, BookService_DeleteShelf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ListShelves(ctx context.Context, in *ListShelvesRequest, opts ...grpc.CallOption) (*ListShelvesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShelvesResponse)
+	err := c.cc.Invoke(ctx, BookService_ListShelves_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) AddBookToShelf(ctx context.Context, in *ShelfBookRequest, opts ...grpc.CallOption) (*Shelf, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Shelf)
+	err := c.cc.Invoke(ctx, BookService_AddBookToShelf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) RemoveBookFromShelf(ctx context.Context, in *ShelfBookRequest, opts ...grpc.CallOption) (*Shelf, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Shelf)
+	err := c.cc.Invoke(ctx, BookService_RemoveBookFromShelf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ReorderShelf(ctx context.Context, in *ReorderShelfRequest, opts ...grpc.CallOption) (*Shelf, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Shelf)
+	err := c.cc.Invoke(ctx, BookService_ReorderShelf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) GetShelf(ctx context.Context, in *GetShelfRequest, opts ...grpc.CallOption) (*Shelf, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Shelf)
+	err := c.cc.Invoke(ctx, BookService_GetShelf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ListSeries(ctx context.Context, in *ListSeriesRequest, opts ...grpc.CallOption) (*ListSeriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSeriesResponse)
+	err := c.cc.Invoke(ctx, BookService_ListSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) GetSeries(ctx context.Context, in *GetSeriesRequest, opts ...grpc.CallOption) (*Series, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Series)
+	err := c.cc.Invoke(ctx, BookService_GetSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) AddSeriesToUser(ctx context.Context, in *AddSeriesToUserRequest, opts ...grpc.CallOption) (*AddSeriesToUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddSeriesToUserResponse)
+	err := c.cc.Invoke(ctx, BookService_AddSeriesToUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) UploadCover(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadCoverRequest, Cover], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BookService_ServiceDesc.Streams[0], BookService_UploadCover_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadCoverRequest, Cover]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_UploadCoverClient = grpc.ClientStreamingClient[UploadCoverRequest, Cover]
+
+func (c *bookServiceClient) GetCover(ctx context.Context, in *GetCoverRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CoverChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BookService_ServiceDesc.Streams[1], BookService_GetCover_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetCoverRequest, CoverChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_GetCoverClient = grpc.ServerStreamingClient[CoverChunk]
+
 // BookServiceServer is the server API for BookService service.
 // All implementations must embed UnimplementedBookServiceServer
 // for forward compatibility.
 type BookServiceServer interface {
 	AddBook(context.Context, *AddBookRequest) (*Book, error)
+	// AddBooks adds up to 1000 books at once; when one of them is rejected,
+	// none is added.
+	AddBooks(context.Context, *AddBooksRequest) (*AddBooksResponse, error)
 	GetBook(context.Context, *GetBookRequest) (*Book, error)
 	UpdateBook(context.Context, *UpdateBookRequest) (*Book, error)
 	DeleteBook(context.Context, *DeleteBookRequest) (*DeleteBookResponse, error)
 	ListBooks(context.Context, *ListBooksRequest) (*ListBooksResponse, error)
+	// Book counts per genre, author and decade for browse filters.
+	GetBookFacets(context.Context, *GetBookFacetsRequest) (*BookFacets, error)
 	AddBookToUser(context.Context, *UserBookRequest) (*AddUserBookResponse, error)
 	RemoveBookFromUser(context.Context, *UserBookRequest) (*RemoveBookFromUserResponse, error)
+	// GetUserBooks lists the user's books or, with shelf_id, the books on
+	// one of their shelves in shelf order unless sort_by is set.
 	GetUserBooks(context.Context, *GetUserBooksRequest) (*ListBooksResponse, error)
+	CreateShelf(context.Context, *CreateShelfRequest) (*Shelf, error)
+	RenameShelf(context.Context, *RenameShelfRequest) (*Shelf, error)
+	// SetShelfVisibility makes a shelf private, readable by anyone holding
+	// its share link, or public. Every switch to link sharing issues a new
+	// share token, so sharing again revokes earlier links.
+	SetShelfVisibility(context.Context, *SetShelfVisibilityRequest) (*Shelf, error)
+	DeleteShelf(context.Context, *DeleteShelfRequest) (*DeleteShelfResponse, error)
+	ListShelves(context.Context, *ListShelvesRequest) (*ListShelvesResponse, error)
+	// AddBookToShelf puts the book at the end of the shelf. Adding a book
+	// that is already there leaves it in place.
+	AddBookToShelf(context.Context, *ShelfBookRequest) (*Shelf, error)
+	RemoveBookFromShelf(context.Context, *ShelfBookRequest) (*Shelf, error)
+	// ReorderShelf takes every book of the shelf in the new order.
+	ReorderShelf(context.Context, *ReorderShelfRequest) (*Shelf, error)
+	// GetShelf returns a public shelf, or a link-shared one given its share
+	// token, with its books in shelf order. Private shelves are not found.
+	GetShelf(context.Context, *GetShelfRequest) (*Shelf, error)
+	ListSeries(context.Context, *ListSeriesRequest) (*ListSeriesResponse, error)
+	// GetSeries returns the series with its volumes in reading order.
+	GetSeries(context.Context, *GetSeriesRequest) (*Series, error)
+	// AddSeriesToUser shelves every volume of a series in one transaction.
+	AddSeriesToUser(context.Context, *AddSeriesToUserRequest) (*AddSeriesToUserResponse, error)
+	// UploadCover takes the book id in the first message and the image bytes
+	// in the following ones. JPEG, PNG, GIF and WebP images are accepted.
+	UploadCover(grpc.ClientStreamingServer[UploadCoverRequest, Cover]) error
+	// GetCover streams the cover image. Over HTTP it is served as raw bytes
+	// from GET /v1/books/{book_id}/cover.
+	GetCover(*GetCoverRequest, grpc.ServerStreamingServer[CoverChunk]) error
 	mustEmbedUnimplementedBookServiceServer()
 }
 
@@ -156,6 +414,9 @@ type UnimplementedBookServiceServer struct{}
 func (UnimplementedBookServiceServer) AddBook(context.Context, *AddBookRequest) (*Book, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddBook not implemented")
 }
+func (UnimplementedBookServiceServer) AddBooks(context.Context, *AddBooksRequest) (*AddBooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddBooks not implemented")
+}
 func (UnimplementedBookServiceServer) GetBook(context.Context, *GetBookRequest) (*Book, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBook not implemented")
 }
@@ -168,6 +429,9 @@ func (UnimplementedBookServiceServer) DeleteBook(context.Context, *DeleteBookReq
 func (UnimplementedBookServiceServer) ListBooks(context.Context, *ListBooksRequest) (*ListBooksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListBooks not implemented")
 }
+func (UnimplementedBookServiceServer) GetBookFacets(context.Context, *GetBookFacetsRequest) (*BookFacets, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBookFacets not implemented")
+}
 func (UnimplementedBookServiceServer) AddBookToUser(context.Context, *UserBookRequest) (*AddUserBookResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddBookToUser not implemented")
 }
@@ -177,6 +441,48 @@ func (UnimplementedBookServiceServer) RemoveBookFromUser(context.Context, *UserB
 func (UnimplementedBookServiceServer) GetUserBooks(context.Context, *GetUserBooksRequest) (*ListBooksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserBooks not implemented")
 }
+func (UnimplementedBookServiceServer) CreateShelf(context.Context, *CreateShelfRequest) (*Shelf, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShelf not implemented")
+}
+func (UnimplementedBookServiceServer) RenameShelf(context.Context, *RenameShelfRequest) (*Shelf, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameShelf not implemented")
+}
+func (UnimplementedBookServiceServer) SetShelfVisibility(context.Context, *SetShelfVisibilityRequest) (*Shelf, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetShelfVisibility not implemented")
+}
+func (UnimplementedBookServiceServer) DeleteShelf(context.Context, *DeleteShelfRequest) (*DeleteShelfResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteShelf not implemented")
+}
+func (UnimplementedBookServiceServer) ListShelves(context.Context, *ListShelvesRequest) (*ListShelvesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShelves not implemented")
+}
+func (UnimplementedBookServiceServer) AddBookToShelf(context.Context, *ShelfBookRequest) (*Shelf, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddBookToShelf not implemented")
+}
+func (UnimplementedBookServiceServer) RemoveBookFromShelf(context.Context, *ShelfBookRequest) (*Shelf, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveBookFromShelf not implemented")
+}
+func (UnimplementedBookServiceServer) ReorderShelf(context.Context, *ReorderShelfRequest) (*Shelf, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderShelf not implemented")
+}
+func (UnimplementedBookServiceServer) GetShelf(context.Context, *GetShelfRequest) (*Shelf, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShelf not implemented")
+}
+func (UnimplementedBookServiceServer) ListSeries(context.Context, *ListSeriesRequest) (*ListSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSeries not implemented")
+}
+func (UnimplementedBookServiceServer) GetSeries(context.Context, *GetSeriesRequest) (*Series, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSeries not implemented")
+}
+func (UnimplementedBookServiceServer) AddSeriesToUser(context.Context, *AddSeriesToUserRequest) (*AddSeriesToUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddSeriesToUser not implemented")
+}
+func (UnimplementedBookServiceServer) UploadCover(grpc.ClientStreamingServer[UploadCoverRequest, Cover]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadCover not implemented")
+}
+func (UnimplementedBookServiceServer) GetCover(*GetCoverRequest, grpc.ServerStreamingServer[CoverChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method GetCover not implemented")
+}
 func (UnimplementedBookServiceServer) mustEmbedUnimplementedBookServiceServer() {}
 func (UnimplementedBookServiceServer) testEmbeddedByValue()                     {}
 
@@ -216,6 +522,24 @@ func _BookService_AddBook_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BookService_AddBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddBooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).AddBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_AddBooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).AddBooks(ctx, req.(*AddBooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BookService_GetBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetBookRequest)
 	if err := dec(in); err != nil {
@@ -288,6 +612,24 @@ func _BookService_ListBooks_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BookService_GetBookFacets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBookFacetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).GetBookFacets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_GetBookFacets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).GetBookFacets(ctx, req.(*GetBookFacetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BookService_AddBookToUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UserBookRequest)
 	if err := dec(in); err != nil {
@@ -342,44 +684,1111 @@ func _BookService_GetUserBooks_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
-// BookService_ServiceDesc is the grpc.ServiceDesc for BookService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var BookService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "bookService.BookService",
-	HandlerType: (*BookServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "AddBook",
-			Handler:    _BookService_AddBook_Handler,
-		},
-		{
-			MethodName: "GetBook",
-			Handler:    _BookService_GetBook_Handler,
-		},
-		{
-			MethodName: "UpdateBook",
-			Handler:    _BookService_UpdateBook_Handler,
-		},
-		{
-			MethodName: "DeleteBook",
-			Handler:    _BookService_DeleteBook_Handler,
-		},
-		{
-			MethodName: "ListBooks",
-			Handler:    _BookService_ListBooks_Handler,
-		},
-		{
-			MethodName: "AddBookToUser",
-			Handler:    _BookService_AddBookToUser_Handler,
-		},
-		{
-			MethodName: "RemoveBookFromUser",
-			Handler:    _BookService_RemoveBookFromUser_Handler,
-		},
-		{
-			MethodName: "GetUserBooks",
-			Handler:    _BookService_GetUserBooks_Handler,
+func _BookService_CreateShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShelfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).CreateShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_CreateShelf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).CreateShelf(ctx, req.(*CreateShelfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_RenameShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameShelfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).RenameShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_RenameShelf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).RenameShelf(ctx, req.(*RenameShelfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_SetShelfVisibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetShelfVisibilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).SetShelfVisibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_SetShelfVisibility_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).SetShelfVisibility(ctx, req.(*SetShelfVisibilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_DeleteShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteShelfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).DeleteShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_DeleteShelf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).DeleteShelf(ctx, req.(*DeleteShelfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ListShelves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShelvesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListShelves(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_ListShelves_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListShelves(ctx, req.(*ListShelvesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_AddBookToShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShelfBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).AddBookToShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_AddBookToShelf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).AddBookToShelf(ctx, req.(*ShelfBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_RemoveBookFromShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShelfBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).RemoveBookFromShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_RemoveBookFromShelf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).RemoveBookFromShelf(ctx, req.(*ShelfBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ReorderShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderShelfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ReorderShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_ReorderShelf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ReorderShelf(ctx, req.(*ReorderShelfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_GetShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShelfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).GetShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_GetShelf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).GetShelf(ctx, req.(*GetShelfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ListSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_ListSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListSeries(ctx, req.(*ListSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_GetSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).GetSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_GetSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).GetSeries(ctx, req.(*GetSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_AddSeriesToUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSeriesToUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).AddSeriesToUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_AddSeriesToUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).AddSeriesToUser(ctx, req.(*AddSeriesToUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_UploadCover_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BookServiceServer).UploadCover(&grpc.GenericServerStream[UploadCoverRequest, Cover]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_UploadCoverServer = grpc.ClientStreamingServer[UploadCoverRequest, Cover]
+
+func _BookService_GetCover_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetCoverRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BookServiceServer).GetCover(m, &grpc.GenericServerStream[GetCoverRequest, CoverChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_GetCoverServer = grpc.ServerStreamingServer[CoverChunk]
+
+// BookService_ServiceDesc is the grpc.ServiceDesc for BookService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BookService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bookService.BookService",
+	HandlerType: (*BookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddBook",
+			Handler:    _BookService_AddBook_Handler,
+		},
+		{
+			MethodName: "AddBooks",
+			Handler:    _BookService_AddBooks_Handler,
+		},
+		{
+			MethodName: "GetBook",
+			Handler:    _BookService_GetBook_Handler,
+		},
+		{
+			MethodName: "UpdateBook",
+			Handler:    _BookService_UpdateBook_Handler,
+		},
+		{
+			MethodName: "DeleteBook",
+			Handler:    _BookService_DeleteBook_Handler,
+		},
+		{
+			MethodName: "ListBooks",
+			Handler:    _BookService_ListBooks_Handler,
+		},
+		{
+			MethodName: "GetBookFacets",
+			Handler:    _BookService_GetBookFacets_Handler,
+		},
+		{
+			MethodName: "AddBookToUser",
+			Handler:    _BookService_AddBookToUser_Handler,
+		},
+		{
+			MethodName: "RemoveBookFromUser",
+			Handler:    _BookService_RemoveBookFromUser_Handler,
+		},
+		{
+			MethodName: "GetUserBooks",
+			Handler:    _BookService_GetUserBooks_Handler,
+		},
+		{
+			MethodName: "CreateShelf",
+			Handler:    _BookService_CreateShelf_Handler,
+		},
+		{
+			MethodName: "RenameShelf",
+			Handler:    _BookService_RenameShelf_Handler,
+		},
+		{
+			MethodName: "SetShelfVisibility",
+			Handler:    _BookService_SetShelfVisibility_Handler,
+		},
+		{
+			MethodName: "DeleteShelf",
+			Handler:    _BookService_DeleteShelf_Handler,
+		},
+		{
+			MethodName: "ListShelves",
+			Handler:    _BookService_ListShelves_Handler,
+		},
+		{
+			MethodName: "AddBookToShelf",
+			Handler:    _BookService_AddBookToShelf_Handler,
+		},
+		{
+			MethodName: "RemoveBookFromShelf",
+			Handler:    _BookService_RemoveBookFromShelf_Handler,
+		},
+		{
+			MethodName: "ReorderShelf",
+			Handler:    _BookService_ReorderShelf_Handler,
+		},
+		{
+			MethodName: "GetShelf",
+			Handler:    _BookService_GetShelf_Handler,
+		},
+		{
+			MethodName: "ListSeries",
+			Handler:    _BookService_ListSeries_Handler,
+		},
+		{
+			MethodName: "GetSeries",
+			Handler:    _BookService_GetSeries_Handler,
+		},
+		{
+			MethodName: "AddSeriesToUser",
+			Handler:    _BookService_AddSeriesToUser_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadCover",
+			Handler:       _BookService_UploadCover_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetCover",
+			Handler:       _BookService_GetCover_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "book-service.proto",
+}
+
+const (
+	WebhookService_CreateWebhook_FullMethodName         = "/bookService.WebhookService/CreateWebhook"
+	WebhookService_ListWebhooks_FullMethodName          = "/bookService.WebhookService/ListWebhooks"
+	WebhookService_DeleteWebhook_FullMethodName         = "/bookService.WebhookService/DeleteWebhook"
+	WebhookService_ListWebhookDeliveries_FullMethodName = "/bookService.WebhookService/ListWebhookDeliveries"
+)
+
+// WebhookServiceClient is the client API for WebhookService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WebhookServiceClient interface {
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+}
+
+type webhookServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebhookServiceClient(cc grpc.ClientConnInterface) WebhookServiceClient {
+	return &webhookServiceClient{cc}
+}
+
+func (c *webhookServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, WebhookService_CreateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, WebhookService_ListWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, WebhookService_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, WebhookService_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebhookServiceServer is the server API for WebhookService service.
+// All implementations must embed UnimplementedWebhookServiceServer
+// for forward compatibility.
+type WebhookServiceServer interface {
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*Webhook, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	mustEmbedUnimplementedWebhookServiceServer()
+}
+
+// UnimplementedWebhookServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWebhookServiceServer struct{}
+
+func (UnimplementedWebhookServiceServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedWebhookServiceServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedWebhookServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedWebhookServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedWebhookServiceServer) mustEmbedUnimplementedWebhookServiceServer() {}
+func (UnimplementedWebhookServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeWebhookServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebhookServiceServer will
+// result in compilation errors.
+type UnsafeWebhookServiceServer interface {
+	mustEmbedUnimplementedWebhookServiceServer()
+}
+
+func RegisterWebhookServiceServer(s grpc.ServiceRegistrar, srv WebhookServiceServer) {
+	// If the following call pancis, it indicates UnimplementedWebhookServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WebhookService_ServiceDesc, srv)
+}
+
+func _WebhookService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WebhookService_ServiceDesc is the grpc.ServiceDesc for WebhookService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WebhookService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bookService.WebhookService",
+	HandlerType: (*WebhookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _WebhookService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _WebhookService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _WebhookService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _WebhookService_ListWebhookDeliveries_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "book-service.proto",
+}
+
+const (
+	GenreService_CreateGenre_FullMethodName   = "/bookService.GenreService/CreateGenre"
+	GenreService_UpdateGenre_FullMethodName   = "/bookService.GenreService/UpdateGenre"
+	GenreService_DeleteGenre_FullMethodName   = "/bookService.GenreService/DeleteGenre"
+	GenreService_ListGenres_FullMethodName    = "/bookService.GenreService/ListGenres"
+	GenreService_SetBookGenres_FullMethodName = "/bookService.GenreService/SetBookGenres"
+)
+
+// GenreServiceClient is the client API for GenreService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GenreServiceClient interface {
+	CreateGenre(ctx context.Context, in *CreateGenreRequest, opts ...grpc.CallOption) (*Genre, error)
+	UpdateGenre(ctx context.Context, in *UpdateGenreRequest, opts ...grpc.CallOption) (*Genre, error)
+	DeleteGenre(ctx context.Context, in *DeleteGenreRequest, opts ...grpc.CallOption) (*DeleteGenreResponse, error)
+	ListGenres(ctx context.Context, in *ListGenresRequest, opts ...grpc.CallOption) (*ListGenresResponse, error)
+	SetBookGenres(ctx context.Context, in *SetBookGenresRequest, opts ...grpc.CallOption) (*SetBookGenresResponse, error)
+}
+
+type genreServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGenreServiceClient(cc grpc.ClientConnInterface) GenreServiceClient {
+	return &genreServiceClient{cc}
+}
+
+func (c *genreServiceClient) CreateGenre(ctx context.Context, in *CreateGenreRequest, opts ...grpc.CallOption) (*Genre, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Genre)
+	err := c.cc.Invoke(ctx, GenreService_CreateGenre_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *genreServiceClient) UpdateGenre(ctx context.Context, in *UpdateGenreRequest, opts ...grpc.CallOption) (*Genre, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Genre)
+	err := c.cc.Invoke(ctx, GenreService_UpdateGenre_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *genreServiceClient) DeleteGenre(ctx context.Context, in *DeleteGenreRequest, opts ...grpc.CallOption) (*DeleteGenreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteGenreResponse)
+	err := c.cc.Invoke(ctx, GenreService_DeleteGenre_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *genreServiceClient) ListGenres(ctx context.Context, in *ListGenresRequest, opts ...grpc.CallOption) (*ListGenresResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGenresResponse)
+	err := c.cc.Invoke(ctx, GenreService_ListGenres_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *genreServiceClient) SetBookGenres(ctx context.Context, in *SetBookGenresRequest, opts ...grpc.CallOption) (*SetBookGenresResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetBookGenresResponse)
+	err := c.cc.Invoke(ctx, GenreService_SetBookGenres_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GenreServiceServer is the server API for GenreService service.
+// All implementations must embed UnimplementedGenreServiceServer
+// for forward compatibility.
+type GenreServiceServer interface {
+	CreateGenre(context.Context, *CreateGenreRequest) (*Genre, error)
+	UpdateGenre(context.Context, *UpdateGenreRequest) (*Genre, error)
+	DeleteGenre(context.Context, *DeleteGenreRequest) (*DeleteGenreResponse, error)
+	ListGenres(context.Context, *ListGenresRequest) (*ListGenresResponse, error)
+	SetBookGenres(context.Context, *SetBookGenresRequest) (*SetBookGenresResponse, error)
+	mustEmbedUnimplementedGenreServiceServer()
+}
+
+// UnimplementedGenreServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGenreServiceServer struct{}
+
+func (UnimplementedGenreServiceServer) CreateGenre(context.Context, *CreateGenreRequest) (*Genre, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGenre not implemented")
+}
+func (UnimplementedGenreServiceServer) UpdateGenre(context.Context, *UpdateGenreRequest) (*Genre, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGenre not implemented")
+}
+func (UnimplementedGenreServiceServer) DeleteGenre(context.Context, *DeleteGenreRequest) (*DeleteGenreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGenre not implemented")
+}
+func (UnimplementedGenreServiceServer) ListGenres(context.Context, *ListGenresRequest) (*ListGenresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGenres not implemented")
+}
+func (UnimplementedGenreServiceServer) SetBookGenres(context.Context, *SetBookGenresRequest) (*SetBookGenresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBookGenres not implemented")
+}
+func (UnimplementedGenreServiceServer) mustEmbedUnimplementedGenreServiceServer() {}
+func (UnimplementedGenreServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeGenreServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GenreServiceServer will
+// result in compilation errors.
+type UnsafeGenreServiceServer interface {
+	mustEmbedUnimplementedGenreServiceServer()
+}
+
+func RegisterGenreServiceServer(s grpc.ServiceRegistrar, srv GenreServiceServer) {
+	// If the following call pancis, it indicates UnimplementedGenreServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GenreService_ServiceDesc, srv)
+}
+
+func _GenreService_CreateGenre_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGenreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GenreServiceServer).CreateGenre(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GenreService_CreateGenre_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GenreServiceServer).CreateGenre(ctx, req.(*CreateGenreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GenreService_UpdateGenre_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGenreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GenreServiceServer).UpdateGenre(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GenreService_UpdateGenre_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GenreServiceServer).UpdateGenre(ctx, req.(*UpdateGenreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GenreService_DeleteGenre_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGenreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GenreServiceServer).DeleteGenre(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GenreService_DeleteGenre_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GenreServiceServer).DeleteGenre(ctx, req.(*DeleteGenreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GenreService_ListGenres_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGenresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GenreServiceServer).ListGenres(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GenreService_ListGenres_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GenreServiceServer).ListGenres(ctx, req.(*ListGenresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GenreService_SetBookGenres_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBookGenresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GenreServiceServer).SetBookGenres(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GenreService_SetBookGenres_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GenreServiceServer).SetBookGenres(ctx, req.(*SetBookGenresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GenreService_ServiceDesc is the grpc.ServiceDesc for GenreService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GenreService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bookService.GenreService",
+	HandlerType: (*GenreServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateGenre",
+			Handler:    _GenreService_CreateGenre_Handler,
+		},
+		{
+			MethodName: "UpdateGenre",
+			Handler:    _GenreService_UpdateGenre_Handler,
+		},
+		{
+			MethodName: "DeleteGenre",
+			Handler:    _GenreService_DeleteGenre_Handler,
+		},
+		{
+			MethodName: "ListGenres",
+			Handler:    _GenreService_ListGenres_Handler,
+		},
+		{
+			MethodName: "SetBookGenres",
+			Handler:    _GenreService_SetBookGenres_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "book-service.proto",
+}
+
+const (
+	CatalogService_ImportCatalog_FullMethodName = "/bookService.CatalogService/ImportCatalog"
+	CatalogService_ExportCatalog_FullMethodName = "/bookService.CatalogService/ExportCatalog"
+)
+
+// CatalogServiceClient is the client API for CatalogService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CatalogServiceClient interface {
+	// ImportCatalog takes the import options in the first message and the
+	// file contents in the following ones. Records already in the catalog,
+	// by ISBN or by a close match of title and author, are skipped or
+	// updated as the options say.
+	ImportCatalog(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportCatalogRequest, ImportReport], error)
+	// ExportCatalog streams the books matching the filter as a catalog file.
+	ExportCatalog(ctx context.Context, in *ExportCatalogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportCatalogChunk], error)
+}
+
+type catalogServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCatalogServiceClient(cc grpc.ClientConnInterface) CatalogServiceClient {
+	return &catalogServiceClient{cc}
+}
+
+func (c *catalogServiceClient) ImportCatalog(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportCatalogRequest, ImportReport], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CatalogService_ServiceDesc.Streams[0], CatalogService_ImportCatalog_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportCatalogRequest, ImportReport]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_ImportCatalogClient = grpc.ClientStreamingClient[ImportCatalogRequest, ImportReport]
+
+func (c *catalogServiceClient) ExportCatalog(ctx context.Context, in *ExportCatalogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportCatalogChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CatalogService_ServiceDesc.Streams[1], CatalogService_ExportCatalog_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportCatalogRequest, ExportCatalogChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_ExportCatalogClient = grpc.ServerStreamingClient[ExportCatalogChunk]
+
+// CatalogServiceServer is the server API for CatalogService service.
+// All implementations must embed UnimplementedCatalogServiceServer
+// for forward compatibility.
+type CatalogServiceServer interface {
+	// ImportCatalog takes the import options in the first message and the
+	// file contents in the following ones. Records already in the catalog,
+	// by ISBN or by a close match of title and author, are skipped or
+	// updated as the options say.
+	ImportCatalog(grpc.ClientStreamingServer[ImportCatalogRequest, ImportReport]) error
+	// ExportCatalog streams the books matching the filter as a catalog file.
+	ExportCatalog(*ExportCatalogRequest, grpc.ServerStreamingServer[ExportCatalogChunk]) error
+	mustEmbedUnimplementedCatalogServiceServer()
+}
+
+// UnimplementedCatalogServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCatalogServiceServer struct{}
+
+func (UnimplementedCatalogServiceServer) ImportCatalog(grpc.ClientStreamingServer[ImportCatalogRequest, ImportReport]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportCatalog not implemented")
+}
+func (UnimplementedCatalogServiceServer) ExportCatalog(*ExportCatalogRequest, grpc.ServerStreamingServer[ExportCatalogChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportCatalog not implemented")
+}
+func (UnimplementedCatalogServiceServer) mustEmbedUnimplementedCatalogServiceServer() {}
+func (UnimplementedCatalogServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeCatalogServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CatalogServiceServer will
+// result in compilation errors.
+type UnsafeCatalogServiceServer interface {
+	mustEmbedUnimplementedCatalogServiceServer()
+}
+
+func RegisterCatalogServiceServer(s grpc.ServiceRegistrar, srv CatalogServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCatalogServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CatalogService_ServiceDesc, srv)
+}
+
+func _CatalogService_ImportCatalog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CatalogServiceServer).ImportCatalog(&grpc.GenericServerStream[ImportCatalogRequest, ImportReport]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_ImportCatalogServer = grpc.ClientStreamingServer[ImportCatalogRequest, ImportReport]
+
+func _CatalogService_ExportCatalog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportCatalogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogServiceServer).ExportCatalog(m, &grpc.GenericServerStream[ExportCatalogRequest, ExportCatalogChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_ExportCatalogServer = grpc.ServerStreamingServer[ExportCatalogChunk]
+
+// CatalogService_ServiceDesc is the grpc.ServiceDesc for CatalogService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CatalogService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bookService.CatalogService",
+	HandlerType: (*CatalogServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportCatalog",
+			Handler:       _CatalogService_ImportCatalog_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportCatalog",
+			Handler:       _CatalogService_ExportCatalog_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "book-service.proto",
+}
+
+const (
+	RecommendationService_GetSimilarBooks_FullMethodName           = "/bookService.RecommendationService/GetSimilarBooks"
+	RecommendationService_GetRecommendationsForUser_FullMethodName = "/bookService.RecommendationService/GetRecommendationsForUser"
+)
+
+// RecommendationServiceClient is the client API for RecommendationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RecommendationServiceClient interface {
+	// GetSimilarBooks returns the books most often shelved together with the
+	// given one. Books with too few readers are filled up with books by the
+	// same author or in the same genre.
+	GetSimilarBooks(ctx context.Context, in *GetSimilarBooksRequest, opts ...grpc.CallOption) (*RecommendationsResponse, error)
+	// GetRecommendationsForUser ranks books by their similarity to the
+	// user's shelf and leaves out the books already on it.
+	GetRecommendationsForUser(ctx context.Context, in *GetRecommendationsForUserRequest, opts ...grpc.CallOption) (*RecommendationsResponse, error)
+}
+
+type recommendationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRecommendationServiceClient(cc grpc.ClientConnInterface) RecommendationServiceClient {
+	return &recommendationServiceClient{cc}
+}
+
+func (c *recommendationServiceClient) GetSimilarBooks(ctx context.Context, in *GetSimilarBooksRequest, opts ...grpc.CallOption) (*RecommendationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecommendationsResponse)
+	err := c.cc.Invoke(ctx, RecommendationService_GetSimilarBooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationServiceClient) GetRecommendationsForUser(ctx context.Context, in *GetRecommendationsForUserRequest, opts ...grpc.CallOption) (*RecommendationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecommendationsResponse)
+	err := c.cc.Invoke(ctx, RecommendationService_GetRecommendationsForUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RecommendationServiceServer is the server API for RecommendationService service.
+// All implementations must embed UnimplementedRecommendationServiceServer
+// for forward compatibility.
+type RecommendationServiceServer interface {
+	// GetSimilarBooks returns the books most often shelved together with the
+	// given one. Books with too few readers are filled up with books by the
+	// same author or in the same genre.
+	GetSimilarBooks(context.Context, *GetSimilarBooksRequest) (*RecommendationsResponse, error)
+	// GetRecommendationsForUser ranks books by their similarity to the
+	// user's shelf and leaves out the books already on it.
+	GetRecommendationsForUser(context.Context, *GetRecommendationsForUserRequest) (*RecommendationsResponse, error)
+	mustEmbedUnimplementedRecommendationServiceServer()
+}
+
+// UnimplementedRecommendationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRecommendationServiceServer struct{}
+
+func (UnimplementedRecommendationServiceServer) GetSimilarBooks(context.Context, *GetSimilarBooksRequest) (*RecommendationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSimilarBooks not implemented")
+}
+func (UnimplementedRecommendationServiceServer) GetRecommendationsForUser(context.Context, *GetRecommendationsForUserRequest) (*RecommendationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecommendationsForUser not implemented")
+}
+func (UnimplementedRecommendationServiceServer) mustEmbedUnimplementedRecommendationServiceServer() {}
+func (UnimplementedRecommendationServiceServer) testEmbeddedByValue()                               {}
+
+// UnsafeRecommendationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RecommendationServiceServer will
+// result in compilation errors.
+type UnsafeRecommendationServiceServer interface {
+	mustEmbedUnimplementedRecommendationServiceServer()
+}
+
+func RegisterRecommendationServiceServer(s grpc.ServiceRegistrar, srv RecommendationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRecommendationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RecommendationService_ServiceDesc, srv)
+}
+
+func _RecommendationService_GetSimilarBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSimilarBooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).GetSimilarBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationService_GetSimilarBooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).GetSimilarBooks(ctx, req.(*GetSimilarBooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecommendationService_GetRecommendationsForUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecommendationsForUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).GetRecommendationsForUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationService_GetRecommendationsForUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).GetRecommendationsForUser(ctx, req.(*GetRecommendationsForUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RecommendationService_ServiceDesc is the grpc.ServiceDesc for RecommendationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RecommendationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bookService.RecommendationService",
+	HandlerType: (*RecommendationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSimilarBooks",
+			Handler:    _RecommendationService_GetSimilarBooks_Handler,
+		},
+		{
+			MethodName: "GetRecommendationsForUser",
+			Handler:    _RecommendationService_GetRecommendationsForUser_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -0,0 +1,300 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.30.2
+// source: validate/validate.proto
+
+package validate
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FieldRules declares constraints on a single field. They are enforced by the
+// validation interceptor before a request reaches its handler. For repeated
+// fields the scalar rules apply to every element.
+type FieldRules struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The field must be set: non-empty for strings and repeated fields,
+	// present for optional and message fields.
+	Required bool `protobuf:"varint,1,opt,name=required,proto3" json:"required,omitempty"`
+	// String length limits, counted in characters.
+	MinLen *uint32 `protobuf:"varint,2,opt,name=min_len,json=minLen,proto3,oneof" json:"min_len,omitempty"`
+	MaxLen *uint32 `protobuf:"varint,3,opt,name=max_len,json=maxLen,proto3,oneof" json:"max_len,omitempty"`
+	// The string must be a canonical UUID.
+	Uuid bool `protobuf:"varint,4,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// The string must be an absolute http or https URL.
+	HttpUrl bool `protobuf:"varint,5,opt,name=http_url,json=httpUrl,proto3" json:"http_url,omitempty"`
+	// The string must be one of these values.
+	In []string `protobuf:"bytes,6,rep,name=in,proto3" json:"in,omitempty"`
+	// Inclusive integer bounds.
+	Gte *int64 `protobuf:"varint,7,opt,name=gte,proto3,oneof" json:"gte,omitempty"`
+	Lte *int64 `protobuf:"varint,8,opt,name=lte,proto3,oneof" json:"lte,omitempty"`
+	// The integer must not be greater than the current calendar year.
+	NotFutureYear bool `protobuf:"varint,9,opt,name=not_future_year,json=notFutureYear,proto3" json:"not_future_year,omitempty"`
+	// Limit for repeated fields.
+	MaxItems *uint32 `protobuf:"varint,10,opt,name=max_items,json=maxItems,proto3,oneof" json:"max_items,omitempty"`
+	// Inclusive floating-point bounds.
+	Min *float64 `protobuf:"fixed64,11,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	Max *float64 `protobuf:"fixed64,12,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	// The enum value must be one of the declared values.
+	DefinedOnly bool `protobuf:"varint,13,opt,name=defined_only,json=definedOnly,proto3" json:"defined_only,omitempty"`
+	// The string must be a well-formed BCP-47 language tag.
+	Bcp47 bool `protobuf:"varint,14,opt,name=bcp47,proto3" json:"bcp47,omitempty"`
+	// The string must be a valid ISBN-10 or ISBN-13; hyphens are allowed.
+	Isbn          bool `protobuf:"varint,15,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldRules) Reset() {
+	*x = FieldRules{}
+	mi := &file_validate_validate_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldRules) ProtoMessage() {}
+
+func (x *FieldRules) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_validate_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldRules.ProtoReflect.Descriptor instead.
+func (*FieldRules) Descriptor() ([]byte, []int) {
+	return file_validate_validate_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FieldRules) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *FieldRules) GetMinLen() uint32 {
+	if x != nil && x.MinLen != nil {
+		return *x.MinLen
+	}
+	return 0
+}
+
+func (x *FieldRules) GetMaxLen() uint32 {
+	if x != nil && x.MaxLen != nil {
+		return *x.MaxLen
+	}
+	return 0
+}
+
+func (x *FieldRules) GetUuid() bool {
+	if x != nil {
+		return x.Uuid
+	}
+	return false
+}
+
+func (x *FieldRules) GetHttpUrl() bool {
+	if x != nil {
+		return x.HttpUrl
+	}
+	return false
+}
+
+func (x *FieldRules) GetIn() []string {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+func (x *FieldRules) GetGte() int64 {
+	if x != nil && x.Gte != nil {
+		return *x.Gte
+	}
+	return 0
+}
+
+func (x *FieldRules) GetLte() int64 {
+	if x != nil && x.Lte != nil {
+		return *x.Lte
+	}
+	return 0
+}
+
+func (x *FieldRules) GetNotFutureYear() bool {
+	if x != nil {
+		return x.NotFutureYear
+	}
+	return false
+}
+
+func (x *FieldRules) GetMaxItems() uint32 {
+	if x != nil && x.MaxItems != nil {
+		return *x.MaxItems
+	}
+	return 0
+}
+
+func (x *FieldRules) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *FieldRules) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+func (x *FieldRules) GetDefinedOnly() bool {
+	if x != nil {
+		return x.DefinedOnly
+	}
+	return false
+}
+
+func (x *FieldRules) GetBcp47() bool {
+	if x != nil {
+		return x.Bcp47
+	}
+	return false
+}
+
+func (x *FieldRules) GetIsbn() bool {
+	if x != nil {
+		return x.Isbn
+	}
+	return false
+}
+
+var file_validate_validate_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*FieldRules)(nil),
+		Field:         51234,
+		Name:          "bookService.validate.rules",
+		Tag:           "bytes,51234,opt,name=rules",
+		Filename:      "validate/validate.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// optional bookService.validate.FieldRules rules = 51234;
+	E_Rules = &file_validate_validate_proto_extTypes[0]
+)
+
+var File_validate_validate_proto protoreflect.FileDescriptor
+
+const file_validate_validate_proto_rawDesc = "" +
+	"\n" +
+	"\x17validate/validate.proto\x12\x14bookService.validate\x1a google/protobuf/descriptor.proto\"\xdc\x03\n" +
+	"\n" +
+	"FieldRules\x12\x1a\n" +
+	"\brequired\x18\x01 \x01(\bR\brequired\x12\x1c\n" +
+	"\amin_len\x18\x02 \x01(\rH\x00R\x06minLen\x88\x01\x01\x12\x1c\n" +
+	"\amax_len\x18\x03 \x01(\rH\x01R\x06maxLen\x88\x01\x01\x12\x12\n" +
+	"\x04uuid\x18\x04 \x01(\bR\x04uuid\x12\x19\n" +
+	"\bhttp_url\x18\x05 \x01(\bR\ahttpUrl\x12\x0e\n" +
+	"\x02in\x18\x06 \x03(\tR\x02in\x12\x15\n" +
+	"\x03gte\x18\a \x01(\x03H\x02R\x03gte\x88\x01\x01\x12\x15\n" +
+	"\x03lte\x18\b \x01(\x03H\x03R\x03lte\x88\x01\x01\x12&\n" +
+	"\x0fnot_future_year\x18\t \x01(\bR\rnotFutureYear\x12 \n" +
+	"\tmax_items\x18\n" +
+	" \x01(\rH\x04R\bmaxItems\x88\x01\x01\x12\x15\n" +
+	"\x03min\x18\v \x01(\x01H\x05R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\f \x01(\x01H\x06R\x03max\x88\x01\x01\x12!\n" +
+	"\fdefined_only\x18\r \x01(\bR\vdefinedOnly\x12\x14\n" +
+	"\x05bcp47\x18\x0e \x01(\bR\x05bcp47\x12\x12\n" +
+	"\x04isbn\x18\x0f \x01(\bR\x04isbnB\n" +
+	"\n" +
+	"\b_min_lenB\n" +
+	"\n" +
+	"\b_max_lenB\x06\n" +
+	"\x04_gteB\x06\n" +
+	"\x04_lteB\f\n" +
+	"\n" +
+	"_max_itemsB\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_max:W\n" +
+	"\x05rules\x12\x1d.google.protobuf.FieldOptions\x18\xa2\x90\x03 \x01(\v2 .bookService.validate.FieldRulesR\x05rulesB6Z4bookService/internal/delivery/protos/gen/go/validateb\x06proto3"
+
+var (
+	file_validate_validate_proto_rawDescOnce sync.Once
+	file_validate_validate_proto_rawDescData []byte
+)
+
+func file_validate_validate_proto_rawDescGZIP() []byte {
+	file_validate_validate_proto_rawDescOnce.Do(func() {
+		file_validate_validate_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_validate_validate_proto_rawDesc), len(file_validate_validate_proto_rawDesc)))
+	})
+	return file_validate_validate_proto_rawDescData
+}
+
+var file_validate_validate_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_validate_validate_proto_goTypes = []any{
+	(*FieldRules)(nil),                // 0: bookService.validate.FieldRules
+	(*descriptorpb.FieldOptions)(nil), // 1: google.protobuf.FieldOptions
+}
+var file_validate_validate_proto_depIdxs = []int32{
+	1, // 0: bookService.validate.rules:extendee -> google.protobuf.FieldOptions
+	0, // 1: bookService.validate.rules:type_name -> bookService.validate.FieldRules
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	1, // [1:2] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_validate_validate_proto_init() }
+func file_validate_validate_proto_init() {
+	if File_validate_validate_proto != nil {
+		return
+	}
+	file_validate_validate_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_validate_validate_proto_rawDesc), len(file_validate_validate_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_validate_validate_proto_goTypes,
+		DependencyIndexes: file_validate_validate_proto_depIdxs,
+		MessageInfos:      file_validate_validate_proto_msgTypes,
+		ExtensionInfos:    file_validate_validate_proto_extTypes,
+	}.Build()
+	File_validate_validate_proto = out.File
+	file_validate_validate_proto_goTypes = nil
+	file_validate_validate_proto_depIdxs = nil
+}
@@ -0,0 +1,8 @@
+package openapiv2
+
+import _ "embed"
+
+// Spec is the OpenAPI v2 document generated from book-service.proto.
+//
+//go:embed book-service.swagger.json
+var Spec []byte
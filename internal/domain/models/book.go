@@ -1,15 +1,80 @@
 package models
 
+import (
+	"slices"
+	"time"
+)
+
 type Book struct {
 	ID              string
 	Title           string
 	Author          string
 	PublicationYear int32
 	Genre           string
+	// Genres holds the names of every taxonomy genre the book is tagged
+	// with; Genre is the primary one.
+	Genres    []string
+	Rating    *float64
+	CreatedAt time.Time
+
+	Publisher string
+	// Language is a BCP-47 tag such as "en" or "pt-BR".
+	Language    string
+	PageCount   int32
+	Edition     string
+	Description string
+	Subjects    []string
+	// ISBN is stored in its normalized ISBN-13 form.
+	ISBN string
+	// SeriesName and SeriesNumber place the book in a series, e.g. the third
+	// volume of "The Expanse". Setting a new name creates the series.
+	SeriesID     string
+	SeriesName   string
+	SeriesNumber int32
+
+	// Blob store keys of the cover image and its thumbnail; empty when the
+	// book has no cover.
+	CoverKey     string
+	ThumbnailKey string
+}
+
+// Clone returns a copy of b that shares no memory with it.
+func (b *Book) Clone() *Book {
+	c := *b
+	if b.Rating != nil {
+		rating := *b.Rating
+		c.Rating = &rating
+	}
+	c.Genres = slices.Clone(b.Genres)
+	c.Subjects = slices.Clone(b.Subjects)
+	return &c
 }
 
+const (
+	SortByTitle           = "title"
+	SortByAuthor          = "author"
+	SortByPublicationYear = "publication_year"
+	SortByCreatedAt       = "created_at"
+	SortByRating          = "rating"
+)
+
 type BookFilter struct {
 	Author          *string
 	PublicationYear *int32
 	Genre           *string
+
+	// Authors and Genres match any of the listed values; they are combined
+	// with Author and Genre above.
+	Authors     []string
+	Genres      []string
+	YearFrom    *int32
+	YearTo      *int32
+	TitlePrefix *string
+	// Language matches the tag itself and its subtags: "en" matches "en-GB".
+	Language *string
+	Series   *string
+
+	// SortBy is one of the SortBy* constants; title when empty.
+	SortBy   string
+	SortDesc bool
 }
@@ -0,0 +1,9 @@
+package models
+
+type Cover struct {
+	BookID       string
+	Key          string
+	ThumbnailKey string
+	ContentType  string
+	Size         int64
+}
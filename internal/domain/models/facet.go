@@ -0,0 +1,14 @@
+package models
+
+type FacetCount struct {
+	Value string
+	Count int64
+}
+
+// BookFacets holds the number of books per facet value. Decades are keyed by
+// their first year, e.g. "1990".
+type BookFacets struct {
+	Genres  []FacetCount
+	Authors []FacetCount
+	Decades []FacetCount
+}
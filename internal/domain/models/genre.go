@@ -0,0 +1,8 @@
+package models
+
+type Genre struct {
+	ID       string
+	Name     string
+	ParentID *string
+	Aliases  []string
+}
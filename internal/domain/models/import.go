@@ -0,0 +1,68 @@
+package models
+
+const (
+	ImportCreated = "created"
+	ImportUpdated = "updated"
+	ImportSkipped = "skipped"
+	ImportFailed  = "failed"
+)
+
+const (
+	DuplicateSkip   = "skip"
+	DuplicateUpdate = "update"
+)
+
+// ImportOptions tune a catalog import.
+type ImportOptions struct {
+	// DryRun validates and matches every record without writing anything.
+	DryRun bool
+	// OnDuplicate is DuplicateSkip or DuplicateUpdate; empty means skip.
+	OnDuplicate string
+	// Columns maps CSV headers or JSON keys to book fields and replaces the
+	// configured columns of the fields it names.
+	Columns map[string]string
+	// Skip passes over the first records of the file, so an interrupted
+	// import can be resumed. Indexes in the report stay file positions.
+	Skip int
+	// Progress, when set, is called after every record.
+	Progress func(report *ImportReport)
+}
+
+// ImportRecord is the outcome of importing one record of a catalog file.
+type ImportRecord struct {
+	// Index is the 1-based position of the record in the file.
+	Index  int
+	Status string
+	// BookID is the created book, or for a skipped or updated record the
+	// existing book it duplicates. A dry run leaves it empty for books it
+	// would create.
+	BookID string
+	Title  string
+	Error  string
+}
+
+type ImportReport struct {
+	DryRun  bool
+	Created int
+	Updated int
+	Skipped int
+	Failed  int
+	// Processed counts the records read, including the skipped-over ones
+	// of a resumed import.
+	Processed int
+	Records   []ImportRecord
+}
+
+func (r *ImportReport) Add(record ImportRecord) {
+	switch record.Status {
+	case ImportCreated:
+		r.Created++
+	case ImportUpdated:
+		r.Updated++
+	case ImportSkipped:
+		r.Skipped++
+	case ImportFailed:
+		r.Failed++
+	}
+	r.Records = append(r.Records, record)
+}
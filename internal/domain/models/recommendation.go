@@ -0,0 +1,16 @@
+package models
+
+const (
+	ReasonCoShelved  = "co_shelved"
+	ReasonSameAuthor = "same_author"
+	ReasonSameGenre  = "same_genre"
+	ReasonPopular    = "popular"
+)
+
+type Recommendation struct {
+	Book *Book
+	// Score is the normalized co-occurrence from 0 to 1; recommendations for
+	// any other reason score 0.
+	Score  float64
+	Reason string
+}
@@ -0,0 +1,20 @@
+package models
+
+type Series struct {
+	ID          string
+	Name        string
+	Description string
+	BookCount   int
+	// Books lists the volumes in reading order; only filled by GetSeries.
+	Books []*Book
+}
+
+// SeriesProgress summarises how much of a series is on a user's shelf.
+type SeriesProgress struct {
+	SeriesID   string
+	SeriesName string
+	Owned      int
+	Total      int
+	// BookIDs are the owned volumes in reading order.
+	BookIDs []string
+}
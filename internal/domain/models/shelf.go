@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+const (
+	ShelfPrivate = "private"
+	// ShelfLink shelves can be read by anyone holding their share token.
+	ShelfLink   = "link"
+	ShelfPublic = "public"
+)
+
+// Shelf is a named, manually ordered list of books kept by a user, next to
+// the flat shelf of AddBookToUser.
+type Shelf struct {
+	ID         string
+	UserID     string
+	Name       string
+	Visibility string
+	ShareToken string
+	BookCount  int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	// Books lists the books in shelf order; only filled by GetShelf.
+	Books []*Book
+}
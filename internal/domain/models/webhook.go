@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+const (
+	EventBookCreated = "book.created"
+	EventBookUpdated = "book.updated"
+	EventBookDeleted = "book.deleted"
+)
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusDead      = "dead"
+)
+
+type WebhookSubscription struct {
+	ID         string
+	URL        string
+	EventTypes []string
+	Secret     string
+	CreatedAt  time.Time
+}
+
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	EventType      string
+	Payload        []byte
+	Status         string
+	Attempts       int32
+	LastError      string
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+type BookEvent struct {
+	Type       string    `json:"type"`
+	BookID     string    `json:"book_id"`
+	Book       *Book     `json:"book,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
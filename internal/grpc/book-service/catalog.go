@@ -0,0 +1,157 @@
+package book_service
+
+import (
+	"bookService/internal/catalog"
+	gen "bookService/internal/delivery/protos/gen/go"
+	"bookService/internal/domain/models"
+	"bufio"
+	"context"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CatalogService interface {
+	Import(ctx context.Context, format string, r io.Reader, opts models.ImportOptions) (*models.ImportReport, error)
+	Export(ctx context.Context, format string, filter *models.BookFilter, w io.Writer) (int, error)
+}
+
+type catalogAPI struct {
+	gen.UnimplementedCatalogServiceServer
+	catalogService CatalogService
+}
+
+func RegisterCatalog(gRPC *grpc.Server, catalogService CatalogService) {
+	gen.RegisterCatalogServiceServer(gRPC, &catalogAPI{catalogService: catalogService})
+}
+
+var catalogFormats = map[gen.CatalogFormat]string{
+	gen.CatalogFormat_CATALOG_FORMAT_MARC21:      catalog.FormatMARC21,
+	gen.CatalogFormat_CATALOG_FORMAT_MARCXML:     catalog.FormatMARCXML,
+	gen.CatalogFormat_CATALOG_FORMAT_DUBLIN_CORE: catalog.FormatDublinCore,
+	gen.CatalogFormat_CATALOG_FORMAT_CSV:         catalog.FormatCSV,
+	gen.CatalogFormat_CATALOG_FORMAT_JSONL:       catalog.FormatJSONLines,
+}
+
+var duplicatePolicies = map[gen.DuplicatePolicy]string{
+	gen.DuplicatePolicy_DUPLICATE_POLICY_UNSPECIFIED: models.DuplicateSkip,
+	gen.DuplicatePolicy_DUPLICATE_POLICY_SKIP:        models.DuplicateSkip,
+	gen.DuplicatePolicy_DUPLICATE_POLICY_UPDATE:      models.DuplicateUpdate,
+}
+
+func (s *catalogAPI) ImportCatalog(stream grpc.ClientStreamingServer[gen.ImportCatalogRequest, gen.ImportReport]) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "options message expected")
+	}
+	if first.GetOptions() == nil {
+		return status.Error(codes.InvalidArgument, "first message must carry options")
+	}
+	options := first.GetOptions()
+	opts := models.ImportOptions{
+		DryRun:      options.GetDryRun(),
+		OnDuplicate: duplicatePolicies[options.GetOnDuplicate()],
+		Columns:     options.GetColumns(),
+		Skip:        int(options.GetSkipRecords()),
+	}
+
+	report, err := s.catalogService.Import(stream.Context(), catalogFormats[options.GetFormat()], &importReader{stream: stream}, opts)
+	if err != nil {
+		// Past the options, only an unreadable file fails the import.
+		return catalogStatus(err, codes.InvalidArgument)
+	}
+
+	return stream.SendAndClose(toProtoImportReport(report))
+}
+
+func (s *catalogAPI) ExportCatalog(req *gen.ExportCatalogRequest, stream grpc.ServerStreamingServer[gen.ExportCatalogChunk]) error {
+	var filter *models.BookFilter
+	if req.GetFilter() != nil {
+		var err error
+		if filter, err = toBookFilter(req.GetFilter()); err != nil {
+			return err
+		}
+	}
+
+	w := bufio.NewWriterSize(&exportWriter{stream: stream}, coverChunkSize)
+	if _, err := s.catalogService.Export(stream.Context(), catalogFormats[req.GetFormat()], filter, w); err != nil {
+		return catalogStatus(err, codes.Internal)
+	}
+	if err := w.Flush(); err != nil {
+		return catalogStatus(err, codes.Internal)
+	}
+	return nil
+}
+
+// importReader exposes the file chunks of an ImportCatalog stream as an
+// io.Reader.
+type importReader struct {
+	stream grpc.ClientStreamingServer[gen.ImportCatalogRequest, gen.ImportReport]
+	buf    []byte
+}
+
+func (r *importReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if msg.GetOptions() != nil {
+			return 0, status.Error(codes.InvalidArgument, "options may only be sent once")
+		}
+		r.buf = msg.GetChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// exportWriter sends every write as an ExportCatalogChunk.
+type exportWriter struct {
+	stream grpc.ServerStreamingServer[gen.ExportCatalogChunk]
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&gen.ExportCatalogChunk{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func catalogStatus(err error, fallback codes.Code) error {
+	switch {
+	case errors.Is(err, catalog.ErrUnknownFormat), errors.Is(err, catalog.ErrNoExport), errors.Is(err, catalog.ErrBadMapping):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	// Errors of the stream itself, e.g. a second options message.
+	if st, ok := status.FromError(err); ok {
+		return status.Error(st.Code(), st.Message())
+	}
+	return status.Error(fallback, err.Error())
+}
+
+func toProtoImportReport(report *models.ImportReport) *gen.ImportReport {
+	response := &gen.ImportReport{
+		DryRun:    report.DryRun,
+		Created:   int32(report.Created),
+		Updated:   int32(report.Updated),
+		Skipped:   int32(report.Skipped),
+		Failed:    int32(report.Failed),
+		Processed: int32(report.Processed),
+	}
+	for _, r := range report.Records {
+		response.Records = append(response.Records, &gen.ImportRecordResult{
+			Index:  int32(r.Index),
+			Status: r.Status,
+			BookId: optional(r.BookID),
+			Title:  optional(r.Title),
+			Error:  optional(r.Error),
+		})
+	}
+	return response
+}
@@ -0,0 +1,126 @@
+package book_service
+
+import (
+	gen "bookService/internal/delivery/protos/gen/go"
+	"bookService/internal/services/coverService"
+	"bookService/internal/storage"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const coverChunkSize = 64 << 10
+
+func (s *serverAPI) UploadCover(stream grpc.ClientStreamingServer[gen.UploadCoverRequest, gen.Cover]) error {
+	if s.coverService == nil {
+		return s.UnimplementedBookServiceServer.UploadCover(stream)
+	}
+	first, err := stream.Recv()
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "book_id message expected")
+	}
+	bookID := first.GetBookId()
+	if bookID == "" {
+		return status.Error(codes.InvalidArgument, "first message must carry book_id")
+	}
+
+	cover, err := s.coverService.UploadCover(stream.Context(), bookID, &chunkReader{stream: stream})
+	if err != nil {
+		return coverStatus(err)
+	}
+
+	return stream.SendAndClose(&gen.Cover{
+		BookId:       cover.BookID,
+		ContentType:  cover.ContentType,
+		Size:         cover.Size,
+		CoverUrl:     coverURL(cover.BookID, cover.Key, false),
+		ThumbnailUrl: coverURL(cover.BookID, cover.ThumbnailKey, true),
+	})
+}
+
+func (s *serverAPI) GetCover(req *gen.GetCoverRequest, stream grpc.ServerStreamingServer[gen.CoverChunk]) error {
+	if s.coverService == nil {
+		return s.UnimplementedBookServiceServer.GetCover(req, stream)
+	}
+	rc, info, err := s.coverService.GetCover(stream.Context(), req.GetBookId(), req.GetThumbnail())
+	if err != nil {
+		return coverStatus(err)
+	}
+	defer rc.Close()
+
+	chunk := &gen.CoverChunk{ContentType: info.ContentType, Size: info.Size}
+	buf := make([]byte, coverChunkSize)
+	for {
+		n, err := io.ReadFull(rc, buf)
+		if n > 0 {
+			chunk.Data = buf[:n]
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+			chunk = &gen.CoverChunk{}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+}
+
+// chunkReader exposes the image chunks of an UploadCover stream as an
+// io.Reader.
+type chunkReader struct {
+	stream grpc.ClientStreamingServer[gen.UploadCoverRequest, gen.Cover]
+	buf    []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if msg.GetBookId() != "" {
+			return 0, status.Error(codes.InvalidArgument, "book_id may only be sent once")
+		}
+		r.buf = msg.GetChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func coverStatus(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrBookNotFound):
+		return status.Error(codes.NotFound, "book not found")
+	case errors.Is(err, coverService.ErrNoCover):
+		return status.Error(codes.NotFound, "book has no cover")
+	case errors.Is(err, coverService.ErrCoverTooLarge):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, coverService.ErrUnsupportedCover):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	// Errors of the upload stream itself, e.g. a cancelled client.
+	if st, ok := status.FromError(err); ok {
+		return status.Error(st.Code(), st.Message())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// coverURL returns the gateway path serving the blob, or "" without one.
+func coverURL(bookID, key string, thumbnail bool) string {
+	if key == "" {
+		return ""
+	}
+	url := fmt.Sprintf("/v1/books/%s/cover", bookID)
+	if thumbnail {
+		url += "?thumbnail=true"
+	}
+	return url
+}
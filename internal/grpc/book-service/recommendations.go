@@ -0,0 +1,66 @@
+package book_service
+
+import (
+	gen "bookService/internal/delivery/protos/gen/go"
+	"bookService/internal/domain/models"
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+type RecommendationService interface {
+	GetSimilarBooks(ctx context.Context, bookID string, limit int) ([]*models.Recommendation, error)
+	GetRecommendationsForUser(ctx context.Context, userID string, limit int) ([]*models.Recommendation, error)
+}
+
+type recommendationAPI struct {
+	gen.UnimplementedRecommendationServiceServer
+	recommendationService RecommendationService
+}
+
+func RegisterRecommendations(gRPC *grpc.Server, recommendationService RecommendationService) {
+	gen.RegisterRecommendationServiceServer(gRPC, &recommendationAPI{recommendationService: recommendationService})
+}
+
+var recommendationReasons = map[string]gen.RecommendationReason{
+	models.ReasonCoShelved:  gen.RecommendationReason_RECOMMENDATION_REASON_CO_SHELVED,
+	models.ReasonSameAuthor: gen.RecommendationReason_RECOMMENDATION_REASON_SAME_AUTHOR,
+	models.ReasonSameGenre:  gen.RecommendationReason_RECOMMENDATION_REASON_SAME_GENRE,
+	models.ReasonPopular:    gen.RecommendationReason_RECOMMENDATION_REASON_POPULAR,
+}
+
+func (s *recommendationAPI) GetSimilarBooks(
+	ctx context.Context,
+	req *gen.GetSimilarBooksRequest,
+) (*gen.RecommendationsResponse, error) {
+	recs, err := s.recommendationService.GetSimilarBooks(ctx, req.GetBookId(), int(req.GetLimit()))
+	if err != nil {
+		return nil, bookStatus(err)
+	}
+
+	return toProtoRecommendations(recs), nil
+}
+
+func (s *recommendationAPI) GetRecommendationsForUser(
+	ctx context.Context,
+	req *gen.GetRecommendationsForUserRequest,
+) (*gen.RecommendationsResponse, error) {
+	recs, err := s.recommendationService.GetRecommendationsForUser(ctx, req.GetUserId(), int(req.GetLimit()))
+	if err != nil {
+		return nil, bookStatus(err)
+	}
+
+	return toProtoRecommendations(recs), nil
+}
+
+func toProtoRecommendations(recs []*models.Recommendation) *gen.RecommendationsResponse {
+	response := &gen.RecommendationsResponse{}
+	for _, r := range recs {
+		response.Recommendations = append(response.Recommendations, &gen.Recommendation{
+			Book:   toProtoBook(r.Book),
+			Score:  r.Score,
+			Reason: recommendationReasons[r.Reason],
+		})
+	}
+	return response
+}
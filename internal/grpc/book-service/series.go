@@ -0,0 +1,60 @@
+package book_service
+
+import (
+	gen "bookService/internal/delivery/protos/gen/go"
+	"bookService/internal/domain/models"
+	"context"
+)
+
+func (s *serverAPI) ListSeries(
+	ctx context.Context,
+	req *gen.ListSeriesRequest,
+) (*gen.ListSeriesResponse, error) {
+	series, err := s.bookService.ListSeries(ctx)
+	if err != nil {
+		return nil, bookStatus(err)
+	}
+
+	response := &gen.ListSeriesResponse{}
+	for _, sr := range series {
+		response.Series = append(response.Series, toProtoSeries(sr))
+	}
+	return response, nil
+}
+
+func (s *serverAPI) GetSeries(
+	ctx context.Context,
+	req *gen.GetSeriesRequest,
+) (*gen.Series, error) {
+	series, err := s.bookService.GetSeries(ctx, req.GetSeriesId())
+	if err != nil {
+		return nil, bookStatus(err)
+	}
+
+	return toProtoSeries(series), nil
+}
+
+func (s *serverAPI) AddSeriesToUser(
+	ctx context.Context,
+	req *gen.AddSeriesToUserRequest,
+) (*gen.AddSeriesToUserResponse, error) {
+	ids, err := s.bookService.AddSeriesToUser(ctx, req.GetUserId(), req.GetSeriesId())
+	if err != nil {
+		return nil, bookStatus(err)
+	}
+
+	return &gen.AddSeriesToUserResponse{BookIds: ids}, nil
+}
+
+func toProtoSeries(series *models.Series) *gen.Series {
+	result := &gen.Series{
+		SeriesId:    series.ID,
+		Name:        series.Name,
+		Description: optional(series.Description),
+		BookCount:   int32(series.BookCount),
+	}
+	for _, book := range series.Books {
+		result.Books = append(result.Books, toProtoBook(book))
+	}
+	return result
+}
@@ -1,122 +1,160 @@
 package book_service
 
 import (
+	"bookService/internal/blobstore"
 	gen "bookService/internal/delivery/protos/gen/go"
 	"bookService/internal/domain/models"
+	bookService "bookService/internal/services/bookService"
+	"bookService/internal/storage"
 	"context"
+	"errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
 )
 
 type BookService interface {
 	AddBook(ctx context.Context, book *models.Book) (*models.Book, error)
+	AddBooks(ctx context.Context, books []*models.Book) ([]*models.Book, error)
 	GetBook(ctx context.Context, id string) (*models.Book, error)
-	UpdateBook(ctx context.Context, book *models.Book) (*models.Book, error)
+	UpdateBook(ctx context.Context, update bookService.BookUpdate) (*models.Book, error)
 	DeleteBook(ctx context.Context, id string) (string, error)
 	ListBooks(ctx context.Context, filter *models.BookFilter) ([]*models.Book, error)
+	GetBookFacets(ctx context.Context, filter *models.BookFilter, limit int) (*models.BookFacets, error)
 	AddBookToUser(ctx context.Context, userID, bookID string) (string, error)
 	RemoveBookFromUser(ctx context.Context, userID, bookID string) (string, error)
-	GetUserBooks(ctx context.Context, userID string, filter *models.BookFilter) ([]*models.Book, error)
+	GetUserBooks(ctx context.Context, userID, shelfID string, filter *models.BookFilter) ([]*models.Book, error)
+	ListSeries(ctx context.Context) ([]*models.Series, error)
+	GetSeries(ctx context.Context, id string) (*models.Series, error)
+	AddSeriesToUser(ctx context.Context, userID, seriesID string) ([]string, error)
+	SeriesProgress(ctx context.Context, books []*models.Book) ([]*models.SeriesProgress, error)
+	CreateShelf(ctx context.Context, userID, name, visibility string) (*models.Shelf, error)
+	RenameShelf(ctx context.Context, userID, shelfID, name string) (*models.Shelf, error)
+	SetShelfVisibility(ctx context.Context, userID, shelfID, visibility string) (*models.Shelf, error)
+	DeleteShelf(ctx context.Context, userID, shelfID string) (string, error)
+	ListShelves(ctx context.Context, userID string) ([]*models.Shelf, error)
+	AddBookToShelf(ctx context.Context, userID, shelfID, bookID string) (*models.Shelf, error)
+	RemoveBookFromShelf(ctx context.Context, userID, shelfID, bookID string) (*models.Shelf, error)
+	ReorderShelf(ctx context.Context, userID, shelfID string, bookIDs []string) (*models.Shelf, error)
+	GetShelf(ctx context.Context, shelfID, token string) (*models.Shelf, error)
+}
+
+type CoverService interface {
+	UploadCover(ctx context.Context, bookID string, r io.Reader) (*models.Cover, error)
+	GetCover(ctx context.Context, bookID string, thumbnail bool) (io.ReadCloser, blobstore.Info, error)
 }
 
 type serverAPI struct {
 	gen.UnimplementedBookServiceServer
-	bookService BookService
+	bookService  BookService
+	coverService CoverService
 }
 
-func Register(gRPC *grpc.Server, bookService BookService) {
-	gen.RegisterBookServiceServer(gRPC, &serverAPI{bookService: bookService})
+// Register registers the book service. coverService may be nil when covers
+// are not available, in which case the cover calls are unimplemented.
+func Register(gRPC *grpc.Server, bookService BookService, coverService CoverService) {
+	gen.RegisterBookServiceServer(gRPC, &serverAPI{bookService: bookService, coverService: coverService})
 }
 
 func (s *serverAPI) AddBook(
 	ctx context.Context,
 	req *gen.AddBookRequest,
 ) (*gen.Book, error) {
+	book, err := s.bookService.AddBook(ctx, fromAddBookRequest(req))
+	if err != nil {
+		return nil, bookStatus(err)
+	}
+
+	return toProtoBook(book), nil
+}
+
+func (s *serverAPI) AddBooks(
+	ctx context.Context,
+	req *gen.AddBooksRequest,
+) (*gen.AddBooksResponse, error) {
+	books := make([]*models.Book, 0, len(req.GetBooks()))
+	for _, b := range req.GetBooks() {
+		books = append(books, fromAddBookRequest(b))
+	}
 
-	if req.GetTitle() == "" {
-		return nil, status.Error(codes.InvalidArgument, "title is required")
+	added, err := s.bookService.AddBooks(ctx, books)
+	if err != nil {
+		return nil, bookStatus(err)
 	}
-	if req.GetAuthor() == "" {
-		return nil, status.Error(codes.InvalidArgument, "author is required")
+
+	response := &gen.AddBooksResponse{}
+	for _, book := range added {
+		response.Books = append(response.Books, toProtoBook(book))
 	}
-	book, err := s.bookService.AddBook(ctx, &models.Book{
+	return response, nil
+}
+
+func fromAddBookRequest(req *gen.AddBookRequest) *models.Book {
+	return &models.Book{
 		Title:           req.Title,
 		Author:          req.Author,
 		PublicationYear: req.GetPublicationYear(),
 		Genre:           req.GetGenre(),
-	})
-	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		Rating:          req.Rating,
+		Publisher:       req.GetPublisher(),
+		Language:        req.GetLanguage(),
+		PageCount:       req.GetPageCount(),
+		Edition:         req.GetEdition(),
+		Description:     req.GetDescription(),
+		Subjects:        req.GetSubjects(),
+		SeriesName:      req.GetSeriesName(),
+		SeriesNumber:    req.GetSeriesNumber(),
+		ISBN:            req.GetIsbn(),
 	}
-
-	return &gen.Book{
-		BookId:          book.ID,
-		Title:           book.Title,
-		Author:          book.Author,
-		PublicationYear: &book.PublicationYear,
-		Genre:           &book.Genre,
-	}, nil
 }
+
 func (s *serverAPI) GetBook(
 	ctx context.Context,
 	req *gen.GetBookRequest,
 ) (*gen.Book, error) {
-
-	if req.GetBookId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "book id is required")
-	}
-
 	book, err := s.bookService.GetBook(ctx, req.GetBookId())
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	return &gen.Book{
-		BookId:          book.ID,
-		Title:           book.Title,
-		Author:          book.Author,
-		PublicationYear: &book.PublicationYear,
-		Genre:           &book.Genre,
-	}, nil
+	return toProtoBook(book), nil
 }
 
 func (s *serverAPI) UpdateBook(
 	ctx context.Context,
 	req *gen.UpdateBookRequest,
 ) (*gen.Book, error) {
-	if req.GetBookId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "book id is required")
-	}
-
-	book, err := s.bookService.UpdateBook(ctx, &models.Book{
+	book, err := s.bookService.UpdateBook(ctx, bookService.BookUpdate{
 		ID:              req.GetBookId(),
-		Title:           req.GetTitle(),
-		Author:          req.GetAuthor(),
-		PublicationYear: req.GetPublicationYear(),
-		Genre:           req.GetGenre(),
+		Title:           req.Title,
+		Author:          req.Author,
+		PublicationYear: req.PublicationYear,
+		Genre:           req.Genre,
+		Rating:          req.Rating,
+		Publisher:       req.Publisher,
+		Language:        req.Language,
+		PageCount:       req.PageCount,
+		Edition:         req.Edition,
+		Description:     req.Description,
+		Subjects:        req.GetSubjects(),
+		SetSubjects:     req.GetSetSubjects(),
+		SeriesName:      req.SeriesName,
+		SeriesNumber:    req.SeriesNumber,
+		ISBN:            req.Isbn,
 	})
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, bookStatus(err)
 	}
 
-	return &gen.Book{
-		BookId:          book.ID,
-		Title:           book.Title,
-		Author:          book.Author,
-		PublicationYear: &book.PublicationYear,
-		Genre:           &book.Genre,
-	}, nil
+	return toProtoBook(book), nil
 }
 
 func (s *serverAPI) DeleteBook(
 	ctx context.Context,
 	req *gen.DeleteBookRequest,
 ) (*gen.DeleteBookResponse, error) {
-	if req.GetBookId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "book id is required")
-	}
-
 	id, err := s.bookService.DeleteBook(ctx, req.GetBookId())
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -129,15 +167,9 @@ func (s *serverAPI) ListBooks(
 	ctx context.Context,
 	req *gen.ListBooksRequest,
 ) (*gen.ListBooksResponse, error) {
-	filter := &models.BookFilter{}
-	if req.GetAuthor() != "" {
-		filter.Author = req.Author
-	}
-	if req.GetPublicationYear() != 0 {
-		filter.PublicationYear = req.PublicationYear
-	}
-	if req.GetGenre() != "" {
-		filter.Genre = req.Genre
+	filter, err := toBookFilter(req)
+	if err != nil {
+		return nil, err
 	}
 
 	books, err := s.bookService.ListBooks(ctx, filter)
@@ -147,31 +179,46 @@ func (s *serverAPI) ListBooks(
 
 	response := &gen.ListBooksResponse{}
 	for _, book := range books {
-		response.Books = append(response.Books, &gen.Book{
-			BookId:          book.ID,
-			Title:           book.Title,
-			Author:          book.Author,
-			PublicationYear: &book.PublicationYear,
-			Genre:           &book.Genre,
-		})
+		response.Books = append(response.Books, toProtoBook(book))
 	}
 
 	return response, nil
 }
 
-func (s *serverAPI) AddBookToUser(
+func (s *serverAPI) GetBookFacets(
 	ctx context.Context,
-	req *gen.UserBookRequest,
-) (*gen.AddUserBookResponse, error) {
-	if req.GetUserId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	req *gen.GetBookFacetsRequest,
+) (*gen.BookFacets, error) {
+	filter, err := toBookFilter(req)
+	if err != nil {
+		return nil, err
 	}
-	if req.GetBookId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "book id is required")
+	limit := defaultFacetLimit
+	if req.Limit != nil {
+		limit = int(req.GetLimit())
 	}
 
+	facets, err := s.bookService.GetBookFacets(ctx, filter, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &gen.BookFacets{
+		Genres:  toProtoFacetCounts(facets.Genres),
+		Authors: toProtoFacetCounts(facets.Authors),
+		Decades: toProtoFacetCounts(facets.Decades),
+	}, nil
+}
+
+func (s *serverAPI) AddBookToUser(
+	ctx context.Context,
+	req *gen.UserBookRequest,
+) (*gen.AddUserBookResponse, error) {
 	id, err := s.bookService.AddBookToUser(ctx, req.GetUserId(), req.GetBookId())
 	if err != nil {
+		if errors.Is(err, bookService.ErrShelfQuotaExceeded) {
+			return nil, status.Error(codes.ResourceExhausted, "shelf quota exceeded")
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -182,13 +229,6 @@ func (s *serverAPI) RemoveBookFromUser(
 	ctx context.Context,
 	req *gen.UserBookRequest,
 ) (*gen.RemoveBookFromUserResponse, error) {
-	if req.GetUserId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "user id is required")
-	}
-
-	if req.GetBookId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "book id is required")
-	}
 	id, err := s.bookService.RemoveBookFromUser(ctx, req.GetUserId(), req.GetBookId())
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -200,36 +240,182 @@ func (s *serverAPI) GetUserBooks(
 	ctx context.Context,
 	req *gen.GetUserBooksRequest,
 ) (*gen.ListBooksResponse, error) {
-	if req.GetUserId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "user id is required")
-	}
-
-	filter := &models.BookFilter{}
-	if req.GetAuthor() != "" {
-		filter.Author = req.Author
-	}
-	if req.GetPublicationYear() != 0 {
-		filter.PublicationYear = req.PublicationYear
-	}
-	if req.GetGenre() != "" {
-		filter.Genre = req.Genre
+	filter, err := toBookFilter(req)
+	if err != nil {
+		return nil, err
 	}
 
-	books, err := s.bookService.GetUserBooks(ctx, req.GetUserId(), filter)
+	books, err := s.bookService.GetUserBooks(ctx, req.GetUserId(), req.GetShelfId(), filter)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, bookStatus(err)
 	}
 
 	response := &gen.ListBooksResponse{}
 	for _, book := range books {
-		response.Books = append(response.Books, &gen.Book{
-			BookId:          book.ID,
-			Title:           book.Title,
-			Author:          book.Author,
-			PublicationYear: &book.PublicationYear,
-			Genre:           &book.Genre,
-		})
+		response.Books = append(response.Books, toProtoBook(book))
+	}
+
+	if req.GetGroupBySeries() {
+		progress, err := s.bookService.SeriesProgress(ctx, books)
+		if err != nil {
+			return nil, bookStatus(err)
+		}
+		for _, p := range progress {
+			response.SeriesProgress = append(response.SeriesProgress, &gen.SeriesProgress{
+				SeriesId: p.SeriesID,
+				Name:     p.SeriesName,
+				Owned:    int32(p.Owned),
+				Total:    int32(p.Total),
+				BookIds:  p.BookIDs,
+			})
+		}
 	}
 
 	return response, nil
 }
+
+func bookStatus(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrBookNotFound):
+		return status.Error(codes.NotFound, "book not found")
+	case errors.Is(err, storage.ErrSeriesNotFound):
+		return status.Error(codes.NotFound, "series not found")
+	case errors.Is(err, storage.ErrShelfNotFound):
+		return status.Error(codes.NotFound, "shelf not found")
+	case errors.Is(err, storage.ErrShelfExists):
+		return status.Error(codes.AlreadyExists, "shelf with this name already exists")
+	case errors.Is(err, bookService.ErrShelfLimitExceeded):
+		return status.Error(codes.ResourceExhausted, "shelf limit exceeded")
+	case errors.Is(err, bookService.ErrInvalidShelfName):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, storage.ErrShelfMismatch):
+		return status.Error(codes.InvalidArgument, "book_ids must list every book of the shelf once")
+	case errors.Is(err, bookService.ErrShelfQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, "shelf quota exceeded")
+	case errors.Is(err, bookService.ErrUnknownGenre), errors.Is(err, bookService.ErrInvalidLanguage),
+		errors.Is(err, bookService.ErrInvalidISBN):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, storage.ErrBookExists):
+		return status.Error(codes.AlreadyExists, "book with this ISBN already exists")
+	case errors.Is(err, storage.ErrNotSupported):
+		return status.Error(codes.Unimplemented, "not supported by the configured storage")
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func toProtoBook(book *models.Book) *gen.Book {
+	return &gen.Book{
+		BookId:          book.ID,
+		Title:           book.Title,
+		Author:          book.Author,
+		PublicationYear: &book.PublicationYear,
+		Genre:           &book.Genre,
+		Genres:          book.Genres,
+		Rating:          book.Rating,
+		CreatedAt:       timestamppb.New(book.CreatedAt),
+		CoverUrl:        optional(coverURL(book.ID, book.CoverKey, false)),
+		ThumbnailUrl:    optional(coverURL(book.ID, book.ThumbnailKey, true)),
+		Publisher:       optional(book.Publisher),
+		Language:        optional(book.Language),
+		PageCount:       optionalInt32(book.PageCount),
+		Edition:         optional(book.Edition),
+		Description:     optional(book.Description),
+		Subjects:        book.Subjects,
+		SeriesName:      optional(book.SeriesName),
+		SeriesNumber:    optionalInt32(book.SeriesNumber),
+		SeriesId:        optional(book.SeriesID),
+		Isbn:            optional(book.ISBN),
+	}
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func optionalInt32(n int32) *int32 {
+	if n == 0 {
+		return nil
+	}
+	return &n
+}
+
+const defaultFacetLimit = 20
+
+func toProtoFacetCounts(counts []models.FacetCount) []*gen.FacetCount {
+	result := make([]*gen.FacetCount, 0, len(counts))
+	for _, c := range counts {
+		result = append(result, &gen.FacetCount{Value: c.Value, Count: c.Count})
+	}
+	return result
+}
+
+// bookFilterRequest is implemented by ListBooksRequest, GetUserBooksRequest
+// and GetBookFacetsRequest.
+type bookFilterRequest interface {
+	GetAuthor() string
+	GetPublicationYear() int32
+	GetGenre() string
+	GetAuthors() []string
+	GetGenres() []string
+	GetPublicationYearFrom() int32
+	GetPublicationYearTo() int32
+	GetTitlePrefix() string
+	GetLanguage() string
+	GetSeries() string
+}
+
+type sortedRequest interface {
+	GetSortBy() gen.BookSortField
+	GetSortDirection() gen.SortDirection
+}
+
+var sortFields = map[gen.BookSortField]string{
+	gen.BookSortField_BOOK_SORT_FIELD_TITLE:            models.SortByTitle,
+	gen.BookSortField_BOOK_SORT_FIELD_AUTHOR:           models.SortByAuthor,
+	gen.BookSortField_BOOK_SORT_FIELD_PUBLICATION_YEAR: models.SortByPublicationYear,
+	gen.BookSortField_BOOK_SORT_FIELD_CREATED_AT:       models.SortByCreatedAt,
+	gen.BookSortField_BOOK_SORT_FIELD_RATING:           models.SortByRating,
+}
+
+func toBookFilter(req bookFilterRequest) (*models.BookFilter, error) {
+	filter := &models.BookFilter{
+		Authors: req.GetAuthors(),
+		Genres:  req.GetGenres(),
+	}
+	if sr, ok := req.(sortedRequest); ok {
+		filter.SortBy = sortFields[sr.GetSortBy()]
+		filter.SortDesc = sr.GetSortDirection() == gen.SortDirection_SORT_DIRECTION_DESC
+	}
+	if v := req.GetAuthor(); v != "" {
+		filter.Author = &v
+	}
+	if v := req.GetPublicationYear(); v != 0 {
+		filter.PublicationYear = &v
+	}
+	if v := req.GetGenre(); v != "" {
+		filter.Genre = &v
+	}
+	if v := req.GetPublicationYearFrom(); v != 0 {
+		filter.YearFrom = &v
+	}
+	if v := req.GetPublicationYearTo(); v != 0 {
+		filter.YearTo = &v
+	}
+	if v := req.GetTitlePrefix(); v != "" {
+		filter.TitlePrefix = &v
+	}
+	if v := req.GetLanguage(); v != "" {
+		filter.Language = &v
+	}
+	if v := req.GetSeries(); v != "" {
+		filter.Series = &v
+	}
+
+	if filter.YearFrom != nil && filter.YearTo != nil && *filter.YearFrom > *filter.YearTo {
+		return nil, status.Error(codes.InvalidArgument, "publication_year_from must not be greater than publication_year_to")
+	}
+	return filter, nil
+}
@@ -0,0 +1,151 @@
+package book_service
+
+import (
+	gen "bookService/internal/delivery/protos/gen/go"
+	"bookService/internal/domain/models"
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var shelfVisibilities = map[gen.ShelfVisibility]string{
+	gen.ShelfVisibility_SHELF_VISIBILITY_UNSPECIFIED: models.ShelfPrivate,
+	gen.ShelfVisibility_SHELF_VISIBILITY_PRIVATE:     models.ShelfPrivate,
+	gen.ShelfVisibility_SHELF_VISIBILITY_LINK:        models.ShelfLink,
+	gen.ShelfVisibility_SHELF_VISIBILITY_PUBLIC:      models.ShelfPublic,
+}
+
+var protoShelfVisibilities = map[string]gen.ShelfVisibility{
+	models.ShelfPrivate: gen.ShelfVisibility_SHELF_VISIBILITY_PRIVATE,
+	models.ShelfLink:    gen.ShelfVisibility_SHELF_VISIBILITY_LINK,
+	models.ShelfPublic:  gen.ShelfVisibility_SHELF_VISIBILITY_PUBLIC,
+}
+
+func (s *serverAPI) CreateShelf(
+	ctx context.Context,
+	req *gen.CreateShelfRequest,
+) (*gen.Shelf, error) {
+	shelf, err := s.bookService.CreateShelf(ctx, req.GetUserId(), req.GetName(), shelfVisibilities[req.GetVisibility()])
+	if err != nil {
+		return nil, bookStatus(err)
+	}
+
+	return toProtoShelf(shelf), nil
+}
+
+func (s *serverAPI) RenameShelf(
+	ctx context.Context,
+	req *gen.RenameShelfRequest,
+) (*gen.Shelf, error) {
+	shelf, err := s.bookService.RenameShelf(ctx, req.GetUserId(), req.GetShelfId(), req.GetName())
+	if err != nil {
+		return nil, bookStatus(err)
+	}
+
+	return toProtoShelf(shelf), nil
+}
+
+func (s *serverAPI) SetShelfVisibility(
+	ctx context.Context,
+	req *gen.SetShelfVisibilityRequest,
+) (*gen.Shelf, error) {
+	shelf, err := s.bookService.SetShelfVisibility(ctx, req.GetUserId(), req.GetShelfId(), shelfVisibilities[req.GetVisibility()])
+	if err != nil {
+		return nil, bookStatus(err)
+	}
+
+	return toProtoShelf(shelf), nil
+}
+
+func (s *serverAPI) DeleteShelf(
+	ctx context.Context,
+	req *gen.DeleteShelfRequest,
+) (*gen.DeleteShelfResponse, error) {
+	id, err := s.bookService.DeleteShelf(ctx, req.GetUserId(), req.GetShelfId())
+	if err != nil {
+		return nil, bookStatus(err)
+	}
+
+	return &gen.DeleteShelfResponse{ShelfId: id}, nil
+}
+
+func (s *serverAPI) ListShelves(
+	ctx context.Context,
+	req *gen.ListShelvesRequest,
+) (*gen.ListShelvesResponse, error) {
+	shelves, err := s.bookService.ListShelves(ctx, req.GetUserId())
+	if err != nil {
+		return nil, bookStatus(err)
+	}
+
+	response := &gen.ListShelvesResponse{}
+	for _, shelf := range shelves {
+		response.Shelves = append(response.Shelves, toProtoShelf(shelf))
+	}
+	return response, nil
+}
+
+func (s *serverAPI) AddBookToShelf(
+	ctx context.Context,
+	req *gen.ShelfBookRequest,
+) (*gen.Shelf, error) {
+	shelf, err := s.bookService.AddBookToShelf(ctx, req.GetUserId(), req.GetShelfId(), req.GetBookId())
+	if err != nil {
+		return nil, bookStatus(err)
+	}
+
+	return toProtoShelf(shelf), nil
+}
+
+func (s *serverAPI) RemoveBookFromShelf(
+	ctx context.Context,
+	req *gen.ShelfBookRequest,
+) (*gen.Shelf, error) {
+	shelf, err := s.bookService.RemoveBookFromShelf(ctx, req.GetUserId(), req.GetShelfId(), req.GetBookId())
+	if err != nil {
+		return nil, bookStatus(err)
+	}
+
+	return toProtoShelf(shelf), nil
+}
+
+func (s *serverAPI) ReorderShelf(
+	ctx context.Context,
+	req *gen.ReorderShelfRequest,
+) (*gen.Shelf, error) {
+	shelf, err := s.bookService.ReorderShelf(ctx, req.GetUserId(), req.GetShelfId(), req.GetBookIds())
+	if err != nil {
+		return nil, bookStatus(err)
+	}
+
+	return toProtoShelf(shelf), nil
+}
+
+func (s *serverAPI) GetShelf(
+	ctx context.Context,
+	req *gen.GetShelfRequest,
+) (*gen.Shelf, error) {
+	shelf, err := s.bookService.GetShelf(ctx, req.GetShelfId(), req.GetShareToken())
+	if err != nil {
+		return nil, bookStatus(err)
+	}
+
+	return toProtoShelf(shelf), nil
+}
+
+func toProtoShelf(shelf *models.Shelf) *gen.Shelf {
+	result := &gen.Shelf{
+		ShelfId:    shelf.ID,
+		UserId:     shelf.UserID,
+		Name:       shelf.Name,
+		Visibility: protoShelfVisibilities[shelf.Visibility],
+		ShareToken: optional(shelf.ShareToken),
+		BookCount:  int32(shelf.BookCount),
+		CreatedAt:  timestamppb.New(shelf.CreatedAt),
+		UpdatedAt:  timestamppb.New(shelf.UpdatedAt),
+	}
+	for _, book := range shelf.Books {
+		result.Books = append(result.Books, toProtoBook(book))
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package genre_service
+
+import (
+	gen "bookService/internal/delivery/protos/gen/go"
+	"bookService/internal/domain/models"
+	"bookService/internal/services/genreService"
+	"bookService/internal/storage"
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GenreService interface {
+	CreateGenre(ctx context.Context, genre *models.Genre) (*models.Genre, error)
+	UpdateGenre(ctx context.Context, update genreService.GenreUpdate) (*models.Genre, error)
+	DeleteGenre(ctx context.Context, id string) (string, error)
+	ListGenres(ctx context.Context) ([]*models.Genre, error)
+	SetBookGenres(ctx context.Context, bookID string, genreIDs []string) ([]string, error)
+}
+
+type serverAPI struct {
+	gen.UnimplementedGenreServiceServer
+	genreService GenreService
+}
+
+func Register(gRPC *grpc.Server, genreService GenreService) {
+	gen.RegisterGenreServiceServer(gRPC, &serverAPI{genreService: genreService})
+}
+
+func (s *serverAPI) CreateGenre(
+	ctx context.Context,
+	req *gen.CreateGenreRequest,
+) (*gen.Genre, error) {
+	genre, err := s.genreService.CreateGenre(ctx, &models.Genre{
+		Name:     req.GetName(),
+		ParentID: req.ParentId,
+		Aliases:  req.GetAliases(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toProtoGenre(genre), nil
+}
+
+func (s *serverAPI) UpdateGenre(
+	ctx context.Context,
+	req *gen.UpdateGenreRequest,
+) (*gen.Genre, error) {
+	genre, err := s.genreService.UpdateGenre(ctx, genreService.GenreUpdate{
+		ID:          req.GetGenreId(),
+		Name:        req.Name,
+		ParentID:    req.ParentId,
+		ClearParent: req.GetClearParent(),
+		Aliases:     req.GetAliases(),
+		SetAliases:  req.GetSetAliases(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toProtoGenre(genre), nil
+}
+
+func (s *serverAPI) DeleteGenre(
+	ctx context.Context,
+	req *gen.DeleteGenreRequest,
+) (*gen.DeleteGenreResponse, error) {
+	id, err := s.genreService.DeleteGenre(ctx, req.GetGenreId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &gen.DeleteGenreResponse{GenreId: id}, nil
+}
+
+func (s *serverAPI) ListGenres(
+	ctx context.Context,
+	req *gen.ListGenresRequest,
+) (*gen.ListGenresResponse, error) {
+	genres, err := s.genreService.ListGenres(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &gen.ListGenresResponse{}
+	for _, genre := range genres {
+		response.Genres = append(response.Genres, toProtoGenre(genre))
+	}
+	return response, nil
+}
+
+func (s *serverAPI) SetBookGenres(
+	ctx context.Context,
+	req *gen.SetBookGenresRequest,
+) (*gen.SetBookGenresResponse, error) {
+	genres, err := s.genreService.SetBookGenres(ctx, req.GetBookId(), req.GetGenreIds())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &gen.SetBookGenresResponse{BookId: req.GetBookId(), Genres: genres}, nil
+}
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrGenreNotFound):
+		return status.Error(codes.NotFound, "genre not found")
+	case errors.Is(err, storage.ErrBookNotFound):
+		return status.Error(codes.NotFound, "book not found")
+	case errors.Is(err, storage.ErrGenreExists):
+		return status.Error(codes.AlreadyExists, "genre already exists")
+	case errors.Is(err, storage.ErrGenreHasChildren):
+		return status.Error(codes.FailedPrecondition, "genre has child genres")
+	case errors.Is(err, storage.ErrGenreCycle):
+		return status.Error(codes.InvalidArgument, "genre cannot be its own ancestor")
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func toProtoGenre(genre *models.Genre) *gen.Genre {
+	return &gen.Genre{
+		GenreId:  genre.ID,
+		Name:     genre.Name,
+		ParentId: genre.ParentID,
+		Aliases:  genre.Aliases,
+	}
+}
@@ -0,0 +1,122 @@
+package webhook_service
+
+import (
+	gen "bookService/internal/delivery/protos/gen/go"
+	"bookService/internal/domain/models"
+	"bookService/internal/services/webhookService"
+	"bookService/internal/storage"
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, sub *models.WebhookSubscription) (*models.WebhookSubscription, error)
+	ListWebhooks(ctx context.Context) ([]*models.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, id string) (string, error)
+	ListDeliveries(ctx context.Context, subscriptionID, status string, limit int) ([]*models.WebhookDelivery, error)
+}
+
+type serverAPI struct {
+	gen.UnimplementedWebhookServiceServer
+	webhookService WebhookService
+}
+
+func Register(gRPC *grpc.Server, webhookService WebhookService) {
+	gen.RegisterWebhookServiceServer(gRPC, &serverAPI{webhookService: webhookService})
+}
+
+func (s *serverAPI) CreateWebhook(
+	ctx context.Context,
+	req *gen.CreateWebhookRequest,
+) (*gen.Webhook, error) {
+	sub, err := s.webhookService.CreateWebhook(ctx, &models.WebhookSubscription{
+		URL:        req.GetUrl(),
+		EventTypes: req.GetEventTypes(),
+		Secret:     req.GetSecret(),
+	})
+	if err != nil {
+		if errors.Is(err, webhookService.ErrInvalidURL) ||
+			errors.Is(err, webhookService.ErrInvalidEventType) ||
+			errors.Is(err, webhookService.ErrNoEventTypes) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := toProtoWebhook(sub)
+	resp.Secret = sub.Secret
+	return resp, nil
+}
+
+func (s *serverAPI) ListWebhooks(
+	ctx context.Context,
+	req *gen.ListWebhooksRequest,
+) (*gen.ListWebhooksResponse, error) {
+	subs, err := s.webhookService.ListWebhooks(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := &gen.ListWebhooksResponse{}
+	for _, sub := range subs {
+		response.Webhooks = append(response.Webhooks, toProtoWebhook(sub))
+	}
+	return response, nil
+}
+
+func (s *serverAPI) DeleteWebhook(
+	ctx context.Context,
+	req *gen.DeleteWebhookRequest,
+) (*gen.DeleteWebhookResponse, error) {
+	id, err := s.webhookService.DeleteWebhook(ctx, req.GetWebhookId())
+	if err != nil {
+		if errors.Is(err, storage.ErrWebhookNotFound) {
+			return nil, status.Error(codes.NotFound, "webhook not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &gen.DeleteWebhookResponse{WebhookId: id}, nil
+}
+
+func (s *serverAPI) ListWebhookDeliveries(
+	ctx context.Context,
+	req *gen.ListWebhookDeliveriesRequest,
+) (*gen.ListWebhookDeliveriesResponse, error) {
+	deliveries, err := s.webhookService.ListDeliveries(ctx, req.GetWebhookId(), req.GetStatus(), int(req.GetLimit()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := &gen.ListWebhookDeliveriesResponse{}
+	for _, d := range deliveries {
+		delivery := &gen.WebhookDelivery{
+			DeliveryId:    d.ID,
+			WebhookId:     d.SubscriptionID,
+			EventType:     d.EventType,
+			Status:        d.Status,
+			Attempts:      d.Attempts,
+			LastError:     d.LastError,
+			NextAttemptAt: timestamppb.New(d.NextAttemptAt),
+			CreatedAt:     timestamppb.New(d.CreatedAt),
+		}
+		if d.DeliveredAt != nil {
+			delivery.DeliveredAt = timestamppb.New(*d.DeliveredAt)
+		}
+		response.Deliveries = append(response.Deliveries, delivery)
+	}
+	return response, nil
+}
+
+func toProtoWebhook(sub *models.WebhookSubscription) *gen.Webhook {
+	return &gen.Webhook{
+		WebhookId:  sub.ID,
+		Url:        sub.URL,
+		EventTypes: sub.EventTypes,
+		CreatedAt:  timestamppb.New(sub.CreatedAt),
+	}
+}
@@ -0,0 +1,81 @@
+// Package isbn validates and normalizes International Standard Book Numbers.
+package isbn
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalid = errors.New("invalid ISBN")
+
+// Normalize strips hyphens and spaces, checks the check digit and converts
+// ISBN-10 to ISBN-13, so "0-306-40615-2" becomes "9780306406157". Trailing
+// qualifiers as found in catalog records, e.g. "9780306406157 (pbk.)", are
+// dropped.
+func Normalize(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexAny(s, " (:;"); i > 0 {
+		s = s[:i]
+	}
+	s = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(s))
+
+	switch len(s) {
+	case 10:
+		if !valid10(s) {
+			return "", ErrInvalid
+		}
+		isbn13 := "978" + s[:9]
+		return isbn13 + string(checkDigit13(isbn13)), nil
+	case 13:
+		if !digits(s) || checkDigit13(s[:12]) != s[12] {
+			return "", ErrInvalid
+		}
+		return s, nil
+	}
+	return "", ErrInvalid
+}
+
+// Valid reports whether s is a well-formed ISBN-10 or ISBN-13.
+func Valid(s string) bool {
+	_, err := Normalize(s)
+	return err == nil
+}
+
+func valid10(s string) bool {
+	sum := 0
+	for i := 0; i < 10; i++ {
+		c := s[i]
+		var d int
+		switch {
+		case c >= '0' && c <= '9':
+			d = int(c - '0')
+		case c == 'X' && i == 9:
+			d = 10
+		default:
+			return false
+		}
+		sum += d * (10 - i)
+	}
+	return sum%11 == 0
+}
+
+func checkDigit13(s string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		d := int(s[i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func digits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
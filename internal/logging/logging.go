@@ -0,0 +1,37 @@
+package logging
+
+import (
+	"bookService/internal/tracing"
+	"context"
+	"log/slog"
+)
+
+type (
+	loggerKey    struct{}
+	requestIDKey struct{}
+)
+
+// WithLogger returns a copy of ctx carrying the request-scoped logger.
+func WithLogger(ctx context.Context, log *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, log)
+}
+
+// FromContext returns the request-scoped logger of ctx, which carries the
+// request ID and method of the call. Outside of a request, such as in
+// background jobs, it returns log with the trace of ctx.
+func FromContext(ctx context.Context, log *slog.Logger) *slog.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return l
+	}
+	return tracing.Logger(ctx, log)
+}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the ID of the request ctx belongs to, or "".
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -21,8 +21,49 @@ var (
 		},
 		[]string{"method"},
 	)
+
+	GRPCPanicsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_server_panics_total",
+			Help: "Panics recovered in gRPC handlers",
+		},
+		[]string{"method"},
+	)
+
+	CacheBreakerState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cache_breaker_state",
+			Help: "Cache circuit breaker state: 0 closed, 1 half-open, 2 open",
+		},
+		[]string{"cache"},
+	)
+
+	CacheBreakerTransitionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_breaker_transitions_total",
+			Help: "Cache circuit breaker state changes by new state",
+		},
+		[]string{"cache", "state"},
+	)
+
+	CacheBreakerRejectedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_breaker_rejected_total",
+			Help: "Cache calls skipped because the circuit breaker was open",
+		},
+		[]string{"cache"},
+	)
+
+	CacheRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_requests_total",
+			Help: "Book cache lookups by tier and result: hit, stale or miss",
+		},
+		[]string{"tier", "result"},
+	)
 )
 
 func Init() {
-	prometheus.MustRegister(GRPCRequestsTotal, GRPCDuration)
+	prometheus.MustRegister(GRPCRequestsTotal, GRPCDuration, GRPCPanicsTotal,
+		CacheBreakerState, CacheBreakerTransitionsTotal, CacheBreakerRejectedTotal, CacheRequestsTotal)
 }
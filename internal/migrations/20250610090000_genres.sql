@@ -0,0 +1,79 @@
+-- +goose Up
+CREATE TABLE genres
+(
+    genre_id   UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name       VARCHAR(100) NOT NULL,
+    parent_id  UUID REFERENCES genres (genre_id) ON DELETE RESTRICT,
+    -- lower-case alternative spellings, e.g. 'sci-fi' for Science Fiction
+    aliases    TEXT[]       NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_genres_name ON genres (lower(name));
+CREATE INDEX idx_genres_parent_id ON genres (parent_id);
+CREATE INDEX idx_genres_aliases ON genres USING GIN (aliases);
+
+CREATE TABLE books_genres
+(
+    book_id  UUID REFERENCES books (book_id) ON DELETE CASCADE,
+    genre_id UUID REFERENCES genres (genre_id) ON DELETE CASCADE,
+    PRIMARY KEY (book_id, genre_id)
+);
+
+CREATE INDEX idx_books_genres_genre_id ON books_genres (genre_id);
+
+INSERT INTO genres (name, aliases)
+VALUES ('Fantasy', '{}'),
+       ('Science Fiction', '{sci-fi,sci fi,scifi,sf}'),
+       ('Mystery', '{detective,crime}'),
+       ('Thriller', '{suspense}'),
+       ('Romance', '{}'),
+       ('Horror', '{}'),
+       ('Historical Fiction', '{}'),
+       ('Literary Fiction', '{literary,fiction}'),
+       ('Young Adult', '{ya}'),
+       ('Children', '{kids,childrens}'),
+       ('Classics', '{classic}'),
+       ('Poetry', '{}'),
+       ('Drama', '{plays}'),
+       ('Comics', '{graphic novel,graphic novels,manga}'),
+       ('Non-Fiction', '{nonfiction,non fiction}');
+
+INSERT INTO genres (name, parent_id, aliases)
+SELECT child.name, parent.genre_id, child.aliases
+FROM (VALUES ('Epic Fantasy', 'Fantasy', '{high fantasy}'::TEXT[]),
+             ('Urban Fantasy', 'Fantasy', '{}'::TEXT[]),
+             ('Space Opera', 'Science Fiction', '{}'::TEXT[]),
+             ('Cyberpunk', 'Science Fiction', '{}'::TEXT[]),
+             ('Biography', 'Non-Fiction', '{memoir,autobiography}'::TEXT[]),
+             ('History', 'Non-Fiction', '{}'::TEXT[]),
+             ('Science', 'Non-Fiction', '{popular science}'::TEXT[]),
+             ('Philosophy', 'Non-Fiction', '{}'::TEXT[]),
+             ('Self-Help', 'Non-Fiction', '{self help}'::TEXT[])) AS child (name, parent, aliases)
+         JOIN genres parent ON parent.name = child.parent;
+
+-- Free-text values that match nothing above become top-level genres.
+INSERT INTO genres (name)
+SELECT DISTINCT ON (lower(trim(b.genre))) trim(b.genre)
+FROM books b
+WHERE trim(coalesce(b.genre, '')) <> ''
+  AND NOT EXISTS (SELECT 1
+                  FROM genres g
+                  WHERE lower(g.name) = lower(trim(b.genre))
+                     OR lower(trim(b.genre)) = ANY (g.aliases));
+
+INSERT INTO books_genres (book_id, genre_id)
+SELECT b.book_id, g.genre_id
+FROM books b
+         JOIN genres g ON lower(g.name) = lower(trim(b.genre)) OR lower(trim(b.genre)) = ANY (g.aliases)
+ON CONFLICT DO NOTHING;
+
+UPDATE books b
+SET genre = g.name
+FROM genres g
+WHERE lower(g.name) = lower(trim(b.genre))
+   OR lower(trim(b.genre)) = ANY (g.aliases);
+
+-- +goose Down
+DROP TABLE IF EXISTS books_genres;
+DROP TABLE IF EXISTS genres;
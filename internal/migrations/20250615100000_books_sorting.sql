@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE books
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN rating     NUMERIC(3, 2) CHECK (rating >= 0 AND rating <= 5);
+
+CREATE INDEX idx_books_title_prefix ON books (lower(title) text_pattern_ops);
+CREATE INDEX idx_books_created_at ON books (created_at);
+CREATE INDEX idx_books_rating ON books (rating);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_books_rating;
+DROP INDEX IF EXISTS idx_books_created_at;
+DROP INDEX IF EXISTS idx_books_title_prefix;
+
+ALTER TABLE books
+    DROP COLUMN IF EXISTS rating,
+    DROP COLUMN IF EXISTS created_at;
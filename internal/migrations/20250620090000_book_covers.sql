@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE books
+    ADD COLUMN cover_key     VARCHAR(255),
+    ADD COLUMN thumbnail_key VARCHAR(255);
+
+-- +goose Down
+ALTER TABLE books
+    DROP COLUMN IF EXISTS thumbnail_key,
+    DROP COLUMN IF EXISTS cover_key;
@@ -0,0 +1,27 @@
+-- +goose Up
+ALTER TABLE books
+    ADD COLUMN publisher     VARCHAR(255),
+    ADD COLUMN language      VARCHAR(35),
+    ADD COLUMN page_count    INTEGER CHECK (page_count > 0),
+    ADD COLUMN edition       VARCHAR(100),
+    ADD COLUMN description   TEXT,
+    ADD COLUMN subjects      TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN series_name   VARCHAR(255),
+    ADD COLUMN series_number INTEGER CHECK (series_number > 0);
+
+CREATE INDEX idx_books_language ON books (lower(language));
+CREATE INDEX idx_books_series ON books (lower(series_name), series_number);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_books_series;
+DROP INDEX IF EXISTS idx_books_language;
+
+ALTER TABLE books
+    DROP COLUMN IF EXISTS series_number,
+    DROP COLUMN IF EXISTS series_name,
+    DROP COLUMN IF EXISTS subjects,
+    DROP COLUMN IF EXISTS description,
+    DROP COLUMN IF EXISTS edition,
+    DROP COLUMN IF EXISTS page_count,
+    DROP COLUMN IF EXISTS language,
+    DROP COLUMN IF EXISTS publisher;
@@ -0,0 +1,45 @@
+-- +goose Up
+CREATE TABLE series
+(
+    series_id   UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name        VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_series_name ON series (lower(name));
+
+ALTER TABLE books
+    ADD COLUMN series_id UUID REFERENCES series (series_id) ON DELETE SET NULL;
+
+-- Turn the free-text series names into series entities.
+INSERT INTO series (name)
+SELECT DISTINCT ON (lower(series_name)) series_name
+FROM books
+WHERE series_name IS NOT NULL AND series_name <> ''
+ORDER BY lower(series_name), series_name;
+
+UPDATE books b
+SET series_id = s.series_id
+FROM series s
+WHERE lower(b.series_name) = lower(s.name);
+
+DROP INDEX IF EXISTS idx_books_series;
+ALTER TABLE books DROP COLUMN series_name;
+
+CREATE INDEX idx_books_series_id ON books (series_id, series_number);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_books_series_id;
+
+ALTER TABLE books ADD COLUMN series_name VARCHAR(255);
+
+UPDATE books b
+SET series_name = s.name
+FROM series s
+WHERE b.series_id = s.series_id;
+
+CREATE INDEX idx_books_series ON books (lower(series_name), series_number);
+
+ALTER TABLE books DROP COLUMN series_id;
+DROP TABLE IF EXISTS series;
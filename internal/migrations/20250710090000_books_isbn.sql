@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE books ADD COLUMN isbn VARCHAR(13);
+
+-- ISBNs are stored in their normalized ISBN-13 form, so equal numbers
+-- always compare equal.
+CREATE UNIQUE INDEX idx_books_isbn ON books (isbn) WHERE isbn IS NOT NULL;
+CREATE INDEX idx_books_title_author ON books (lower(title), lower(author));
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_books_title_author;
+DROP INDEX IF EXISTS idx_books_isbn;
+
+ALTER TABLE books DROP COLUMN IF EXISTS isbn;
@@ -0,0 +1,31 @@
+-- +goose Up
+CREATE TABLE shelves
+(
+    shelf_id    UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     UUID         NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    name        VARCHAR(100) NOT NULL,
+    visibility  VARCHAR(10)  NOT NULL DEFAULT 'private'
+        CHECK (visibility IN ('private', 'link', 'public')),
+    -- Set while the shelf is link-shared.
+    share_token VARCHAR(64) UNIQUE,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_shelves_user_name ON shelves (user_id, lower(name));
+
+CREATE TABLE shelf_books
+(
+    shelf_id UUID        NOT NULL REFERENCES shelves (shelf_id) ON DELETE CASCADE,
+    book_id  UUID        NOT NULL REFERENCES books (book_id) ON DELETE CASCADE,
+    position INTEGER     NOT NULL,
+    added_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (shelf_id, book_id)
+);
+
+CREATE INDEX idx_shelf_books_position ON shelf_books (shelf_id, position);
+CREATE INDEX idx_shelf_books_book_id ON shelf_books (book_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS shelf_books;
+DROP TABLE IF EXISTS shelves;
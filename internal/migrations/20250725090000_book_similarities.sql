@@ -0,0 +1,17 @@
+-- +goose Up
+-- Item-to-item scores derived from users_books, rebuilt periodically by the
+-- recommendations refresher.
+CREATE TABLE book_similarities
+(
+    book_id         UUID             NOT NULL REFERENCES books (book_id) ON DELETE CASCADE,
+    similar_book_id UUID             NOT NULL REFERENCES books (book_id) ON DELETE CASCADE,
+    score           DOUBLE PRECISION NOT NULL,
+    co_shelved      INTEGER          NOT NULL,
+    computed_at     TIMESTAMPTZ      NOT NULL DEFAULT now(),
+    PRIMARY KEY (book_id, similar_book_id)
+);
+
+CREATE INDEX idx_book_similarities_score ON book_similarities (book_id, score DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS book_similarities;
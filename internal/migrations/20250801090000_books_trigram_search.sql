@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- fold_text lowercases text and strips diacritics, as the import's
+-- duplicate detection does, so "Émile" and "emile" compare equal. unaccent
+-- itself is only stable; naming the dictionary makes it safe to index.
+-- +goose StatementBegin
+CREATE FUNCTION fold_text(text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+AS $$ SELECT lower(public.unaccent('public.unaccent'::regdictionary, $1)) $$;
+-- +goose StatementEnd
+
+CREATE INDEX idx_books_title_trgm ON books USING gin (fold_text(title) gin_trgm_ops);
+CREATE INDEX idx_books_author_trgm ON books USING gin (fold_text(author) gin_trgm_ops);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_books_author_trgm;
+DROP INDEX IF EXISTS idx_books_title_trgm;
+
+DROP FUNCTION IF EXISTS fold_text(text);
+-- The extensions stay: other objects in the database may use them.
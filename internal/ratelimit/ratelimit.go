@@ -0,0 +1,19 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+type Limit struct {
+	// Rate is the number of tokens added to the bucket per second.
+	Rate float64
+	// Burst is the bucket capacity.
+	Burst int
+}
+
+type Limiter interface {
+	// Allow takes one token from the bucket identified by key. When the
+	// bucket is empty it reports how long the caller should wait.
+	Allow(ctx context.Context, key string, limit Limit) (allowed bool, retryAfter time.Duration, err error)
+}
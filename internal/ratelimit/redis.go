@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"log/slog"
+	"time"
+)
+
+const keyPrefix = "ratelimit:"
+
+// tokenBucket refills and takes a token atomically. It uses the Redis clock
+// so that replicas with skewed clocks share one view of the bucket.
+var tokenBucket = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call("TIME")
+local now = t[1] * 1000 + math.floor(t[2] / 1000)
+
+local data = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(data[1]) or burst
+local ts = tonumber(data[2]) or now
+
+tokens = math.min(burst, tokens + (now - ts) / 1000 * rate)
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / rate * 1000)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+return {allowed, retry}
+`)
+
+// Redis is a token bucket limiter shared by all replicas. If Redis is
+// unavailable it falls back to the local limiter instead of failing calls.
+type Redis struct {
+	log      *slog.Logger
+	client   *redis.Client
+	fallback Limiter
+}
+
+func NewRedis(log *slog.Logger, client *redis.Client, fallback Limiter) *Redis {
+	return &Redis{
+		log:      log,
+		client:   client,
+		fallback: fallback,
+	}
+}
+
+func (r *Redis) Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	const op = "ratelimit.Redis.Allow"
+
+	res, err := tokenBucket.Run(ctx, r.client, []string{keyPrefix + key}, limit.Rate, limit.Burst).Int64Slice()
+	if err != nil {
+		r.log.Warn("redis rate limiter unavailable, using local limiter",
+			slog.String("op", op),
+			slog.String("error", err.Error()),
+		)
+		if r.fallback == nil {
+			return false, 0, fmt.Errorf("%s: %w", op, err)
+		}
+		return r.fallback.Allow(ctx, key, limit)
+	}
+	if len(res) != 2 {
+		return false, 0, fmt.Errorf("%s: unexpected script result %v", op, res)
+	}
+
+	return res[0] == 1, time.Duration(res[1]) * time.Millisecond, nil
+}
@@ -0,0 +1,88 @@
+package recommendations
+
+import (
+	"bookService/config"
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type Storage interface {
+	RefreshBookSimilarities(ctx context.Context, topN, minCoShelved int) (bool, int64, error)
+}
+
+// Refresher periodically recomputes book similarities from shelf
+// co-occurrence, so that recommendation reads are plain lookups.
+type Refresher struct {
+	log     *slog.Logger
+	storage Storage
+	cfg     config.RecommendationsConfig
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(log *slog.Logger, storage Storage, cfg config.RecommendationsConfig) *Refresher {
+	return &Refresher{
+		log:     log,
+		storage: storage,
+		cfg:     cfg,
+	}
+}
+
+func (r *Refresher) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.run(ctx)
+	}()
+}
+
+func (r *Refresher) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+}
+
+func (r *Refresher) run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	// Refresh right away so that a fresh deployment has scores to serve.
+	for {
+		r.refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Refresher) refresh(ctx context.Context) {
+	const op = "recommendations.refresh"
+	log := r.log.With(slog.String("op", op))
+
+	started := time.Now()
+	refreshed, count, err := r.storage.RefreshBookSimilarities(ctx, r.cfg.TopN, r.cfg.MinCoShelved)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Error("failed to refresh book similarities", slog.String("error", err.Error()))
+		}
+		return
+	}
+	if !refreshed {
+		log.Debug("book similarities are being refreshed by another replica")
+		return
+	}
+	log.Info("book similarities refreshed",
+		slog.Int64("pairs", count),
+		slog.Duration("took", time.Since(started)),
+	)
+}
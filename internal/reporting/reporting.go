@@ -0,0 +1,85 @@
+package reporting
+
+import (
+	"bookService/config"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Event is a failure that needs a human's attention, such as a panic.
+type Event struct {
+	Time      time.Time `json:"time"`
+	Method    string    `json:"method"`
+	RequestID string    `json:"request_id,omitempty"`
+	Message   string    `json:"message"`
+	Stack     string    `json:"stack,omitempty"`
+}
+
+// ErrorReporter sends events to an error tracking sink.
+type ErrorReporter interface {
+	Report(ctx context.Context, event Event) error
+}
+
+// New returns the reporter selected by cfg, or nil when reporting is off.
+func New(cfg config.ErrorReportingConfig) (ErrorReporter, error) {
+	const op = "reporting.New"
+
+	switch cfg.Backend {
+	case "", "none":
+		return nil, nil
+	case "file":
+		r, err := NewFile(cfg.File)
+		if err != nil {
+			return nil, err
+		}
+		return r, nil
+	default:
+		return nil, fmt.Errorf("%s: unknown backend %q", op, cfg.Backend)
+	}
+}
+
+// FileReporter appends events to a file as JSON lines. It is meant for
+// local runs and tests, where no error tracking service is at hand.
+type FileReporter struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFile(path string) (*FileReporter, error) {
+	const op = "reporting.NewFile"
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &FileReporter{path: path}, nil
+}
+
+func (r *FileReporter) Report(_ context.Context, event Event) error {
+	const op = "reporting.FileReporter.Report"
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
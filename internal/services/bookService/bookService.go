@@ -2,9 +2,31 @@ package bookService
 
 import (
 	"bookService/internal/domain/models"
+	"bookService/internal/isbn"
+	"bookService/internal/logging"
+	"bookService/internal/storage"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/maphash"
 	"log/slog"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/text/language"
+)
+
+var (
+	ErrShelfQuotaExceeded = errors.New("shelf quota exceeded")
+	ErrUnknownGenre       = errors.New("unknown genre")
+	ErrInvalidLanguage    = errors.New("invalid language tag")
+	ErrInvalidISBN        = errors.New("invalid ISBN")
 )
 
 type BookService struct {
@@ -12,82 +34,414 @@ type BookService struct {
 	bookSaver    BookSaver
 	bookProvider BookProvider
 	bookCache    BookCache
+	events       EventPublisher
+	genres       GenreResolver
+	series       SeriesStorage
+	shelves      ShelfStorage
+	maxShelfSize int
+	maxShelves   int
+
+	// loads coalesces concurrent cache misses of a book into one query.
+	loads singleflight.Group
+	// invalidations counts the invalidations of book cache keys, spread over
+	// a fixed number of slots, so that a load can tell that the book it read
+	// may have changed before it got cached.
+	invalidations [invalidationSlots]atomic.Uint64
+	seed          maphash.Seed
 }
 
+// invalidationSlots is the number of invalidation counters. Keys sharing a
+// slot only cost each other a skipped cache write.
+const invalidationSlots = 256
+
 type BookSaver interface {
 	AddBook(ctx context.Context, book *models.Book) (*models.Book, error)
-	UpdateBook(ctx context.Context, book *models.Book) (*models.Book, error)
+	// AddBooks adds every book or none. genreIDs, when not nil, holds for
+	// each book the genre to tag it with, or "" for none; the tags are added
+	// in the transaction that adds the books.
+	AddBooks(ctx context.Context, books []*models.Book, genreIDs []string) ([]*models.Book, error)
+	// UpdateBook reads the book, lets update change it and writes it back,
+	// with the book locked throughout, so that concurrent updates apply one
+	// after the other. An error from update leaves the book as it was.
+	UpdateBook(ctx context.Context, id string, update func(book *models.Book) error) (*models.Book, error)
 	DeleteBook(ctx context.Context, id string) (string, error)
 	RemoveBookFromUser(ctx context.Context, userID, bookID string) (string, error)
-	AddBookToUser(ctx context.Context, userID, bookID string) (string, error)
+	AddBookToUser(ctx context.Context, userID, bookID string, maxShelfSize int) (string, error)
 }
 type BookProvider interface {
 	GetBook(ctx context.Context, id string) (*models.Book, error)
 	ListBooks(ctx context.Context, filter *models.BookFilter) ([]*models.Book, error)
 	GetUserBooks(ctx context.Context, userID string, filter *models.BookFilter) ([]*models.Book, error)
+	CountUserBooks(ctx context.Context, userID string) (int, error)
+	GetBookFacets(ctx context.Context, filter *models.BookFilter, limit int) (*models.BookFacets, error)
 }
+
+// BookCache returns nil, nil for a key it does not hold, and
+// storage.ErrBookNotFound for one cached by SetBookNotFound.
 type BookCache interface {
 	GetBook(ctx context.Context, id string) (*models.Book, error)
 	SetBook(ctx context.Context, key string, book *models.Book) error
+	SetBookNotFound(ctx context.Context, key string) error
 	InvalidateBook(ctx context.Context, key string) error
+	// FacetsGeneration changes with every InvalidateFacets. Facet keys carry
+	// it, so counts computed before an invalidation are never read after it.
+	FacetsGeneration(ctx context.Context) (int64, error)
+	GetFacets(ctx context.Context, key string) (*models.BookFacets, error)
+	SetFacets(ctx context.Context, key string, facets *models.BookFacets) error
+	InvalidateFacets(ctx context.Context) error
+}
+type GenreResolver interface {
+	ResolveGenre(ctx context.Context, name string) (*models.Genre, error)
+	TagBook(ctx context.Context, bookID, genreID string) error
+	RetagBook(ctx context.Context, bookID, previous, genreID string) error
+}
+type SeriesStorage interface {
+	ListSeries(ctx context.Context) ([]*models.Series, error)
+	GetSeries(ctx context.Context, id string) (*models.Series, error)
+	CountSeriesBooks(ctx context.Context, seriesIDs []string) (map[string]int, error)
+	AddSeriesToUser(ctx context.Context, userID, seriesID string, maxShelfSize int) ([]string, error)
+}
+type EventPublisher interface {
+	Publish(ctx context.Context, event models.BookEvent) error
+}
+
+// BookUpdate lists the fields to change; nil fields are left as they are,
+// so clients that don't know about a field never clear it.
+type BookUpdate struct {
+	ID              string
+	Title           *string
+	Author          *string
+	PublicationYear *int32
+	Genre           *string
+	Rating          *float64
+	Publisher       *string
+	Language        *string
+	PageCount       *int32
+	Edition         *string
+	Description     *string
+	Subjects        []string
+	SetSubjects     bool
+	SeriesName      *string
+	SeriesNumber    *int32
+	ISBN            *string
+}
+
+func (u BookUpdate) apply(book *models.Book) {
+	setIf(&book.Title, u.Title)
+	setIf(&book.Author, u.Author)
+	setIf(&book.PublicationYear, u.PublicationYear)
+	setIf(&book.Genre, u.Genre)
+	if u.Rating != nil {
+		book.Rating = u.Rating
+	}
+	setIf(&book.Publisher, u.Publisher)
+	setIf(&book.Language, u.Language)
+	setIf(&book.PageCount, u.PageCount)
+	setIf(&book.Edition, u.Edition)
+	setIf(&book.Description, u.Description)
+	if u.SetSubjects {
+		book.Subjects = u.Subjects
+	}
+	setIf(&book.SeriesName, u.SeriesName)
+	setIf(&book.SeriesNumber, u.SeriesNumber)
+	setIf(&book.ISBN, u.ISBN)
+}
+
+func setIf[T any](dst *T, v *T) {
+	if v != nil {
+		*dst = *v
+	}
 }
 
+// New builds the book service. events and genres may be nil when the
+// storage has no webhook outbox or genre taxonomy: mutations then notify
+// nobody and genres stay free text. Without series or shelves storage the
+// calls that need them fail with storage.ErrNotSupported.
 func New(
 	bookSaver BookSaver,
 	bookProvider BookProvider,
 	bookCache BookCache,
+	events EventPublisher,
+	genres GenreResolver,
+	series SeriesStorage,
+	shelves ShelfStorage,
+	maxShelfSize int,
+	maxShelves int,
 	log *slog.Logger,
 ) *BookService {
+	if series == nil {
+		series = unsupported{}
+	}
+	if shelves == nil {
+		shelves = unsupported{}
+	}
 	return &BookService{
 		bookSaver:    bookSaver,
 		bookProvider: bookProvider,
 		bookCache:    bookCache,
+		events:       events,
+		genres:       genres,
+		series:       series,
+		shelves:      shelves,
+		maxShelfSize: maxShelfSize,
+		maxShelves:   maxShelves,
 		log:          log,
+		seed:         maphash.MakeSeed(),
+	}
+}
+
+// publish notifies subscribers about a catalog change. Failures are logged
+// rather than returned so that a broken webhook store never fails a mutation.
+func (s *BookService) publish(ctx context.Context, log *slog.Logger, eventType, bookID string, book *models.Book) {
+	if s.events == nil {
+		return
+	}
+	event := models.BookEvent{
+		Type:       eventType,
+		BookID:     bookID,
+		Book:       book,
+		OccurredAt: time.Now().UTC(),
+	}
+	if err := s.events.Publish(ctx, event); err != nil {
+		log.Warn("failed to publish event", slog.String("event", eventType), slog.String("error", err.Error()))
+	}
+}
+
+// resolveGenre maps the free-text genre of book to the taxonomy and rewrites
+// it to the canonical name, so "sci-fi" is stored as "Science Fiction".
+func (s *BookService) resolveGenre(ctx context.Context, book *models.Book) (*models.Genre, error) {
+	if book.Genre == "" || s.genres == nil {
+		return nil, nil
+	}
+	genre, err := s.genres.ResolveGenre(ctx, book.Genre)
+	if err != nil {
+		if errors.Is(err, storage.ErrGenreNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownGenre, book.Genre)
+		}
+		return nil, err
+	}
+	book.Genre = genre.Name
+	return genre, nil
+}
+
+// normalizeMetadata canonicalizes the language tag ("EN-us" becomes "en-US")
+// and the ISBN, and trims and dedupes subjects.
+func normalizeMetadata(book *models.Book) error {
+	if book.Language != "" {
+		tag, err := language.Parse(book.Language)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidLanguage, book.Language)
+		}
+		book.Language = tag.String()
+	}
+	if book.ISBN != "" {
+		normalized, err := isbn.Normalize(book.ISBN)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidISBN, book.ISBN)
+		}
+		book.ISBN = normalized
+	}
+
+	subjects := make([]string, 0, len(book.Subjects))
+	for _, subject := range book.Subjects {
+		subject = strings.TrimSpace(subject)
+		if subject != "" && !slices.Contains(subjects, subject) {
+			subjects = append(subjects, subject)
+		}
+	}
+	book.Subjects = subjects
+	return nil
+}
+
+// tagBook makes sure the primary genre is also one of the book's tags.
+func (s *BookService) tagBook(ctx context.Context, log *slog.Logger, book *models.Book, genre *models.Genre) {
+	if genre == nil {
+		return
+	}
+	if err := s.genres.TagBook(ctx, book.ID, genre.ID); err != nil {
+		log.Warn("failed to tag book with genre", slog.String("error", err.Error()))
+		return
+	}
+	if !slices.Contains(book.Genres, genre.Name) {
+		book.Genres = append(book.Genres, genre.Name)
+	}
+}
+
+// retagBook moves the book's tag from its previous primary genre, named
+// previous, to genre, which may be nil when the genre was cleared.
+func (s *BookService) retagBook(ctx context.Context, log *slog.Logger, book *models.Book, previous string, genre *models.Genre) {
+	if s.genres == nil {
+		return
+	}
+	genreID, name := "", ""
+	if genre != nil {
+		genreID, name = genre.ID, genre.Name
+	}
+	if strings.EqualFold(previous, name) {
+		s.tagBook(ctx, log, book, genre)
+		return
+	}
+	if err := s.genres.RetagBook(ctx, book.ID, previous, genreID); err != nil {
+		log.Warn("failed to retag book with genre", slog.String("error", err.Error()))
+		return
+	}
+	book.Genres = slices.DeleteFunc(book.Genres, func(g string) bool {
+		return strings.EqualFold(g, previous)
+	})
+	if genre != nil && !slices.Contains(book.Genres, name) {
+		book.Genres = append(book.Genres, name)
 	}
 }
 
+// PrepareBook normalizes book and resolves its genre as AddBook does, but
+// stores nothing, so a dry run reports the errors AddBook would return.
+func (s *BookService) PrepareBook(ctx context.Context, book *models.Book) error {
+	const op = "BookService.PrepareBook"
+
+	if err := normalizeMetadata(book); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := s.resolveGenre(ctx, book); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
 func (s *BookService) AddBook(ctx context.Context, book *models.Book) (*models.Book, error) {
 	const op = "BookService.AddBook"
 
-	log := s.log.With(
+	log := logging.FromContext(ctx, s.log).With(
 		slog.String("op", op),
 		slog.String("id", book.ID),
 	)
 
-	book, err := s.bookSaver.AddBook(ctx, book)
+	if err := normalizeMetadata(book); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	genre, err := s.resolveGenre(ctx, book)
+	if err != nil {
+		log.Info("rejected genre", slog.String("genre", book.Genre), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	book, err = s.bookSaver.AddBook(ctx, book)
 	if err != nil {
 		log.Error("failed AddBook", slog.String("error", err.Error()))
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+	s.tagBook(ctx, log, book, genre)
+	// The ID may have been looked up, and cached as missing, before.
+	s.invalidateBook(ctx, log, book.ID)
+	s.invalidateFacets(ctx, log)
+	s.publish(ctx, log, models.EventBookCreated, book.ID, book)
 	log.Info("added book")
 	return book, nil
 }
-func (s *BookService) UpdateBook(ctx context.Context, book *models.Book) (*models.Book, error) {
+
+// AddBooks adds books in one go: every book is prepared as AddBook does and
+// then all of them are stored together, or none when one fails.
+func (s *BookService) AddBooks(ctx context.Context, books []*models.Book) ([]*models.Book, error) {
+	const op = "BookService.AddBooks"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.Int("count", len(books)),
+	)
+
+	genres := make([]*models.Genre, len(books))
+	for i, book := range books {
+		if err := normalizeMetadata(book); err != nil {
+			return nil, fmt.Errorf("%s: book %d: %w", op, i, err)
+		}
+		genre, err := s.resolveGenre(ctx, book)
+		if err != nil {
+			log.Info("rejected genre", slog.String("genre", book.Genre), slog.String("error", err.Error()))
+			return nil, fmt.Errorf("%s: book %d: %w", op, i, err)
+		}
+		genres[i] = genre
+	}
+
+	// The books are tagged along, so a batch is never left tagged in part.
+	var genreIDs []string
+	for i, genre := range genres {
+		if genre == nil {
+			continue
+		}
+		if genreIDs == nil {
+			genreIDs = make([]string, len(books))
+		}
+		genreIDs[i] = genre.ID
+	}
+	added, err := s.bookSaver.AddBooks(ctx, books, genreIDs)
+	if err != nil {
+		log.Error("failed AddBooks", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	for i, book := range added {
+		if genre := genres[i]; genre != nil && !slices.Contains(book.Genres, genre.Name) {
+			book.Genres = append(book.Genres, genre.Name)
+		}
+		s.invalidateBook(ctx, log, book.ID)
+	}
+	s.invalidateFacets(ctx, log)
+	for _, book := range added {
+		s.publish(ctx, log, models.EventBookCreated, book.ID, book)
+	}
+	log.Info("added books")
+	return added, nil
+}
+func (s *BookService) UpdateBook(ctx context.Context, update BookUpdate) (*models.Book, error) {
 	const op = "BookService.UpdateBook"
 
-	log := s.log.With(
+	log := logging.FromContext(ctx, s.log).With(
 		slog.String("op", op),
-		slog.String("id", book.ID),
+		slog.String("id", update.ID),
+	)
+
+	// Only a changed genre is resolved; legacy free-text values stay
+	// untouched until the client sets a new one. It is resolved up front so
+	// that the book is not kept locked meanwhile.
+	var (
+		genre *models.Genre
+		err   error
 	)
+	if update.Genre != nil {
+		resolved := &models.Book{Genre: *update.Genre}
+		genre, err = s.resolveGenre(ctx, resolved)
+		if err != nil {
+			log.Info("rejected genre", slog.String("genre", resolved.Genre), slog.String("error", err.Error()))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		update.Genre = &resolved.Genre
+	}
 
-	updatedBook, err := s.bookSaver.UpdateBook(ctx, book)
+	var previousGenre string
+	updatedBook, err := s.bookSaver.UpdateBook(ctx, update.ID, func(book *models.Book) error {
+		previousGenre = book.Genre
+		update.apply(book)
+		return normalizeMetadata(book)
+	})
 	if err != nil {
+		if errors.Is(err, ErrInvalidLanguage) || errors.Is(err, ErrInvalidISBN) {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
 		log.Error("failed to update book", slog.String("error", err.Error()))
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-
-	cacheKey := fmt.Sprintf("book:%s", book.ID)
-	if err := s.bookCache.InvalidateBook(ctx, cacheKey); err != nil {
-		log.Warn("failed to invalidate cache", slog.String("error", err.Error()))
+	if update.Genre != nil {
+		s.retagBook(ctx, log, updatedBook, previousGenre, genre)
 	}
 
+	s.invalidateBook(ctx, log, updatedBook.ID)
+	s.invalidateFacets(ctx, log)
+	s.publish(ctx, log, models.EventBookUpdated, updatedBook.ID, updatedBook)
 	log.Info("book updated successfully")
 	return updatedBook, nil
 }
 func (s *BookService) DeleteBook(ctx context.Context, id string) (string, error) {
 	const op = "BookService.DeleteBook"
 
-	log := s.log.With(
+	log := logging.FromContext(ctx, s.log).With(
 		slog.String("op", op),
 		slog.String("id", id),
 	)
@@ -98,42 +452,117 @@ func (s *BookService) DeleteBook(ctx context.Context, id string) (string, error)
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
+	s.invalidateBook(ctx, log, id)
+	s.invalidateFacets(ctx, log)
+	s.publish(ctx, log, models.EventBookDeleted, id, nil)
 	log.Info("book deleted successfully")
 	return id, nil
 }
 func (s *BookService) GetBook(ctx context.Context, id string) (*models.Book, error) {
 	const op = "BookService.GetBook"
 
-	log := s.log.With(
+	log := logging.FromContext(ctx, s.log).With(
 		slog.String("op", op),
 		slog.String("id", id),
 	)
-	cacheKey := fmt.Sprintf("book:%s", id)
+	cacheKey := bookCacheKey(id)
 	cachedBook, err := s.bookCache.GetBook(ctx, cacheKey)
-	if err != nil {
+	switch {
+	case errors.Is(err, storage.ErrBookNotFound):
+		log.Debug("book known to be missing from cache")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	case err != nil:
 		log.Warn("cache get error", slog.String("error", err.Error()))
-	}
-	if cachedBook != nil {
+	case cachedBook != nil:
 		log.Debug("book retrieved from cache")
 		return cachedBook, nil
 	}
 
-	book, err := s.bookProvider.GetBook(ctx, id)
+	book, err := s.loadBook(ctx, log, cacheKey, id)
 	if err != nil {
 		log.Error("failed to get book", slog.String("error", err.Error()))
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-
-	if err := s.bookCache.SetBook(ctx, cacheKey, book); err != nil {
-		log.Warn("failed to cache book", slog.String("error", err.Error()))
-	}
 	log.Debug("book retrieved")
 	return book, nil
 }
+
+// loadBook reads a book that missed the cache and caches it, or caches that
+// it does not exist. Concurrent misses of the same book share one query,
+// which does not stop when the caller that started it gives up.
+//
+// A write may land between the read and the cache write, whose
+// invalidation must then win: the load caches nothing when the book was
+// invalidated since it started, and drops what it cached when that
+// happens while it does.
+func (s *BookService) loadBook(ctx context.Context, log *slog.Logger, cacheKey, id string) (*models.Book, error) {
+	loaded := s.loads.DoChan(cacheKey, func() (any, error) {
+		ctx := context.WithoutCancel(ctx)
+		counter := s.invalidationCounter(cacheKey)
+		gen := counter.Load()
+
+		book, err := s.bookProvider.GetBook(ctx, id)
+		if counter.Load() != gen {
+			return book, err
+		}
+		switch {
+		case errors.Is(err, storage.ErrBookNotFound):
+			if err := s.bookCache.SetBookNotFound(ctx, cacheKey); err != nil {
+				log.Warn("failed to cache missing book", slog.String("error", err.Error()))
+			}
+		case err == nil:
+			if err := s.bookCache.SetBook(ctx, cacheKey, book); err != nil {
+				log.Warn("failed to cache book", slog.String("error", err.Error()))
+			}
+		default:
+			return book, err
+		}
+		if counter.Load() != gen {
+			if err := s.bookCache.InvalidateBook(ctx, cacheKey); err != nil {
+				log.Warn("failed to invalidate cache", slog.String("error", err.Error()))
+			}
+		}
+		return book, err
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-loaded:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		book := res.Val.(*models.Book)
+		if res.Shared {
+			book = book.Clone()
+		}
+		return book, nil
+	}
+}
+
+func bookCacheKey(id string) string {
+	return fmt.Sprintf("book:%s", id)
+}
+
+func (s *BookService) invalidationCounter(cacheKey string) *atomic.Uint64 {
+	return &s.invalidations[maphash.String(s.seed, cacheKey)%invalidationSlots]
+}
+
+// invalidateBook drops the cached book, or the cached fact that it does
+// not exist. Loads already reading the book do not cache what they read,
+// and later misses start a load of their own.
+func (s *BookService) invalidateBook(ctx context.Context, log *slog.Logger, id string) {
+	cacheKey := bookCacheKey(id)
+	s.invalidationCounter(cacheKey).Add(1)
+	s.loads.Forget(cacheKey)
+	if err := s.bookCache.InvalidateBook(ctx, cacheKey); err != nil {
+		log.Warn("failed to invalidate cache", slog.String("error", err.Error()))
+	}
+}
 func (s *BookService) ListBooks(ctx context.Context, filter *models.BookFilter) ([]*models.Book, error) {
 	const op = "BookService.ListBooks"
 
-	log := s.log.With(
+	log := logging.FromContext(ctx, s.log).With(
 		slog.String("op", op),
 	)
 
@@ -146,17 +575,97 @@ func (s *BookService) ListBooks(ctx context.Context, filter *models.BookFilter)
 	log.Info("listed books", slog.Int("count", len(books)))
 	return books, nil
 }
-func (s *BookService) GetUserBooks(ctx context.Context, userID string, filter *models.BookFilter) ([]*models.Book, error) {
+
+// GetBookFacets returns per-facet book counts for filter, caching them until
+// the next catalog change.
+func (s *BookService) GetBookFacets(ctx context.Context, filter *models.BookFilter, limit int) (*models.BookFacets, error) {
+	const op = "BookService.GetBookFacets"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+	)
+
+	// Without the generation a cached result cannot be told from a stale
+	// one, so the cache is left alone.
+	gen, err := s.bookCache.FacetsGeneration(ctx)
+	cacheable := err == nil
+	if err != nil {
+		log.Warn("cache get error", slog.String("error", err.Error()))
+	}
+	cacheKey, err := facetsCacheKey(gen, filter, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if cacheable {
+		cached, err := s.bookCache.GetFacets(ctx, cacheKey)
+		if err != nil {
+			log.Warn("cache get error", slog.String("error", err.Error()))
+		}
+		if cached != nil {
+			log.Debug("facets retrieved from cache")
+			return cached, nil
+		}
+	}
+
+	facets, err := s.bookProvider.GetBookFacets(ctx, filter, limit)
+	if err != nil {
+		log.Error("failed to get book facets", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Counts taken before a concurrent invalidation land under the old
+	// generation, where nobody looks for them.
+	if cacheable {
+		if err := s.bookCache.SetFacets(ctx, cacheKey, facets); err != nil {
+			log.Warn("failed to cache facets", slog.String("error", err.Error()))
+		}
+	}
+	log.Debug("facets retrieved")
+	return facets, nil
+}
+
+func facetsCacheKey(gen int64, filter *models.BookFilter, limit int) (string, error) {
+	data, err := json.Marshal(struct {
+		Filter *models.BookFilter
+		Limit  int
+	}{filter, limit})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("facets:%d:%s", gen, hex.EncodeToString(sum[:16])), nil
+}
+
+func (s *BookService) invalidateFacets(ctx context.Context, log *slog.Logger) {
+	if err := s.bookCache.InvalidateFacets(ctx); err != nil {
+		log.Warn("failed to invalidate facets cache", slog.String("error", err.Error()))
+	}
+}
+
+// GetUserBooks lists the books of the user or, when shelfID is set, the
+// books on that shelf of the user.
+func (s *BookService) GetUserBooks(ctx context.Context, userID, shelfID string, filter *models.BookFilter) ([]*models.Book, error) {
 	const op = "BookService.GetUserBooks"
 
-	log := s.log.With(
+	log := logging.FromContext(ctx, s.log).With(
 		slog.String("op", op),
 		slog.String("user_id", userID),
+		slog.String("shelf_id", shelfID),
 	)
 
-	books, err := s.bookProvider.GetUserBooks(ctx, userID, filter)
+	var (
+		books []*models.Book
+		err   error
+	)
+	if shelfID != "" {
+		books, err = s.getOwnShelfBooks(ctx, userID, shelfID, filter)
+	} else {
+		books, err = s.bookProvider.GetUserBooks(ctx, userID, filter)
+	}
 	if err != nil {
-		log.Error("failed to get user books", slog.String("error", err.Error()))
+		if !errors.Is(err, storage.ErrShelfNotFound) {
+			log.Error("failed to get user books", slog.String("error", err.Error()))
+		}
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -166,13 +675,17 @@ func (s *BookService) GetUserBooks(ctx context.Context, userID string, filter *m
 func (s *BookService) AddBookToUser(ctx context.Context, userID, bookID string) (string, error) {
 	const op = "BookService.AddBookToUser"
 
-	log := s.log.With(
+	log := logging.FromContext(ctx, s.log).With(
 		slog.String("op", op),
 		slog.String("user_id", userID))
 
-	savedBookID, err := s.bookSaver.AddBookToUser(ctx, userID, bookID)
+	savedBookID, err := s.bookSaver.AddBookToUser(ctx, userID, bookID, s.maxShelfSize)
 
 	if err != nil {
+		if errors.Is(err, storage.ErrShelfFull) {
+			log.Info("shelf quota exceeded")
+			return "", fmt.Errorf("%s: %w", op, ErrShelfQuotaExceeded)
+		}
 		log.Error("failed to add book to user", slog.String("error", err.Error()))
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
@@ -183,7 +696,7 @@ func (s *BookService) AddBookToUser(ctx context.Context, userID, bookID string)
 func (s *BookService) RemoveBookFromUser(ctx context.Context, userID, bookID string) (string, error) {
 	const op = "BookService.RemoveBookFromUser"
 
-	log := s.log.With(
+	log := logging.FromContext(ctx, s.log).With(
 		slog.String("op", op),
 		slog.String("user_id", userID))
 
@@ -0,0 +1,83 @@
+package bookService_test
+
+import (
+	"bookService/internal/domain/models"
+	"bookService/internal/services/bookService"
+	"bookService/internal/storage/memory"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// pausedReads holds back the result of a GetBook, once armed, until the
+// test lets it go: the book has been read by then, so a write made
+// meanwhile leaves the reader with the old version.
+type pausedReads struct {
+	*memory.Storage
+	armed   chan struct{}
+	read    chan struct{}
+	release chan struct{}
+}
+
+func (p *pausedReads) GetBook(ctx context.Context, id string) (*models.Book, error) {
+	book, err := p.Storage.GetBook(ctx, id)
+	select {
+	case <-p.armed:
+		close(p.read)
+		<-p.release
+	default:
+	}
+	return book, err
+}
+
+type noEvents struct{}
+
+func (noEvents) Publish(context.Context, models.BookEvent) error { return nil }
+
+func TestLoadDoesNotCacheOverAnUpdate(t *testing.T) {
+	ctx := context.Background()
+	books := &pausedReads{
+		Storage: memory.New(),
+		armed:   make(chan struct{}, 1),
+		read:    make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	cache := memory.NewCache(time.Minute, time.Minute)
+	s := bookService.New(books, books, cache, noEvents{}, nil, nil, nil, 0, 0, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	book, err := books.AddBook(ctx, &models.Book{Title: "Old", Author: "Kim Stanley"})
+	if err != nil {
+		t.Fatalf("AddBook: %v", err)
+	}
+
+	// A cache miss reads the old book and is held before caching it.
+	books.armed <- struct{}{}
+	loaded := make(chan *models.Book)
+	go func() {
+		got, err := s.GetBook(ctx, book.ID)
+		if err != nil {
+			t.Errorf("GetBook: %v", err)
+		}
+		loaded <- got
+	}()
+	<-books.read
+
+	title := "New"
+	if _, err := s.UpdateBook(ctx, bookService.BookUpdate{ID: book.ID, Title: &title}); err != nil {
+		t.Fatalf("UpdateBook: %v", err)
+	}
+	close(books.release)
+	if got := <-loaded; got == nil || got.Title != "Old" {
+		t.Fatalf("GetBook overlapping the update = %v, want the old book", got)
+	}
+
+	// The old book must not have been cached after the update.
+	if cached, err := cache.GetBook(ctx, "book:"+book.ID); err != nil || cached != nil {
+		t.Errorf("cached book = %v, %v, want nothing", cached, err)
+	}
+	if got, err := s.GetBook(ctx, book.ID); err != nil || got.Title != "New" {
+		t.Errorf("GetBook after the update = %v, %v, want the new title", got, err)
+	}
+}
@@ -0,0 +1,138 @@
+package bookService
+
+import (
+	"bookService/internal/domain/models"
+	"bookService/internal/logging"
+	"bookService/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+func (s *BookService) ListSeries(ctx context.Context) ([]*models.Series, error) {
+	const op = "BookService.ListSeries"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+	)
+
+	series, err := s.series.ListSeries(ctx)
+	if err != nil {
+		log.Error("failed to list series", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("listed series", slog.Int("count", len(series)))
+	return series, nil
+}
+
+func (s *BookService) GetSeries(ctx context.Context, id string) (*models.Series, error) {
+	const op = "BookService.GetSeries"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("id", id),
+	)
+
+	series, err := s.series.GetSeries(ctx, id)
+	if err != nil {
+		if !errors.Is(err, storage.ErrSeriesNotFound) {
+			log.Error("failed to get series", slog.String("error", err.Error()))
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return series, nil
+}
+
+// AddSeriesToUser shelves every volume of a series at once. Either all
+// missing volumes are added or, when the shelf quota would be exceeded,
+// none are.
+func (s *BookService) AddSeriesToUser(ctx context.Context, userID, seriesID string) ([]string, error) {
+	const op = "BookService.AddSeriesToUser"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+		slog.String("series_id", seriesID),
+	)
+
+	added, err := s.series.AddSeriesToUser(ctx, userID, seriesID, s.maxShelfSize)
+	if err != nil {
+		if errors.Is(err, storage.ErrShelfFull) {
+			log.Info("shelf quota exceeded")
+			return nil, fmt.Errorf("%s: %w", op, ErrShelfQuotaExceeded)
+		}
+		log.Error("failed to add series to user", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("series added to user", slog.Int("added", len(added)))
+	return added, nil
+}
+
+// SeriesProgress groups books by series and reports how many volumes of
+// each series they cover. Books outside any series are skipped.
+func (s *BookService) SeriesProgress(ctx context.Context, books []*models.Book) ([]*models.SeriesProgress, error) {
+	const op = "BookService.SeriesProgress"
+
+	var progress []*models.SeriesProgress
+	byID := make(map[string]*models.SeriesProgress)
+	for _, book := range books {
+		if book.SeriesID == "" {
+			continue
+		}
+		p, ok := byID[book.SeriesID]
+		if !ok {
+			p = &models.SeriesProgress{SeriesID: book.SeriesID, SeriesName: book.SeriesName}
+			byID[book.SeriesID] = p
+			progress = append(progress, p)
+		}
+		p.Owned++
+		p.BookIDs = append(p.BookIDs, book.ID)
+	}
+	if len(progress) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(progress))
+	for _, p := range progress {
+		ids = append(ids, p.SeriesID)
+	}
+	totals, err := s.series.CountSeriesBooks(ctx, ids)
+	if err != nil {
+		s.log.Error("failed to count series books", slog.String("op", op), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	for _, p := range progress {
+		p.Total = totals[p.SeriesID]
+		sortByReadingOrder(p, books)
+	}
+
+	return progress, nil
+}
+
+// sortByReadingOrder orders p.BookIDs by series number; unnumbered volumes
+// keep their relative order at the end.
+func sortByReadingOrder(p *models.SeriesProgress, books []*models.Book) {
+	numbers := make(map[string]int32, len(p.BookIDs))
+	for _, book := range books {
+		if book.SeriesID == p.SeriesID {
+			numbers[book.ID] = book.SeriesNumber
+		}
+	}
+	slices.SortStableFunc(p.BookIDs, func(a, b string) int {
+		na, nb := numbers[a], numbers[b]
+		switch {
+		case na == nb:
+			return 0
+		case na == 0:
+			return 1
+		case nb == 0:
+			return -1
+		}
+		return int(na - nb)
+	})
+}
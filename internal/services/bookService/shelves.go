@@ -0,0 +1,301 @@
+package bookService
+
+import (
+	"bookService/internal/domain/models"
+	"bookService/internal/logging"
+	"bookService/internal/storage"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+var (
+	ErrShelfLimitExceeded = errors.New("shelf limit exceeded")
+	ErrInvalidShelfName   = errors.New("invalid shelf name")
+)
+
+type ShelfStorage interface {
+	CreateShelf(ctx context.Context, shelf *models.Shelf, maxShelves int) (*models.Shelf, error)
+	GetShelf(ctx context.Context, id string) (*models.Shelf, error)
+	ListShelves(ctx context.Context, userID string) ([]*models.Shelf, error)
+	RenameShelf(ctx context.Context, userID, shelfID, name string) (*models.Shelf, error)
+	SetShelfVisibility(ctx context.Context, userID, shelfID, visibility, token string) (*models.Shelf, error)
+	DeleteShelf(ctx context.Context, userID, shelfID string) (string, error)
+	AddBookToShelf(ctx context.Context, userID, shelfID, bookID string, maxShelfSize int) (*models.Shelf, error)
+	RemoveBookFromShelf(ctx context.Context, userID, shelfID, bookID string) (*models.Shelf, error)
+	ReorderShelf(ctx context.Context, userID, shelfID string, bookIDs []string) (*models.Shelf, error)
+	GetShelfBooks(ctx context.Context, shelfID string, filter *models.BookFilter) ([]*models.Book, error)
+}
+
+func shelfName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: name is blank", ErrInvalidShelfName)
+	}
+	return name, nil
+}
+
+// shareToken returns the token for a shelf switched to visibility: a fresh
+// random one for link sharing and none otherwise.
+func shareToken(visibility string) (string, error) {
+	if visibility != models.ShelfLink {
+		return "", nil
+	}
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (s *BookService) CreateShelf(ctx context.Context, userID, name, visibility string) (*models.Shelf, error) {
+	const op = "BookService.CreateShelf"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+	)
+
+	name, err := shelfName(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	token, err := shareToken(visibility)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	shelf, err := s.shelves.CreateShelf(ctx, &models.Shelf{
+		UserID:     userID,
+		Name:       name,
+		Visibility: visibility,
+		ShareToken: token,
+	}, s.maxShelves)
+	if err != nil {
+		if errors.Is(err, storage.ErrShelfLimit) {
+			log.Info("shelf limit exceeded")
+			return nil, fmt.Errorf("%s: %w", op, ErrShelfLimitExceeded)
+		}
+		if !errors.Is(err, storage.ErrShelfExists) {
+			log.Error("failed to create shelf", slog.String("error", err.Error()))
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("shelf created", slog.String("shelf_id", shelf.ID))
+	return shelf, nil
+}
+
+func (s *BookService) RenameShelf(ctx context.Context, userID, shelfID, name string) (*models.Shelf, error) {
+	const op = "BookService.RenameShelf"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+		slog.String("shelf_id", shelfID),
+	)
+
+	name, err := shelfName(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	shelf, err := s.shelves.RenameShelf(ctx, userID, shelfID, name)
+	if err != nil {
+		if !errors.Is(err, storage.ErrShelfNotFound) && !errors.Is(err, storage.ErrShelfExists) {
+			log.Error("failed to rename shelf", slog.String("error", err.Error()))
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("shelf renamed")
+	return shelf, nil
+}
+
+func (s *BookService) SetShelfVisibility(ctx context.Context, userID, shelfID, visibility string) (*models.Shelf, error) {
+	const op = "BookService.SetShelfVisibility"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+		slog.String("shelf_id", shelfID),
+	)
+
+	token, err := shareToken(visibility)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	shelf, err := s.shelves.SetShelfVisibility(ctx, userID, shelfID, visibility, token)
+	if err != nil {
+		if !errors.Is(err, storage.ErrShelfNotFound) {
+			log.Error("failed to set shelf visibility", slog.String("error", err.Error()))
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("shelf visibility changed", slog.String("visibility", visibility))
+	return shelf, nil
+}
+
+func (s *BookService) DeleteShelf(ctx context.Context, userID, shelfID string) (string, error) {
+	const op = "BookService.DeleteShelf"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+		slog.String("shelf_id", shelfID),
+	)
+
+	id, err := s.shelves.DeleteShelf(ctx, userID, shelfID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrShelfNotFound) {
+			log.Error("failed to delete shelf", slog.String("error", err.Error()))
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("shelf deleted")
+	return id, nil
+}
+
+func (s *BookService) ListShelves(ctx context.Context, userID string) ([]*models.Shelf, error) {
+	const op = "BookService.ListShelves"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+	)
+
+	shelves, err := s.shelves.ListShelves(ctx, userID)
+	if err != nil {
+		log.Error("failed to list shelves", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("listed shelves", slog.Int("count", len(shelves)))
+	return shelves, nil
+}
+
+// AddBookToShelf appends the book to the shelf. Each shelf holds at most as
+// many books as the flat shelf of AddBookToUser.
+func (s *BookService) AddBookToShelf(ctx context.Context, userID, shelfID, bookID string) (*models.Shelf, error) {
+	const op = "BookService.AddBookToShelf"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+		slog.String("shelf_id", shelfID),
+		slog.String("book_id", bookID),
+	)
+
+	shelf, err := s.shelves.AddBookToShelf(ctx, userID, shelfID, bookID, s.maxShelfSize)
+	if err != nil {
+		if errors.Is(err, storage.ErrShelfFull) {
+			log.Info("shelf quota exceeded")
+			return nil, fmt.Errorf("%s: %w", op, ErrShelfQuotaExceeded)
+		}
+		if !errors.Is(err, storage.ErrShelfNotFound) && !errors.Is(err, storage.ErrBookNotFound) {
+			log.Error("failed to add book to shelf", slog.String("error", err.Error()))
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("book added to shelf")
+	return shelf, nil
+}
+
+func (s *BookService) RemoveBookFromShelf(ctx context.Context, userID, shelfID, bookID string) (*models.Shelf, error) {
+	const op = "BookService.RemoveBookFromShelf"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+		slog.String("shelf_id", shelfID),
+		slog.String("book_id", bookID),
+	)
+
+	shelf, err := s.shelves.RemoveBookFromShelf(ctx, userID, shelfID, bookID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrShelfNotFound) && !errors.Is(err, storage.ErrBookNotFound) {
+			log.Error("failed to remove book from shelf", slog.String("error", err.Error()))
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("book removed from shelf")
+	return shelf, nil
+}
+
+func (s *BookService) ReorderShelf(ctx context.Context, userID, shelfID string, bookIDs []string) (*models.Shelf, error) {
+	const op = "BookService.ReorderShelf"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+		slog.String("shelf_id", shelfID),
+	)
+
+	shelf, err := s.shelves.ReorderShelf(ctx, userID, shelfID, bookIDs)
+	if err != nil {
+		if !errors.Is(err, storage.ErrShelfNotFound) && !errors.Is(err, storage.ErrShelfMismatch) {
+			log.Error("failed to reorder shelf", slog.String("error", err.Error()))
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("shelf reordered")
+	return shelf, nil
+}
+
+// GetShelf returns a shelf that is public, or link-shared and token is its
+// share token, with its books. Any other shelf is reported as not found so
+// that private shelves cannot be probed for.
+func (s *BookService) GetShelf(ctx context.Context, shelfID, token string) (*models.Shelf, error) {
+	const op = "BookService.GetShelf"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("shelf_id", shelfID),
+	)
+
+	shelf, err := s.shelves.GetShelf(ctx, shelfID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrShelfNotFound) {
+			log.Error("failed to get shelf", slog.String("error", err.Error()))
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	visible := shelf.Visibility == models.ShelfPublic ||
+		shelf.Visibility == models.ShelfLink && token != "" &&
+			subtle.ConstantTimeCompare([]byte(token), []byte(shelf.ShareToken)) == 1
+	if !visible {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrShelfNotFound)
+	}
+
+	shelf.Books, err = s.shelves.GetShelfBooks(ctx, shelfID, nil)
+	if err != nil {
+		log.Error("failed to get shelf books", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	shelf.ShareToken = ""
+	return shelf, nil
+}
+
+// getOwnShelfBooks lists the books on a shelf of userID.
+func (s *BookService) getOwnShelfBooks(ctx context.Context, userID, shelfID string, filter *models.BookFilter) ([]*models.Book, error) {
+	shelf, err := s.shelves.GetShelf(ctx, shelfID)
+	if err != nil {
+		return nil, err
+	}
+	if shelf.UserID != userID {
+		return nil, storage.ErrShelfNotFound
+	}
+	return s.shelves.GetShelfBooks(ctx, shelfID, filter)
+}
@@ -0,0 +1,67 @@
+package bookService
+
+import (
+	"bookService/internal/domain/models"
+	"bookService/internal/storage"
+	"context"
+)
+
+// unsupported stands in for the series and shelves storage of a book
+// storage that has neither, such as the in-memory one.
+type unsupported struct{}
+
+func (unsupported) ListSeries(context.Context) ([]*models.Series, error) {
+	return nil, storage.ErrNotSupported
+}
+
+func (unsupported) GetSeries(context.Context, string) (*models.Series, error) {
+	return nil, storage.ErrNotSupported
+}
+
+func (unsupported) CountSeriesBooks(context.Context, []string) (map[string]int, error) {
+	return nil, storage.ErrNotSupported
+}
+
+func (unsupported) AddSeriesToUser(context.Context, string, string, int) ([]string, error) {
+	return nil, storage.ErrNotSupported
+}
+
+func (unsupported) CreateShelf(context.Context, *models.Shelf, int) (*models.Shelf, error) {
+	return nil, storage.ErrNotSupported
+}
+
+func (unsupported) GetShelf(context.Context, string) (*models.Shelf, error) {
+	return nil, storage.ErrNotSupported
+}
+
+func (unsupported) ListShelves(context.Context, string) ([]*models.Shelf, error) {
+	return nil, storage.ErrNotSupported
+}
+
+func (unsupported) RenameShelf(context.Context, string, string, string) (*models.Shelf, error) {
+	return nil, storage.ErrNotSupported
+}
+
+func (unsupported) SetShelfVisibility(context.Context, string, string, string, string) (*models.Shelf, error) {
+	return nil, storage.ErrNotSupported
+}
+
+func (unsupported) DeleteShelf(context.Context, string, string) (string, error) {
+	return "", storage.ErrNotSupported
+}
+
+func (unsupported) AddBookToShelf(context.Context, string, string, string, int) (*models.Shelf, error) {
+	return nil, storage.ErrNotSupported
+}
+
+func (unsupported) RemoveBookFromShelf(context.Context, string, string, string) (*models.Shelf, error) {
+	return nil, storage.ErrNotSupported
+}
+
+func (unsupported) ReorderShelf(context.Context, string, string, []string) (*models.Shelf, error) {
+	return nil, storage.ErrNotSupported
+}
+
+func (unsupported) GetShelfBooks(context.Context, string, *models.BookFilter) ([]*models.Book, error) {
+	return nil, storage.ErrNotSupported
+}
@@ -0,0 +1,252 @@
+package catalogService
+
+import (
+	"bookService/internal/catalog"
+	"bookService/internal/domain/models"
+	"bookService/internal/logging"
+	bookService "bookService/internal/services/bookService"
+	"bookService/internal/validation"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// BookWriter creates and updates books with the same normalization, genre
+// resolution and events as the AddBook and UpdateBook RPCs.
+type BookWriter interface {
+	PrepareBook(ctx context.Context, book *models.Book) error
+	AddBook(ctx context.Context, book *models.Book) (*models.Book, error)
+	UpdateBook(ctx context.Context, update bookService.BookUpdate) (*models.Book, error)
+}
+
+type BookStorage interface {
+	FindDuplicateCandidates(ctx context.Context, book *models.Book, words []string) ([]*models.Book, error)
+	ListBooks(ctx context.Context, filter *models.BookFilter) ([]*models.Book, error)
+}
+
+type CatalogService struct {
+	log       *slog.Logger
+	books     BookWriter
+	storage   BookStorage
+	mappings  catalog.Mappings
+	threshold float64
+}
+
+// New returns a CatalogService that takes an imported book for a duplicate
+// of an existing one when their titles and authors are at least threshold
+// similar.
+func New(books BookWriter, storage BookStorage, mappings catalog.Mappings, threshold float64, log *slog.Logger) *CatalogService {
+	return &CatalogService{
+		books:     books,
+		storage:   storage,
+		mappings:  mappings,
+		threshold: threshold,
+		log:       log,
+	}
+}
+
+// Import adds every record of a catalog file in format that is not already
+// in the catalog, and updates the ones that are if opts ask for it. Records
+// that cannot be read, fail validation or cannot be stored are reported and
+// skipped; the error is only set when the file itself is unreadable, in
+// which case the report covers the records before the failure.
+func (s *CatalogService) Import(ctx context.Context, format string, r io.Reader, opts models.ImportOptions) (*models.ImportReport, error) {
+	const op = "CatalogService.Import"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("format", format),
+		slog.Bool("dry_run", opts.DryRun),
+	)
+
+	mappings, err := s.mappings.WithColumns(opts.Columns)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	dec, err := catalog.NewDecoder(format, r, mappings)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	report := &models.ImportReport{DryRun: opts.DryRun}
+	// A dry run creates nothing, so the books it would create are kept to
+	// match later records of the same file against.
+	var pending []*models.Book
+	for index := 1; ; index++ {
+		if err := ctx.Err(); err != nil {
+			return report, fmt.Errorf("%s: %w", op, err)
+		}
+
+		book, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		var recordErr *catalog.RecordError
+		if err != nil && !errors.As(err, &recordErr) {
+			log.Warn("import aborted", slog.Int("record", index), slog.String("error", err.Error()))
+			return report, fmt.Errorf("%s: record %d: %w", op, index, err)
+		}
+		report.Processed = index
+		if index <= opts.Skip {
+			continue
+		}
+
+		if recordErr != nil {
+			report.Add(models.ImportRecord{Index: index, Status: models.ImportFailed, Error: recordErr.Error()})
+		} else {
+			record := s.importBook(ctx, index, book, opts, pending)
+			if opts.DryRun && record.Status == models.ImportCreated {
+				pending = append(pending, book)
+			}
+			report.Add(record)
+		}
+		if opts.Progress != nil {
+			opts.Progress(report)
+		}
+	}
+
+	log.Info("catalog imported",
+		slog.Int("created", report.Created),
+		slog.Int("updated", report.Updated),
+		slog.Int("skipped", report.Skipped),
+		slog.Int("failed", report.Failed),
+	)
+	return report, nil
+}
+
+func (s *CatalogService) importBook(ctx context.Context, index int, book *models.Book, opts models.ImportOptions, pending []*models.Book) models.ImportRecord {
+	record := models.ImportRecord{Index: index, Title: book.Title}
+	fail := func(err error) models.ImportRecord {
+		record.Status = models.ImportFailed
+		record.Error = err.Error()
+		return record
+	}
+
+	if err := validation.ValidateBook(book); err != nil {
+		return fail(err)
+	}
+	if err := s.books.PrepareBook(ctx, book); err != nil {
+		return fail(err)
+	}
+
+	duplicate, err := s.findDuplicate(ctx, book, pending)
+	if err != nil {
+		return fail(err)
+	}
+	if duplicate != nil {
+		record.BookID = duplicate.ID
+		if opts.OnDuplicate != models.DuplicateUpdate {
+			record.Status = models.ImportSkipped
+			return record
+		}
+		if !opts.DryRun {
+			if _, err := s.books.UpdateBook(ctx, bookUpdate(duplicate.ID, book)); err != nil {
+				return fail(err)
+			}
+		}
+		record.Status = models.ImportUpdated
+		return record
+	}
+
+	record.Status = models.ImportCreated
+	if opts.DryRun {
+		return record
+	}
+	created, err := s.books.AddBook(ctx, book)
+	if err != nil {
+		return fail(err)
+	}
+	record.BookID = created.ID
+	return record
+}
+
+// findDuplicate returns the stored or pending book that book duplicates
+// most closely, or nil if none is similar enough.
+func (s *CatalogService) findDuplicate(ctx context.Context, book *models.Book, pending []*models.Book) (*models.Book, error) {
+	candidates, err := s.storage.FindDuplicateCandidates(ctx, book, searchWords(book))
+	if err != nil {
+		return nil, err
+	}
+	candidates = append(candidates, pending...)
+
+	key := newMatchKey(book)
+	var (
+		best      *models.Book
+		bestScore float64
+	)
+	for _, candidate := range candidates {
+		score := key.similarity(newMatchKey(candidate))
+		if score >= s.threshold && score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+	return best, nil
+}
+
+// bookUpdate overwrites the fields an imported record sets and keeps the
+// others.
+func bookUpdate(id string, book *models.Book) bookService.BookUpdate {
+	update := bookService.BookUpdate{
+		ID:              id,
+		Title:           nonZero(book.Title),
+		Author:          nonZero(book.Author),
+		PublicationYear: nonZero(book.PublicationYear),
+		Genre:           nonZero(book.Genre),
+		Publisher:       nonZero(book.Publisher),
+		Language:        nonZero(book.Language),
+		Edition:         nonZero(book.Edition),
+		Description:     nonZero(book.Description),
+		SeriesName:      nonZero(book.SeriesName),
+		ISBN:            nonZero(book.ISBN),
+		PageCount:       nonZero(book.PageCount),
+		SeriesNumber:    nonZero(book.SeriesNumber),
+	}
+	if len(book.Subjects) > 0 {
+		update.Subjects = book.Subjects
+		update.SetSubjects = true
+	}
+	return update
+}
+
+func nonZero[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
+
+// Export writes the books matching filter to w in format and returns how
+// many were written.
+func (s *CatalogService) Export(ctx context.Context, format string, filter *models.BookFilter, w io.Writer) (int, error) {
+	const op = "CatalogService.Export"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("format", format),
+	)
+
+	enc, err := catalog.NewEncoder(format, w, s.mappings)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	books, err := s.storage.ListBooks(ctx, filter)
+	if err != nil {
+		log.Error("failed to list books", slog.String("error", err.Error()))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	for _, book := range books {
+		if err := enc.Encode(book); err != nil {
+			return 0, fmt.Errorf("%s: book %s: %w", op, book.ID, err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("catalog exported", slog.Int("count", len(books)))
+	return len(books), nil
+}
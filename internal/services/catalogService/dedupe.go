@@ -0,0 +1,114 @@
+package catalogService
+
+import (
+	"bookService/internal/domain/models"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// leadingArticles are dropped from the start of a title before comparing,
+// so "The Hobbit" matches "Hobbit".
+var leadingArticles = []string{"the", "a", "an", "le", "la", "les", "l", "der", "die", "das", "el", "los", "il"}
+
+// matchKey is the comparable form of a book's title and author.
+type matchKey struct {
+	isbn   string
+	title  string
+	author string
+}
+
+func newMatchKey(book *models.Book) matchKey {
+	title := words(book.Title)
+	if len(title) > 1 && slices.Contains(leadingArticles, title[0]) {
+		title = title[1:]
+	}
+	// Word order of names varies between "Tolkien, J. R. R." and
+	// "J. R. R. Tolkien"; sorted, both read "j r r tolkien".
+	author := words(book.Author)
+	slices.Sort(author)
+
+	return matchKey{
+		isbn:   book.ISBN,
+		title:  strings.Join(title, " "),
+		author: strings.Join(author, " "),
+	}
+}
+
+// similarity scores how alike two keys are, from 0 to 1. Books that both
+// carry an ISBN match exactly when the ISBNs do, so two editions of a title
+// are kept apart.
+func (k matchKey) similarity(other matchKey) float64 {
+	if k.isbn != "" && other.isbn != "" {
+		if k.isbn == other.isbn {
+			return 1
+		}
+		return 0
+	}
+	return min(ratio(k.title, other.title), ratio(k.author, other.author))
+}
+
+// searchWords picks the longest word of title and of author to look up
+// candidates by.
+func searchWords(book *models.Book) []string {
+	var picked []string
+	for _, s := range []string{book.Title, book.Author} {
+		longest := ""
+		for _, w := range words(s) {
+			if len([]rune(w)) > len([]rune(longest)) {
+				longest = w
+			}
+		}
+		if len([]rune(longest)) >= 3 {
+			picked = append(picked, longest)
+		}
+	}
+	return picked
+}
+
+var stripMarks = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// words lowercases s, strips diacritics and splits it on anything that is
+// not a letter or digit.
+func words(s string) []string {
+	if folded, _, err := transform.String(stripMarks, s); err == nil {
+		s = folded
+	}
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// ratio is 1 minus the edit distance of a and b relative to the longer one.
+func ratio(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
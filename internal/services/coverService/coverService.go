@@ -0,0 +1,181 @@
+package coverService
+
+import (
+	"bookService/internal/blobstore"
+	"bookService/internal/domain/models"
+	"bookService/internal/logging"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+var (
+	ErrCoverTooLarge    = errors.New("cover exceeds the size limit")
+	ErrUnsupportedCover = errors.New("unsupported cover image")
+	ErrNoCover          = errors.New("book has no cover")
+)
+
+// coverTypes maps the accepted sniffed content types to the key extension.
+var coverTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+type BookStorage interface {
+	GetBook(ctx context.Context, id string) (*models.Book, error)
+	SetBookCover(ctx context.Context, cover *models.Cover) (*models.Cover, error)
+}
+
+type BookCache interface {
+	InvalidateBook(ctx context.Context, key string) error
+}
+
+type CoverService struct {
+	log           *slog.Logger
+	store         blobstore.BlobStore
+	books         BookStorage
+	bookCache     BookCache
+	maxSize       int64
+	thumbnailSize int
+}
+
+func New(
+	store blobstore.BlobStore,
+	books BookStorage,
+	bookCache BookCache,
+	maxSize int64,
+	thumbnailSize int,
+	log *slog.Logger,
+) *CoverService {
+	return &CoverService{
+		store:         store,
+		books:         books,
+		bookCache:     bookCache,
+		maxSize:       maxSize,
+		thumbnailSize: thumbnailSize,
+		log:           log,
+	}
+}
+
+// UploadCover reads an image from r, stores it with a generated thumbnail
+// and makes it the book's cover. The previous cover blobs are removed.
+func (s *CoverService) UploadCover(ctx context.Context, bookID string, r io.Reader) (*models.Cover, error) {
+	const op = "CoverService.UploadCover"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("book_id", bookID),
+	)
+
+	if _, err := s.books.GetBook(ctx, bookID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, s.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if int64(len(data)) > s.maxSize {
+		return nil, fmt.Errorf("%s: %w", op, ErrCoverTooLarge)
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := coverTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%s: %s: %w", op, contentType, ErrUnsupportedCover)
+	}
+	thumb, err := thumbnail(data, s.thumbnailSize)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %v", op, ErrUnsupportedCover, err)
+	}
+
+	// Content-addressed keys change with the image, so cached copies of an
+	// old cover are never served for a new one.
+	sum := sha256.Sum256(data)
+	name := hex.EncodeToString(sum[:8])
+	cover := &models.Cover{
+		BookID:       bookID,
+		Key:          fmt.Sprintf("covers/%s/%s%s", bookID, name, ext),
+		ThumbnailKey: fmt.Sprintf("covers/%s/%s-thumb.jpg", bookID, name),
+		ContentType:  contentType,
+		Size:         int64(len(data)),
+	}
+
+	if err := s.store.Put(ctx, cover.Key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		log.Error("failed to store cover", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.store.Put(ctx, cover.ThumbnailKey, bytes.NewReader(thumb), int64(len(thumb)), "image/jpeg"); err != nil {
+		log.Error("failed to store thumbnail", slog.String("error", err.Error()))
+		s.deleteBlobs(ctx, log, cover.Key)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	previous, err := s.books.SetBookCover(ctx, cover)
+	if err != nil {
+		log.Error("failed to set book cover", slog.String("error", err.Error()))
+		s.deleteBlobs(ctx, log, cover.Key, cover.ThumbnailKey)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if previous.Key != cover.Key {
+		s.deleteBlobs(ctx, log, previous.Key, previous.ThumbnailKey)
+	}
+
+	cacheKey := fmt.Sprintf("book:%s", bookID)
+	if err := s.bookCache.InvalidateBook(ctx, cacheKey); err != nil {
+		log.Warn("failed to invalidate cache", slog.String("error", err.Error()))
+	}
+
+	log.Info("cover uploaded", slog.String("content_type", contentType), slog.Int64("size", cover.Size))
+	return cover, nil
+}
+
+// GetCover opens the book's cover, or its thumbnail. The caller must close
+// the returned reader.
+func (s *CoverService) GetCover(ctx context.Context, bookID string, thumbnail bool) (io.ReadCloser, blobstore.Info, error) {
+	const op = "CoverService.GetCover"
+
+	book, err := s.books.GetBook(ctx, bookID)
+	if err != nil {
+		return nil, blobstore.Info{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	key := book.CoverKey
+	if thumbnail {
+		key = book.ThumbnailKey
+	}
+	if key == "" {
+		return nil, blobstore.Info{}, fmt.Errorf("%s: %w", op, ErrNoCover)
+	}
+
+	rc, info, err := s.store.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return nil, blobstore.Info{}, fmt.Errorf("%s: %w", op, ErrNoCover)
+		}
+		s.log.Error("failed to open cover", slog.String("op", op), slog.String("key", key), slog.String("error", err.Error()))
+		return nil, blobstore.Info{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return rc, info, nil
+}
+
+// deleteBlobs removes blobs on a best-effort basis; an orphaned blob is
+// preferable to failing the request.
+func (s *CoverService) deleteBlobs(ctx context.Context, log *slog.Logger, keys ...string) {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if err := s.store.Delete(ctx, key); err != nil {
+			log.Warn("failed to delete blob", slog.String("key", key), slog.String("error", err.Error()))
+		}
+	}
+}
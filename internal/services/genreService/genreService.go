@@ -0,0 +1,189 @@
+package genreService
+
+import (
+	"bookService/internal/domain/models"
+	"bookService/internal/logging"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+type GenreStorage interface {
+	CreateGenre(ctx context.Context, genre *models.Genre) (*models.Genre, error)
+	GetGenre(ctx context.Context, id string) (*models.Genre, error)
+	UpdateGenre(ctx context.Context, genre *models.Genre) (*models.Genre, []string, error)
+	DeleteGenre(ctx context.Context, id string) (string, []string, error)
+	ListGenres(ctx context.Context) ([]*models.Genre, error)
+	SetBookGenres(ctx context.Context, bookID string, genreIDs []string) ([]string, error)
+}
+
+type BookCache interface {
+	InvalidateBook(ctx context.Context, key string) error
+	InvalidateFacets(ctx context.Context) error
+}
+
+// GenreUpdate lists the fields to change; nil fields are left as they are.
+type GenreUpdate struct {
+	ID          string
+	Name        *string
+	ParentID    *string
+	ClearParent bool
+	Aliases     []string
+	SetAliases  bool
+}
+
+type GenreService struct {
+	log       *slog.Logger
+	storage   GenreStorage
+	bookCache BookCache
+}
+
+func New(storage GenreStorage, bookCache BookCache, log *slog.Logger) *GenreService {
+	return &GenreService{
+		storage:   storage,
+		bookCache: bookCache,
+		log:       log,
+	}
+}
+
+func (s *GenreService) CreateGenre(ctx context.Context, genre *models.Genre) (*models.Genre, error) {
+	const op = "GenreService.CreateGenre"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("name", genre.Name),
+	)
+
+	genre.Name = strings.TrimSpace(genre.Name)
+	genre.Aliases = normalizeAliases(genre.Aliases)
+
+	created, err := s.storage.CreateGenre(ctx, genre)
+	if err != nil {
+		log.Error("failed to create genre", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("genre created", slog.String("id", created.ID))
+	return created, nil
+}
+
+func (s *GenreService) UpdateGenre(ctx context.Context, update GenreUpdate) (*models.Genre, error) {
+	const op = "GenreService.UpdateGenre"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("id", update.ID),
+	)
+
+	genre, err := s.storage.GetGenre(ctx, update.ID)
+	if err != nil {
+		log.Error("failed to get genre", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if update.Name != nil {
+		genre.Name = strings.TrimSpace(*update.Name)
+	}
+	if update.ClearParent {
+		genre.ParentID = nil
+	} else if update.ParentID != nil {
+		genre.ParentID = update.ParentID
+	}
+	if update.SetAliases {
+		genre.Aliases = normalizeAliases(update.Aliases)
+	}
+
+	updated, books, err := s.storage.UpdateGenre(ctx, genre)
+	if err != nil {
+		log.Error("failed to update genre", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidateBooks(ctx, log, books)
+	s.invalidateFacets(ctx, log)
+	log.Info("genre updated")
+	return updated, nil
+}
+
+func (s *GenreService) DeleteGenre(ctx context.Context, id string) (string, error) {
+	const op = "GenreService.DeleteGenre"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("id", id),
+	)
+
+	id, books, err := s.storage.DeleteGenre(ctx, id)
+	if err != nil {
+		log.Error("failed to delete genre", slog.String("error", err.Error()))
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidateBooks(ctx, log, books)
+	s.invalidateFacets(ctx, log)
+	log.Info("genre deleted")
+	return id, nil
+}
+
+func (s *GenreService) ListGenres(ctx context.Context) ([]*models.Genre, error) {
+	const op = "GenreService.ListGenres"
+
+	log := logging.FromContext(ctx, s.log).With(slog.String("op", op))
+
+	genres, err := s.storage.ListGenres(ctx)
+	if err != nil {
+		log.Error("failed to list genres", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return genres, nil
+}
+
+func (s *GenreService) SetBookGenres(ctx context.Context, bookID string, genreIDs []string) ([]string, error) {
+	const op = "GenreService.SetBookGenres"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("book_id", bookID),
+	)
+
+	genres, err := s.storage.SetBookGenres(ctx, bookID, genreIDs)
+	if err != nil {
+		log.Error("failed to set book genres", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidateBooks(ctx, log, []string{bookID})
+	s.invalidateFacets(ctx, log)
+
+	log.Info("book genres updated", slog.Int("count", len(genres)))
+	return genres, nil
+}
+
+// invalidateBooks drops the cached copies of books whose genres changed.
+func (s *GenreService) invalidateBooks(ctx context.Context, log *slog.Logger, bookIDs []string) {
+	for _, id := range bookIDs {
+		if err := s.bookCache.InvalidateBook(ctx, fmt.Sprintf("book:%s", id)); err != nil {
+			log.Warn("failed to invalidate cache", slog.String("book_id", id), slog.String("error", err.Error()))
+		}
+	}
+}
+
+// invalidateFacets drops cached facet counts, which are keyed by genre name.
+func (s *GenreService) invalidateFacets(ctx context.Context, log *slog.Logger) {
+	if err := s.bookCache.InvalidateFacets(ctx); err != nil {
+		log.Warn("failed to invalidate facets cache", slog.String("error", err.Error()))
+	}
+}
+
+func normalizeAliases(aliases []string) []string {
+	normalized := make([]string, 0, len(aliases))
+	for _, a := range aliases {
+		a = strings.ToLower(strings.TrimSpace(a))
+		if a != "" && !slices.Contains(normalized, a) {
+			normalized = append(normalized, a)
+		}
+	}
+	return normalized
+}
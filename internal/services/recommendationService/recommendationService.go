@@ -0,0 +1,207 @@
+package recommendationService
+
+import (
+	"bookService/internal/domain/models"
+	"bookService/internal/logging"
+	"bookService/internal/storage"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const (
+	defaultLimit = 10
+	// profileSize is how many of a reader's most shelved authors and genres
+	// drive the fallback recommendations.
+	profileSize = 3
+)
+
+type RecommendationStorage interface {
+	GetBook(ctx context.Context, id string) (*models.Book, error)
+	GetUserBooks(ctx context.Context, userID string, filter *models.BookFilter) ([]*models.Book, error)
+	SimilarBooks(ctx context.Context, bookID string, limit int) ([]*models.Recommendation, error)
+	UserRecommendations(ctx context.Context, userID string, limit int) ([]*models.Recommendation, error)
+	BooksLike(ctx context.Context, authors, genres, exclude []string, limit int) ([]*models.Book, error)
+}
+
+type RecommendationService struct {
+	log     *slog.Logger
+	storage RecommendationStorage
+}
+
+func New(storage RecommendationStorage, log *slog.Logger) *RecommendationService {
+	return &RecommendationService{
+		storage: storage,
+		log:     log,
+	}
+}
+
+// GetSimilarBooks returns the books most often shelved together with the
+// book. Books that are too new or too rarely shelved to have co-shelving
+// scores are completed with books by the same author or in the same genres,
+// then with the most shelved books.
+func (s *RecommendationService) GetSimilarBooks(ctx context.Context, bookID string, limit int) ([]*models.Recommendation, error) {
+	const op = "RecommendationService.GetSimilarBooks"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("book_id", bookID),
+	)
+
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	book, err := s.storage.GetBook(ctx, bookID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrBookNotFound) {
+			log.Error("failed to get book", slog.String("error", err.Error()))
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	recs, err := s.storage.SimilarBooks(ctx, bookID, limit)
+	if err != nil {
+		log.Error("failed to get similar books", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	genres := book.Genres
+	if book.Genre != "" {
+		genres = append([]string{book.Genre}, genres...)
+	}
+	recs, err = s.fill(ctx, recs, limit, []string{book.Author}, genres, []string{book.ID})
+	if err != nil {
+		log.Error("failed to complete similar books", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("similar books found", slog.Int("count", len(recs)))
+	return recs, nil
+}
+
+// GetRecommendationsForUser returns books similar to those on the user's
+// shelf, leaving out the shelved ones. When co-shelving scores run short it
+// falls back to the authors and genres the user shelves most, then to the
+// most shelved books.
+func (s *RecommendationService) GetRecommendationsForUser(ctx context.Context, userID string, limit int) ([]*models.Recommendation, error) {
+	const op = "RecommendationService.GetRecommendationsForUser"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+	)
+
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	recs, err := s.storage.UserRecommendations(ctx, userID, limit)
+	if err != nil {
+		log.Error("failed to get user recommendations", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(recs) < limit {
+		shelf, err := s.storage.GetUserBooks(ctx, userID, nil)
+		if err != nil {
+			log.Error("failed to get user books", slog.String("error", err.Error()))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		var authors, genres []string
+		shelved := make([]string, 0, len(shelf))
+		for _, book := range shelf {
+			shelved = append(shelved, book.ID)
+			authors = append(authors, book.Author)
+			if book.Genre != "" {
+				genres = append(genres, book.Genre)
+			}
+			genres = append(genres, book.Genres...)
+		}
+
+		recs, err = s.fill(ctx, recs, limit, mostCommon(authors, profileSize), mostCommon(genres, profileSize), shelved)
+		if err != nil {
+			log.Error("failed to complete user recommendations", slog.String("error", err.Error()))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	log.Debug("recommendations found", slog.Int("count", len(recs)))
+	return recs, nil
+}
+
+// fill tops recs up to limit, first with books by authors or in genres and
+// then with the most shelved books. Books in exclude or already in recs are
+// never added. Fallback recommendations carry no score.
+func (s *RecommendationService) fill(
+	ctx context.Context,
+	recs []*models.Recommendation,
+	limit int,
+	authors, genres, exclude []string,
+) ([]*models.Recommendation, error) {
+	if len(recs) >= limit {
+		return recs, nil
+	}
+
+	exclude = slices.Clone(exclude)
+	for _, r := range recs {
+		exclude = append(exclude, r.Book.ID)
+	}
+	authors = lower(authors)
+	genres = lower(genres)
+
+	if len(authors) > 0 || len(genres) > 0 {
+		books, err := s.storage.BooksLike(ctx, authors, genres, exclude, limit-len(recs))
+		if err != nil {
+			return nil, err
+		}
+		for _, book := range books {
+			reason := models.ReasonSameGenre
+			if slices.Contains(authors, strings.ToLower(book.Author)) {
+				reason = models.ReasonSameAuthor
+			}
+			recs = append(recs, &models.Recommendation{Book: book, Reason: reason})
+			exclude = append(exclude, book.ID)
+		}
+	}
+
+	if len(recs) < limit {
+		books, err := s.storage.BooksLike(ctx, nil, nil, exclude, limit-len(recs))
+		if err != nil {
+			return nil, err
+		}
+		for _, book := range books {
+			recs = append(recs, &models.Recommendation{Book: book, Reason: models.ReasonPopular})
+		}
+	}
+	return recs, nil
+}
+
+func lower(values []string) []string {
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// mostCommon returns up to n of the values, compared ignoring case, that
+// occur most often.
+func mostCommon(values []string, n int) []string {
+	counts := make(map[string]int)
+	for _, v := range lower(values) {
+		counts[v]++
+	}
+	keys := slices.SortedFunc(maps.Keys(counts), func(a, b string) int {
+		return cmp.Or(counts[b]-counts[a], strings.Compare(a, b))
+	})
+	return keys[:min(n, len(keys))]
+}
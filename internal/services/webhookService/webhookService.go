@@ -0,0 +1,144 @@
+package webhookService
+
+import (
+	"bookService/internal/domain/models"
+	"bookService/internal/logging"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+)
+
+var (
+	ErrInvalidURL       = errors.New("webhook url must be an absolute http or https url")
+	ErrInvalidEventType = errors.New("unknown event type")
+	ErrNoEventTypes     = errors.New("at least one event type is required")
+)
+
+var knownEvents = []string{
+	models.EventBookCreated,
+	models.EventBookUpdated,
+	models.EventBookDeleted,
+}
+
+const defaultDeliveryLimit = 100
+
+type WebhookStorage interface {
+	CreateWebhook(ctx context.Context, sub *models.WebhookSubscription) (*models.WebhookSubscription, error)
+	ListWebhooks(ctx context.Context) ([]*models.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, id string) (string, error)
+	ListWebhookDeliveries(ctx context.Context, subscriptionID, status string, limit int) ([]*models.WebhookDelivery, error)
+}
+
+type WebhookService struct {
+	log     *slog.Logger
+	storage WebhookStorage
+}
+
+func New(storage WebhookStorage, log *slog.Logger) *WebhookService {
+	return &WebhookService{
+		storage: storage,
+		log:     log,
+	}
+}
+
+func (s *WebhookService) CreateWebhook(ctx context.Context, sub *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	const op = "WebhookService.CreateWebhook"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("url", sub.URL),
+	)
+
+	u, err := url.Parse(sub.URL)
+	if err != nil || !u.IsAbs() || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidURL)
+	}
+	if len(sub.EventTypes) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrNoEventTypes)
+	}
+	for _, eventType := range sub.EventTypes {
+		if !slices.Contains(knownEvents, eventType) {
+			return nil, fmt.Errorf("%s: %w: %s", op, ErrInvalidEventType, eventType)
+		}
+	}
+	if sub.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		sub.Secret = secret
+	}
+
+	created, err := s.storage.CreateWebhook(ctx, sub)
+	if err != nil {
+		log.Error("failed to create webhook", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("webhook created", slog.String("id", created.ID))
+	return created, nil
+}
+
+func (s *WebhookService) ListWebhooks(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	const op = "WebhookService.ListWebhooks"
+
+	log := logging.FromContext(ctx, s.log).With(slog.String("op", op))
+
+	subs, err := s.storage.ListWebhooks(ctx)
+	if err != nil {
+		log.Error("failed to list webhooks", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return subs, nil
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id string) (string, error) {
+	const op = "WebhookService.DeleteWebhook"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("id", id),
+	)
+
+	id, err := s.storage.DeleteWebhook(ctx, id)
+	if err != nil {
+		log.Error("failed to delete webhook", slog.String("error", err.Error()))
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("webhook deleted")
+	return id, nil
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, subscriptionID, status string, limit int) ([]*models.WebhookDelivery, error) {
+	const op = "WebhookService.ListDeliveries"
+
+	log := logging.FromContext(ctx, s.log).With(
+		slog.String("op", op),
+		slog.String("subscription_id", subscriptionID),
+	)
+
+	if limit <= 0 || limit > defaultDeliveryLimit {
+		limit = defaultDeliveryLimit
+	}
+
+	deliveries, err := s.storage.ListWebhookDeliveries(ctx, subscriptionID, status, limit)
+	if err != nil {
+		log.Error("failed to list deliveries", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return deliveries, nil
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,318 @@
+package caching
+
+import (
+	"bookService/config"
+	"bookService/internal/domain/models"
+	"bookService/internal/metrics"
+	"bookService/internal/storage"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type state int
+
+const (
+	closed state = iota
+	halfOpen
+	open
+)
+
+func (s state) String() string {
+	switch s {
+	case halfOpen:
+		return "half-open"
+	case open:
+		return "open"
+	}
+	return "closed"
+}
+
+// maxPending bounds the invalidations kept while the cache is unreachable.
+const maxPending = 4096
+
+// Breaker stops calling a cache after cfg.Failures errors in a row, so that
+// requests do not wait on a cache that is down. While open, lookups miss
+// and writes are dropped; after cfg.Cooldown one call probes the cache and
+// closes the breaker if it succeeds.
+//
+// Invalidations that could not be made are replayed before the cache is
+// used again, so that it does not serve data changed in the meantime. When
+// more than maxPending pile up, entries may stay stale until their TTL.
+type Breaker struct {
+	log      *slog.Logger
+	name     string
+	next     BookCache
+	failures int
+	cooldown time.Duration
+
+	mu       sync.Mutex
+	state    state
+	errors   int
+	openedAt time.Time
+
+	pendingBooks  map[string]struct{}
+	pendingFacets bool
+}
+
+// NewBreaker wraps next, reported as name in logs and metrics.
+func NewBreaker(log *slog.Logger, name string, next BookCache, cfg config.BreakerConfig) *Breaker {
+	b := &Breaker{
+		log:          log.With(slog.String("cache", name)),
+		name:         name,
+		next:         next,
+		failures:     max(cfg.Failures, 1),
+		cooldown:     cfg.Cooldown,
+		pendingBooks: make(map[string]struct{}),
+	}
+	metrics.CacheBreakerState.WithLabelValues(name).Set(float64(closed))
+	// Export the counters at zero, so that the first trip shows up as an
+	// increase rather than as a new series.
+	metrics.CacheBreakerRejectedTotal.WithLabelValues(name)
+	for _, s := range []state{closed, halfOpen, open} {
+		metrics.CacheBreakerTransitionsTotal.WithLabelValues(name, s.String())
+	}
+	return b
+}
+
+// Open trips the breaker, for a cache known to be down, e.g. at startup.
+func (b *Breaker) Open() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.setState(open)
+}
+
+func (b *Breaker) GetBook(ctx context.Context, key string) (*models.Book, error) {
+	var (
+		book    *models.Book
+		missing bool
+	)
+	_, err := b.call(ctx, func() (err error) {
+		book, err = b.next.GetBook(ctx, key)
+		// A book known to be missing is an answer, not a failure.
+		if errors.Is(err, storage.ErrBookNotFound) {
+			missing, err = true, nil
+		}
+		return err
+	})
+	if missing {
+		return nil, storage.ErrBookNotFound
+	}
+	return book, err
+}
+
+func (b *Breaker) SetBook(ctx context.Context, key string, book *models.Book) error {
+	_, err := b.call(ctx, func() error {
+		return b.next.SetBook(ctx, key, book)
+	})
+	return err
+}
+
+func (b *Breaker) SetBookNotFound(ctx context.Context, key string) error {
+	_, err := b.call(ctx, func() error {
+		return b.next.SetBookNotFound(ctx, key)
+	})
+	return err
+}
+
+func (b *Breaker) InvalidateBook(ctx context.Context, key string) error {
+	ran, err := b.call(ctx, func() error {
+		return b.next.InvalidateBook(ctx, key)
+	})
+	if !ran || err != nil {
+		b.rememberBooks(key)
+	}
+	return err
+}
+
+func (b *Breaker) FacetsGeneration(ctx context.Context) (int64, error) {
+	var gen int64
+	_, err := b.call(ctx, func() (err error) {
+		gen, err = b.next.FacetsGeneration(ctx)
+		return err
+	})
+	return gen, err
+}
+
+func (b *Breaker) GetFacets(ctx context.Context, key string) (*models.BookFacets, error) {
+	var facets *models.BookFacets
+	_, err := b.call(ctx, func() (err error) {
+		facets, err = b.next.GetFacets(ctx, key)
+		return err
+	})
+	return facets, err
+}
+
+func (b *Breaker) SetFacets(ctx context.Context, key string, facets *models.BookFacets) error {
+	_, err := b.call(ctx, func() error {
+		return b.next.SetFacets(ctx, key, facets)
+	})
+	return err
+}
+
+func (b *Breaker) InvalidateFacets(ctx context.Context) error {
+	ran, err := b.call(ctx, func() error {
+		return b.next.InvalidateFacets(ctx)
+	})
+	if !ran || err != nil {
+		b.rememberFacets()
+	}
+	return err
+}
+
+// Bus returns bus with its publishes going through the breaker, for a bus
+// served by the same cache: while it is down they are dropped rather than
+// each waiting for the timeout. The other replicas lose their subscription
+// then too and empty their local tier once they listen again.
+func (b *Breaker) Bus(bus Bus) Bus {
+	return breakerBus{Bus: bus, breaker: b}
+}
+
+type breakerBus struct {
+	Bus
+	breaker *Breaker
+}
+
+func (bb breakerBus) Publish(ctx context.Context, key string) error {
+	_, err := bb.breaker.call(ctx, func() error {
+		return bb.Bus.Publish(ctx, key)
+	})
+	return err
+}
+
+// call runs fn unless the breaker is open and reports whether it did. A
+// skipped call is no error: to the caller the cache just has nothing.
+func (b *Breaker) call(ctx context.Context, fn func() error) (bool, error) {
+	if !b.allow() {
+		metrics.CacheBreakerRejectedTotal.WithLabelValues(b.name).Inc()
+		return false, nil
+	}
+	if err := b.replay(ctx); err != nil {
+		b.record(ctx, err)
+		return false, nil
+	}
+	err := fn()
+	b.record(ctx, err)
+	return true, err
+}
+
+// allow reports whether a call may go to the cache. Once the cooldown is
+// over, the first caller gets through as the probe and the others wait
+// for its outcome.
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case closed:
+		return true
+	case open:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.setState(halfOpen)
+		return true
+	}
+	return false
+}
+
+func (b *Breaker) record(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case err == nil:
+		b.errors = 0
+		if b.state != closed {
+			b.log.Info("cache is back")
+			b.setState(closed)
+		}
+	case ctx.Err() != nil:
+		// The caller gave up; that says nothing about the cache. An
+		// unfinished probe is retried by the next call.
+		if b.state == halfOpen {
+			b.setState(open)
+			b.openedAt = time.Time{}
+		}
+	default:
+		b.errors++
+		if b.state == halfOpen || b.errors >= b.failures {
+			if b.state == closed {
+				b.log.Warn("cache is failing, skipping it", slog.String("error", err.Error()))
+			}
+			b.setState(open)
+		}
+	}
+}
+
+// setState must be called with mu held.
+func (b *Breaker) setState(s state) {
+	if s == open {
+		b.openedAt = time.Now()
+	}
+	if b.state == s {
+		return
+	}
+	b.state = s
+	metrics.CacheBreakerState.WithLabelValues(b.name).Set(float64(s))
+	metrics.CacheBreakerTransitionsTotal.WithLabelValues(b.name, s.String()).Inc()
+}
+
+// replay makes the invalidations that were missed, keeping the ones that
+// fail for later.
+func (b *Breaker) replay(ctx context.Context) error {
+	b.mu.Lock()
+	if len(b.pendingBooks) == 0 && !b.pendingFacets {
+		b.mu.Unlock()
+		return nil
+	}
+	keys := make([]string, 0, len(b.pendingBooks))
+	for key := range b.pendingBooks {
+		keys = append(keys, key)
+	}
+	facets := b.pendingFacets
+	clear(b.pendingBooks)
+	b.pendingFacets = false
+	b.mu.Unlock()
+
+	for i, key := range keys {
+		if err := b.next.InvalidateBook(ctx, key); err != nil {
+			b.rememberBooks(keys[i:]...)
+			if facets {
+				b.rememberFacets()
+			}
+			return err
+		}
+	}
+	if facets {
+		if err := b.next.InvalidateFacets(ctx); err != nil {
+			b.rememberFacets()
+			return err
+		}
+	}
+	b.log.Info("replayed missed invalidations", slog.Int("books", len(keys)), slog.Bool("facets", facets))
+	return nil
+}
+
+func (b *Breaker) rememberBooks(keys ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, key := range keys {
+		if len(b.pendingBooks) >= maxPending {
+			b.log.Warn("too many missed invalidations, some books may be stale until they expire")
+			return
+		}
+		b.pendingBooks[key] = struct{}{}
+	}
+}
+
+func (b *Breaker) rememberFacets() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.pendingFacets = true
+}
@@ -0,0 +1,213 @@
+package caching_test
+
+import (
+	"bookService/config"
+	"bookService/internal/domain/models"
+	"bookService/internal/metrics"
+	"bookService/internal/services/bookService"
+	"bookService/internal/storage"
+	"bookService/internal/storage/caching"
+	"bookService/internal/storage/memory"
+	"bookService/internal/storage/storagetest"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestBreakerCache(t *testing.T) {
+	storagetest.RunBookCache(t, func(t *testing.T) bookService.BookCache {
+		return caching.NewBreaker(discard, "test", memory.NewCache(time.Minute, time.Minute), config.BreakerConfig{Failures: 1, Cooldown: time.Minute})
+	})
+}
+
+// flaky fails every call while down and counts the calls it gets.
+type flaky struct {
+	caching.BookCache
+	down  bool
+	calls int
+}
+
+func (f *flaky) do() error {
+	f.calls++
+	if f.down {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (f *flaky) GetBook(ctx context.Context, key string) (*models.Book, error) {
+	if err := f.do(); err != nil {
+		return nil, err
+	}
+	return f.BookCache.GetBook(ctx, key)
+}
+
+func (f *flaky) InvalidateBook(ctx context.Context, key string) error {
+	if err := f.do(); err != nil {
+		return err
+	}
+	return f.BookCache.InvalidateBook(ctx, key)
+}
+
+func TestBreaker(t *testing.T) {
+	ctx := context.Background()
+	cache := &flaky{BookCache: memory.NewCache(time.Minute, time.Minute)}
+	b := caching.NewBreaker(discard, "test", cache, config.BreakerConfig{Failures: 2, Cooldown: 20 * time.Millisecond})
+
+	if err := cache.SetBook(ctx, "book:1", &models.Book{ID: "1", Title: "Old"}); err != nil {
+		t.Fatalf("SetBook: %v", err)
+	}
+
+	cache.down = true
+	for i := 0; i < 2; i++ {
+		if _, err := b.GetBook(ctx, "book:1"); err == nil {
+			t.Fatalf("GetBook %d succeeded while the cache is down", i)
+		}
+	}
+	// Open: the cache is not called, lookups miss and invalidations wait.
+	calls := cache.calls
+	if book, err := b.GetBook(ctx, "book:1"); book != nil || err != nil {
+		t.Errorf("GetBook while open = %v, %v, want nil, nil", book, err)
+	}
+	if err := b.InvalidateBook(ctx, "book:1"); err != nil {
+		t.Errorf("InvalidateBook while open: %v", err)
+	}
+	if cache.calls != calls {
+		t.Errorf("the cache got %d calls while open", cache.calls-calls)
+	}
+
+	// A failed probe keeps it open for another cooldown.
+	time.Sleep(30 * time.Millisecond)
+	if book, err := b.GetBook(ctx, "book:1"); book != nil || err != nil {
+		t.Errorf("GetBook of the probe = %v, %v, want nil, nil", book, err)
+	}
+	if cache.calls != calls+1 {
+		t.Errorf("the probe made %d calls, want 1", cache.calls-calls)
+	}
+	if _, err := b.GetBook(ctx, "book:1"); err != nil || cache.calls != calls+1 {
+		t.Errorf("GetBook after a failed probe = %v with %d calls", err, cache.calls-calls)
+	}
+
+	// Once the cache is back, the missed invalidation is made before the
+	// stale book could be read.
+	cache.down = false
+	time.Sleep(30 * time.Millisecond)
+	if book, err := b.GetBook(ctx, "book:1"); book != nil || err != nil {
+		t.Errorf("GetBook after recovery = %v, %v, want the stale book gone", book, err)
+	}
+	if err := b.SetBook(ctx, "book:1", &models.Book{ID: "1", Title: "New"}); err != nil {
+		t.Fatalf("SetBook: %v", err)
+	}
+	if book, err := b.GetBook(ctx, "book:1"); err != nil || book == nil || book.Title != "New" {
+		t.Errorf("GetBook when closed = %v, %v", book, err)
+	}
+}
+
+func TestBreakerIgnoresCancelledCalls(t *testing.T) {
+	cache := &flaky{BookCache: memory.NewCache(time.Minute, time.Minute), down: true}
+	b := caching.NewBreaker(discard, "test", cache, config.BreakerConfig{Failures: 1, Cooldown: time.Minute})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _ = b.GetBook(ctx, "book:1")
+	_, _ = b.GetBook(ctx, "book:1")
+	if cache.calls != 2 {
+		t.Errorf("the cache got %d calls, want 2: cancelled calls must not trip the breaker", cache.calls)
+	}
+}
+
+func TestBreakerPassesMissingBooks(t *testing.T) {
+	ctx := context.Background()
+	cache := &flaky{BookCache: memory.NewCache(time.Minute, time.Minute)}
+	b := caching.NewBreaker(discard, "test", cache, config.BreakerConfig{Failures: 1, Cooldown: time.Minute})
+
+	if err := b.SetBookNotFound(ctx, "book:1"); err != nil {
+		t.Fatalf("SetBookNotFound: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := b.GetBook(ctx, "book:1"); !errors.Is(err, storage.ErrBookNotFound) {
+			t.Fatalf("GetBook %d = %v, want %v", i, err, storage.ErrBookNotFound)
+		}
+	}
+	// Both lookups reached the cache: a missing book did not trip the breaker.
+	if cache.calls != 2 {
+		t.Errorf("the cache got %d calls, want 2", cache.calls)
+	}
+}
+
+func TestBreakerMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(metrics.CacheBreakerState, metrics.CacheBreakerTransitionsTotal, metrics.CacheBreakerRejectedTotal)
+	scrape := func() string {
+		rec := httptest.NewRecorder()
+		promhttp.HandlerFor(reg, promhttp.HandlerOpts{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		return rec.Body.String()
+	}
+
+	b := caching.NewBreaker(discard, "scraped", memory.NewCache(time.Minute, time.Minute), config.BreakerConfig{Failures: 1, Cooldown: time.Minute})
+	// The series are there before the breaker ever trips.
+	for _, want := range []string{
+		`cache_breaker_state{cache="scraped"} 0`,
+		`cache_breaker_transitions_total{cache="scraped",state="open"} 0`,
+		`cache_breaker_rejected_total{cache="scraped"} 0`,
+	} {
+		if body := scrape(); !strings.Contains(body, want) {
+			t.Errorf("scrape lacks %s", want)
+		}
+	}
+
+	b.Open()
+	if _, err := b.GetBook(context.Background(), "book:1"); err != nil {
+		t.Fatalf("GetBook: %v", err)
+	}
+	for _, want := range []string{
+		`cache_breaker_state{cache="scraped"} 2`,
+		`cache_breaker_transitions_total{cache="scraped",state="open"} 1`,
+		`cache_breaker_rejected_total{cache="scraped"} 1`,
+	} {
+		if body := scrape(); !strings.Contains(body, want) {
+			t.Errorf("scrape after tripping lacks %s", want)
+		}
+	}
+}
+
+// countingBus counts publishes and fails them while down.
+type countingBus struct {
+	bus
+	down      bool
+	published int
+}
+
+func (c *countingBus) Publish(ctx context.Context, key string) error {
+	c.published++
+	if c.down {
+		return errors.New("connection refused")
+	}
+	return c.bus.Publish(ctx, key)
+}
+
+func TestBreakerBusSkipsPublishesWhileOpen(t *testing.T) {
+	ctx := context.Background()
+	b := caching.NewBreaker(discard, "test", memory.NewCache(time.Minute, time.Minute), config.BreakerConfig{Failures: 1, Cooldown: time.Minute})
+	inner := &countingBus{down: true}
+	c := caching.NewTiered(discard, "test", b, b.Bus(inner), localConfig)
+
+	// The first failing publish trips the breaker; later ones are not tried.
+	for range 3 {
+		_ = c.InvalidateBook(ctx, "book:1")
+	}
+	if inner.published != 1 {
+		t.Errorf("%d publishes reached the bus, want 1", inner.published)
+	}
+}
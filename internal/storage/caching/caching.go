@@ -0,0 +1,33 @@
+// Package caching holds BookCache implementations that wrap or stand in for
+// a real cache: a circuit breaker that keeps a failing cache out of the
+// request path, an in-process tier in front of a shared cache, and a cache
+// that caches nothing.
+package caching
+
+import (
+	"bookService/internal/domain/models"
+	"context"
+)
+
+type BookCache interface {
+	GetBook(ctx context.Context, key string) (*models.Book, error)
+	SetBook(ctx context.Context, key string, book *models.Book) error
+	SetBookNotFound(ctx context.Context, key string) error
+	InvalidateBook(ctx context.Context, key string) error
+	FacetsGeneration(ctx context.Context) (int64, error)
+	GetFacets(ctx context.Context, key string) (*models.BookFacets, error)
+	SetFacets(ctx context.Context, key string, facets *models.BookFacets) error
+	InvalidateFacets(ctx context.Context) error
+}
+
+// Noop caches nothing: every lookup misses.
+type Noop struct{}
+
+func (Noop) GetBook(context.Context, string) (*models.Book, error)         { return nil, nil }
+func (Noop) SetBook(context.Context, string, *models.Book) error           { return nil }
+func (Noop) SetBookNotFound(context.Context, string) error                 { return nil }
+func (Noop) InvalidateBook(context.Context, string) error                  { return nil }
+func (Noop) FacetsGeneration(context.Context) (int64, error)               { return 0, nil }
+func (Noop) GetFacets(context.Context, string) (*models.BookFacets, error) { return nil, nil }
+func (Noop) SetFacets(context.Context, string, *models.BookFacets) error   { return nil }
+func (Noop) InvalidateFacets(context.Context) error                        { return nil }
@@ -0,0 +1,127 @@
+package caching
+
+import (
+	"bookService/internal/domain/models"
+	"container/list"
+	"sync"
+	"time"
+)
+
+// lru holds up to size books, dropping the least recently used first.
+//
+// Every removal bumps gen. A lookup that read the next tier before a
+// removal passes the gen it started with to set, which then does nothing:
+// the book it carries may be the one that was just invalidated.
+type lru struct {
+	size  int
+	ttl   time.Duration
+	stale time.Duration
+
+	mu    sync.Mutex
+	items map[string]*list.Element
+	order *list.List
+	gen   uint64
+}
+
+type lruEntry struct {
+	key string
+	// book is nil for a book known not to exist.
+	book    *models.Book
+	freshTo time.Time
+	staleTo time.Time
+}
+
+func newLRU(size int, ttl, stale time.Duration) *lru {
+	return &lru{
+		size:  max(size, 1),
+		ttl:   ttl,
+		stale: stale,
+		items: make(map[string]*list.Element),
+		order: list.New(),
+	}
+}
+
+// get returns a copy of the book under key, nil if it is known not to
+// exist, and whether that is still fresh.
+func (l *lru) get(key string) (book *models.Book, fresh, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	el, ok := l.items[key]
+	if !ok {
+		return nil, false, false
+	}
+	e := el.Value.(*lruEntry)
+	now := time.Now()
+	if now.After(e.staleTo) {
+		l.order.Remove(el)
+		delete(l.items, key)
+		return nil, false, false
+	}
+	l.order.MoveToFront(el)
+	return cloneBook(e.book), now.Before(e.freshTo), true
+}
+
+// generation returns the gen to pass to set.
+func (l *lru) generation() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.gen
+}
+
+// set stores a copy of book, or nil for a missing book, unless something
+// was removed since gen.
+func (l *lru) set(key string, book *models.Book, gen uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if gen != l.gen {
+		return
+	}
+	now := time.Now()
+	e := &lruEntry{
+		key:     key,
+		book:    cloneBook(book),
+		freshTo: now.Add(l.ttl),
+		staleTo: now.Add(l.ttl + l.stale),
+	}
+	if el, ok := l.items[key]; ok {
+		el.Value = e
+		l.order.MoveToFront(el)
+		return
+	}
+	l.items[key] = l.order.PushFront(e)
+	for l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+func (l *lru) remove(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.gen++
+	if el, ok := l.items[key]; ok {
+		l.order.Remove(el)
+		delete(l.items, key)
+	}
+}
+
+func (l *lru) clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.gen++
+	clear(l.items)
+	l.order.Init()
+}
+
+func cloneBook(book *models.Book) *models.Book {
+	if book == nil {
+		return nil
+	}
+	return book.Clone()
+}
@@ -0,0 +1,226 @@
+package caching
+
+import (
+	"bookService/config"
+	"bookService/internal/domain/models"
+	"bookService/internal/metrics"
+	"bookService/internal/storage"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Bus spreads invalidations to every replica.
+type Bus interface {
+	Publish(ctx context.Context, key string) error
+	// Subscribe calls onKey for every published key until ctx is done or it
+	// fails, and onReset whenever keys may have been missed.
+	Subscribe(ctx context.Context, onKey func(key string), onReset func()) error
+}
+
+const (
+	// revalidateTimeout bounds the background refresh of a stale book.
+	revalidateTimeout = time.Second
+	// resubscribeDelay is the pause before subscribing again after a failure.
+	resubscribeDelay = time.Second
+)
+
+// Tiered keeps hot books in process in front of a shared cache, so that
+// most lookups need no round-trip. A book past its TTL is still served for
+// a while and refreshed from the shared cache in the background.
+//
+// Invalidations are published on the bus and drop the book on every
+// replica; a replica that may have missed some, e.g. while Redis was down,
+// empties its local tier once it listens again. Facets are cached in the
+// shared cache only.
+type Tiered struct {
+	log    *slog.Logger
+	name   string
+	remote BookCache
+	bus    Bus
+	local  *lru
+
+	mu         sync.Mutex
+	refreshing map[string]struct{}
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewTiered puts a local tier sized by cfg in front of remote, reported as
+// name in metrics. bus may be nil for a single replica.
+func NewTiered(log *slog.Logger, name string, remote BookCache, bus Bus, cfg config.LocalCacheConfig) *Tiered {
+	return &Tiered{
+		log:        log.With(slog.String("cache", name)),
+		name:       name,
+		remote:     remote,
+		bus:        bus,
+		local:      newLRU(cfg.Size, cfg.TTL, cfg.StaleTTL),
+		refreshing: make(map[string]struct{}),
+	}
+}
+
+// Start listens for invalidations made by other replicas.
+func (t *Tiered) Start() {
+	if t.bus == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		t.subscribe(ctx)
+	}()
+}
+
+func (t *Tiered) Stop() {
+	if t.cancel == nil {
+		return
+	}
+	t.cancel()
+	t.wg.Wait()
+}
+
+func (t *Tiered) subscribe(ctx context.Context) {
+	failing := false
+	onReset := func() {
+		// Whatever was published while we were not listening is lost.
+		t.local.clear()
+		if failing {
+			t.log.Info("listening for invalidations again")
+			failing = false
+		}
+	}
+	for {
+		err := t.bus.Subscribe(ctx, t.local.remove, onReset)
+		if ctx.Err() != nil {
+			return
+		}
+		if !failing {
+			t.log.Warn("lost invalidations from other replicas, retrying", slog.String("error", err.Error()))
+			failing = true
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resubscribeDelay):
+		}
+	}
+}
+
+func (t *Tiered) GetBook(ctx context.Context, key string) (*models.Book, error) {
+	book, fresh, ok := t.local.get(key)
+	switch {
+	case ok && fresh:
+		metrics.CacheRequestsTotal.WithLabelValues("local", "hit").Inc()
+		return found(book)
+	case ok:
+		metrics.CacheRequestsTotal.WithLabelValues("local", "stale").Inc()
+		t.revalidate(ctx, key)
+		return found(book)
+	}
+	metrics.CacheRequestsTotal.WithLabelValues("local", "miss").Inc()
+
+	gen := t.local.generation()
+	book, err := t.remote.GetBook(ctx, key)
+	switch {
+	case errors.Is(err, storage.ErrBookNotFound):
+		metrics.CacheRequestsTotal.WithLabelValues(t.name, "hit").Inc()
+		t.local.set(key, nil, gen)
+		return nil, err
+	case err != nil:
+		return nil, err
+	case book == nil:
+		metrics.CacheRequestsTotal.WithLabelValues(t.name, "miss").Inc()
+		return nil, nil
+	}
+	metrics.CacheRequestsTotal.WithLabelValues(t.name, "hit").Inc()
+	t.local.set(key, book, gen)
+	return book, nil
+}
+
+// found turns a local entry into what GetBook returns.
+func found(book *models.Book) (*models.Book, error) {
+	if book == nil {
+		return nil, storage.ErrBookNotFound
+	}
+	return book, nil
+}
+
+// revalidate refreshes key from the shared cache in the background, once
+// at a time per key.
+func (t *Tiered) revalidate(ctx context.Context, key string) {
+	t.mu.Lock()
+	if _, ok := t.refreshing[key]; ok {
+		t.mu.Unlock()
+		return
+	}
+	t.refreshing[key] = struct{}{}
+	t.mu.Unlock()
+
+	gen := t.local.generation()
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), revalidateTimeout)
+	go func() {
+		defer cancel()
+		defer func() {
+			t.mu.Lock()
+			delete(t.refreshing, key)
+			t.mu.Unlock()
+		}()
+
+		book, err := t.remote.GetBook(ctx, key)
+		switch {
+		case errors.Is(err, storage.ErrBookNotFound):
+			metrics.CacheRequestsTotal.WithLabelValues(t.name, "hit").Inc()
+			t.local.set(key, nil, gen)
+		case err != nil:
+			// Keep serving the stale book until it runs out.
+			t.log.Debug("failed to revalidate a cached book", slog.String("key", key), slog.String("error", err.Error()))
+		case book == nil:
+			metrics.CacheRequestsTotal.WithLabelValues(t.name, "miss").Inc()
+			t.local.remove(key)
+		default:
+			metrics.CacheRequestsTotal.WithLabelValues(t.name, "hit").Inc()
+			t.local.set(key, book, gen)
+		}
+	}()
+}
+
+func (t *Tiered) SetBook(ctx context.Context, key string, book *models.Book) error {
+	t.local.set(key, book, t.local.generation())
+	return t.remote.SetBook(ctx, key, book)
+}
+
+func (t *Tiered) SetBookNotFound(ctx context.Context, key string) error {
+	t.local.set(key, nil, t.local.generation())
+	return t.remote.SetBookNotFound(ctx, key)
+}
+
+func (t *Tiered) InvalidateBook(ctx context.Context, key string) error {
+	t.local.remove(key)
+	err := t.remote.InvalidateBook(ctx, key)
+	if t.bus != nil {
+		err = errors.Join(err, t.bus.Publish(ctx, key))
+	}
+	return err
+}
+
+func (t *Tiered) FacetsGeneration(ctx context.Context) (int64, error) {
+	return t.remote.FacetsGeneration(ctx)
+}
+
+func (t *Tiered) GetFacets(ctx context.Context, key string) (*models.BookFacets, error) {
+	return t.remote.GetFacets(ctx, key)
+}
+
+func (t *Tiered) SetFacets(ctx context.Context, key string, facets *models.BookFacets) error {
+	return t.remote.SetFacets(ctx, key, facets)
+}
+
+func (t *Tiered) InvalidateFacets(ctx context.Context) error {
+	return t.remote.InvalidateFacets(ctx)
+}
@@ -0,0 +1,156 @@
+package caching_test
+
+import (
+	"bookService/config"
+	"bookService/internal/domain/models"
+	"bookService/internal/services/bookService"
+	"bookService/internal/storage/caching"
+	"bookService/internal/storage/memory"
+	"bookService/internal/storage/storagetest"
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// bus delivers published keys to every subscriber, as Redis pub/sub does.
+type bus struct {
+	mu   sync.Mutex
+	subs []chan string
+}
+
+func (b *bus) Publish(_ context.Context, key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, sub := range b.subs {
+		sub <- key
+	}
+	return nil
+}
+
+func (b *bus) Subscribe(ctx context.Context, onKey func(string), onReset func()) error {
+	sub := make(chan string, 16)
+	b.mu.Lock()
+	b.subs = append(b.subs, sub)
+	b.mu.Unlock()
+	onReset()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case key := <-sub:
+			onKey(key)
+		}
+	}
+}
+
+func (b *bus) subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.subs)
+}
+
+var localConfig = config.LocalCacheConfig{Enabled: true, Size: 100, TTL: time.Minute, StaleTTL: time.Minute}
+
+func TestTieredCache(t *testing.T) {
+	storagetest.RunBookCache(t, func(t *testing.T) bookService.BookCache {
+		return caching.NewTiered(discard, "test", memory.NewCache(time.Minute, time.Minute), nil, localConfig)
+	})
+}
+
+func TestTieredInvalidatesOtherReplicas(t *testing.T) {
+	ctx := context.Background()
+	remote, b := memory.NewCache(time.Minute, time.Minute), &bus{}
+	first := caching.NewTiered(discard, "test", remote, b, localConfig)
+	second := caching.NewTiered(discard, "test", remote, b, localConfig)
+	for _, c := range []*caching.Tiered{first, second} {
+		c.Start()
+		t.Cleanup(c.Stop)
+	}
+	waitFor(t, func() bool { return b.subscribers() == 2 })
+
+	if err := first.SetBook(ctx, "book:1", &models.Book{ID: "1", Title: "Old"}); err != nil {
+		t.Fatalf("SetBook: %v", err)
+	}
+	// The second replica now holds the book locally.
+	if book, err := second.GetBook(ctx, "book:1"); err != nil || book == nil {
+		t.Fatalf("GetBook = %v, %v", book, err)
+	}
+	// Change it behind the local tier's back, then invalidate on the first.
+	if err := remote.SetBook(ctx, "book:1", &models.Book{ID: "1", Title: "New"}); err != nil {
+		t.Fatalf("SetBook: %v", err)
+	}
+	if err := first.InvalidateBook(ctx, "book:1"); err != nil {
+		t.Fatalf("InvalidateBook: %v", err)
+	}
+	waitFor(t, func() bool {
+		book, err := second.GetBook(ctx, "book:1")
+		return err == nil && book == nil
+	})
+}
+
+func TestTieredServesStaleWhileRevalidating(t *testing.T) {
+	ctx := context.Background()
+	remote := memory.NewCache(time.Minute, time.Minute)
+	c := caching.NewTiered(discard, "test", remote, nil, config.LocalCacheConfig{Size: 10, TTL: 10 * time.Millisecond, StaleTTL: time.Minute})
+
+	if err := c.SetBook(ctx, "book:1", &models.Book{ID: "1", Title: "Old"}); err != nil {
+		t.Fatalf("SetBook: %v", err)
+	}
+	if err := remote.SetBook(ctx, "book:1", &models.Book{ID: "1", Title: "New"}); err != nil {
+		t.Fatalf("SetBook: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	// The stale book comes back at once and the refresh lands afterwards.
+	if book, err := c.GetBook(ctx, "book:1"); err != nil || book == nil || book.Title != "Old" {
+		t.Fatalf("GetBook of a stale book = %v, %v, want the old title", book, err)
+	}
+	waitFor(t, func() bool {
+		book, err := c.GetBook(ctx, "book:1")
+		return err == nil && book != nil && book.Title == "New"
+	})
+}
+
+func TestTieredEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	remote := memory.NewCache(time.Minute, time.Minute)
+	c := caching.NewTiered(discard, "test", remote, nil, config.LocalCacheConfig{Size: 2, TTL: time.Minute, StaleTTL: time.Minute})
+
+	for _, key := range []string{"book:1", "book:2"} {
+		if err := c.SetBook(ctx, key, &models.Book{ID: key}); err != nil {
+			t.Fatalf("SetBook: %v", err)
+		}
+	}
+	// Touch book:1 so that book:2 is the one to go.
+	if _, err := c.GetBook(ctx, "book:1"); err != nil {
+		t.Fatalf("GetBook: %v", err)
+	}
+	if err := c.SetBook(ctx, "book:3", &models.Book{ID: "book:3"}); err != nil {
+		t.Fatalf("SetBook: %v", err)
+	}
+	// Only the local tier forgets: drop the shared copies to see it.
+	for _, key := range []string{"book:1", "book:2", "book:3"} {
+		if err := remote.InvalidateBook(ctx, key); err != nil {
+			t.Fatalf("InvalidateBook: %v", err)
+		}
+	}
+	for key, want := range map[string]bool{"book:1": true, "book:2": false, "book:3": true} {
+		if book, err := c.GetBook(ctx, key); err != nil || (book != nil) != want {
+			t.Errorf("GetBook(%q) = %v, %v, want cached %v", key, book, err, want)
+		}
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if cond() {
+			return
+		}
+	}
+	t.Fatal("condition not met within a second")
+}
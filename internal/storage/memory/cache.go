@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"bookService/internal/domain/models"
+	"bookService/internal/storage"
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+type entry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+func (e entry[T]) expired(now time.Time) bool {
+	return !e.expires.IsZero() && now.After(e.expires)
+}
+
+// Cache holds books and facets for ttl, or until invalidated when ttl is 0,
+// and books that do not exist for notFoundTTL. Expired entries are dropped
+// when they are next looked up.
+type Cache struct {
+	ttl         time.Duration
+	notFoundTTL time.Duration
+
+	mu        sync.Mutex
+	books     map[string]entry[*models.Book]
+	facets    map[string]entry[*models.BookFacets]
+	facetsGen int64
+}
+
+// NewCache returns a cache that does not remember missing books when
+// notFoundTTL is 0.
+func NewCache(ttl, notFoundTTL time.Duration) *Cache {
+	return &Cache{
+		ttl:         ttl,
+		notFoundTTL: notFoundTTL,
+		books:       make(map[string]entry[*models.Book]),
+		facets:      make(map[string]entry[*models.BookFacets]),
+	}
+}
+
+func expiry(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
+
+func (c *Cache) GetBook(ctx context.Context, key string) (*models.Book, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.books[key]
+	if !ok {
+		return nil, nil
+	}
+	if e.expired(time.Now()) {
+		delete(c.books, key)
+		return nil, nil
+	}
+	if e.value == nil {
+		return nil, storage.ErrBookNotFound
+	}
+	return cloneBook(e.value), nil
+}
+
+func (c *Cache) SetBook(ctx context.Context, key string, book *models.Book) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.books[key] = entry[*models.Book]{value: cloneBook(book), expires: expiry(c.ttl)}
+	return nil
+}
+
+func (c *Cache) SetBookNotFound(ctx context.Context, key string) error {
+	if c.notFoundTTL <= 0 {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.books[key] = entry[*models.Book]{expires: expiry(c.notFoundTTL)}
+	return nil
+}
+
+func (c *Cache) InvalidateBook(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.books, key)
+	return nil
+}
+
+func (c *Cache) FacetsGeneration(ctx context.Context) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.facetsGen, nil
+}
+
+func (c *Cache) GetFacets(ctx context.Context, key string) (*models.BookFacets, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.facets[key]
+	if !ok {
+		return nil, nil
+	}
+	if e.expired(time.Now()) {
+		delete(c.facets, key)
+		return nil, nil
+	}
+	return cloneFacets(e.value), nil
+}
+
+func (c *Cache) SetFacets(ctx context.Context, key string, facets *models.BookFacets) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.facets[key] = entry[*models.BookFacets]{value: cloneFacets(facets), expires: expiry(c.ttl)}
+	return nil
+}
+
+func (c *Cache) InvalidateFacets(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.facetsGen++
+	clear(c.facets)
+	return nil
+}
+
+func cloneFacets(facets *models.BookFacets) *models.BookFacets {
+	return &models.BookFacets{
+		Genres:  slices.Clone(facets.Genres),
+		Authors: slices.Clone(facets.Authors),
+		Decades: slices.Clone(facets.Decades),
+	}
+}
@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"bookService/internal/domain/models"
+	"cmp"
+	"slices"
+	"strings"
+)
+
+func matches(book *models.Book, filter *models.BookFilter) bool {
+	if filter == nil {
+		return true
+	}
+
+	authors := filter.Authors
+	if filter.Author != nil {
+		authors = append([]string{*filter.Author}, authors...)
+	}
+	if len(authors) > 0 && !slices.Contains(authors, book.Author) {
+		return false
+	}
+
+	if filter.PublicationYear != nil && book.PublicationYear != *filter.PublicationYear {
+		return false
+	}
+	if filter.YearFrom != nil && book.PublicationYear < *filter.YearFrom {
+		return false
+	}
+	if filter.YearTo != nil && book.PublicationYear > *filter.YearTo {
+		return false
+	}
+
+	genres := filter.Genres
+	if filter.Genre != nil {
+		genres = append([]string{*filter.Genre}, genres...)
+	}
+	if len(genres) > 0 && !slices.ContainsFunc(book.Genres, func(g string) bool {
+		return slices.ContainsFunc(genres, func(want string) bool { return strings.EqualFold(g, want) })
+	}) {
+		return false
+	}
+
+	if filter.Language != nil && *filter.Language != "" {
+		lang, want := strings.ToLower(book.Language), strings.ToLower(*filter.Language)
+		if lang != want && !strings.HasPrefix(lang, want+"-") {
+			return false
+		}
+	}
+	if filter.Series != nil && *filter.Series != "" && !strings.EqualFold(book.SeriesName, *filter.Series) {
+		return false
+	}
+	if filter.TitlePrefix != nil && *filter.TitlePrefix != "" &&
+		!strings.HasPrefix(strings.ToLower(book.Title), strings.ToLower(*filter.TitlePrefix)) {
+		return false
+	}
+	return true
+}
+
+// sortBooks orders books as filter asks, by title when it does not say.
+// Books without a rating come last either way, and book ids break ties.
+func sortBooks(books []*models.Book, filter *models.BookFilter) {
+	sortBy, desc := models.SortByTitle, false
+	if filter != nil {
+		switch filter.SortBy {
+		case models.SortByTitle, models.SortByAuthor, models.SortByPublicationYear,
+			models.SortByCreatedAt, models.SortByRating:
+			sortBy = filter.SortBy
+		}
+		desc = filter.SortDesc
+	}
+
+	slices.SortFunc(books, func(a, b *models.Book) int {
+		var c int
+		switch sortBy {
+		case models.SortByTitle:
+			c = strings.Compare(a.Title, b.Title)
+		case models.SortByAuthor:
+			c = strings.Compare(a.Author, b.Author)
+		case models.SortByPublicationYear:
+			c = cmp.Compare(a.PublicationYear, b.PublicationYear)
+		case models.SortByCreatedAt:
+			c = a.CreatedAt.Compare(b.CreatedAt)
+		case models.SortByRating:
+			switch {
+			case a.Rating == nil && b.Rating == nil:
+			case a.Rating == nil:
+				return 1
+			case b.Rating == nil:
+				return -1
+			default:
+				c = cmp.Compare(*a.Rating, *b.Rating)
+			}
+		}
+		if desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+}
@@ -0,0 +1,334 @@
+// Package memory keeps books and cached data in process memory, for tests
+// and for running the service locally without Postgres or Redis. It follows
+// the semantics of the Postgres and Redis implementations, except that
+// genre filters and facets only see the genres books were tagged with, by
+// name, without the taxonomy's hierarchy or aliases.
+package memory
+
+import (
+	"bookService/internal/domain/models"
+	"bookService/internal/storage"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type series struct {
+	id   string
+	name string
+}
+
+type Storage struct {
+	mu     sync.RWMutex
+	books  map[string]*models.Book
+	series map[string]series
+	// shelves holds the ids of every user's books.
+	shelves map[string]map[string]struct{}
+}
+
+func New() *Storage {
+	return &Storage{
+		books:   make(map[string]*models.Book),
+		series:  make(map[string]series),
+		shelves: make(map[string]map[string]struct{}),
+	}
+}
+
+func (s *Storage) GetBook(ctx context.Context, id string) (*models.Book, error) {
+	const op = "memory.GetBook"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	book, ok := s.books[id]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+	}
+	return cloneBook(book), nil
+}
+
+func (s *Storage) ListBooks(ctx context.Context, filter *models.BookFilter) ([]*models.Book, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var books []*models.Book
+	for _, book := range s.books {
+		if matches(book, filter) {
+			books = append(books, cloneBook(book))
+		}
+	}
+	sortBooks(books, filter)
+	return books, nil
+}
+
+func (s *Storage) GetUserBooks(ctx context.Context, userID string, filter *models.BookFilter) ([]*models.Book, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var books []*models.Book
+	for id := range s.shelves[userID] {
+		if book := s.books[id]; matches(book, filter) {
+			books = append(books, cloneBook(book))
+		}
+	}
+	sortBooks(books, filter)
+	return books, nil
+}
+
+func (s *Storage) CountUserBooks(ctx context.Context, userID string) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.shelves[userID]), nil
+}
+
+func (s *Storage) AddBook(ctx context.Context, book *models.Book) (*models.Book, error) {
+	const op = "memory.AddBook"
+
+	added, err := s.AddBooks(ctx, []*models.Book{book}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return added[0], nil
+}
+
+// AddBooks adds every book or, when one of them conflicts with a stored
+// book or another of books, none. There is no genre taxonomy to tag the
+// books with, so genreIDs are ignored.
+func (s *Storage) AddBooks(ctx context.Context, books []*models.Book, genreIDs []string) ([]*models.Book, error) {
+	const op = "memory.AddBooks"
+
+	if len(books) == 0 {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make(map[string]bool, len(books))
+	isbns := make(map[string]bool, len(books))
+	for _, book := range books {
+		if book.ID == "" {
+			book.ID = uuid.New().String()
+		}
+		if _, ok := s.books[book.ID]; ok || ids[book.ID] {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrBookExists)
+		}
+		if book.ISBN != "" && (isbns[book.ISBN] || s.isbnTaken(book.ISBN, "")) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrBookExists)
+		}
+		ids[book.ID], isbns[book.ISBN] = true, true
+	}
+
+	// Like now() in Postgres, the time the whole batch was added.
+	now := time.Now()
+	added := make([]*models.Book, 0, len(books))
+	for _, book := range books {
+		stored := cloneBook(book)
+		stored.CreatedAt = now
+		stored.Genres = nil
+		stored.CoverKey, stored.ThumbnailKey = "", ""
+		s.setSeries(stored)
+		s.books[stored.ID] = stored
+		added = append(added, cloneBook(stored))
+	}
+	return added, nil
+}
+
+func (s *Storage) UpdateBook(ctx context.Context, id string, update func(book *models.Book) error) (*models.Book, error) {
+	const op = "memory.UpdateBook"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.books[id]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+	}
+	book := cloneBook(current)
+	if err := update(book); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if book.ISBN != "" && s.isbnTaken(book.ISBN, id) {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrBookExists)
+	}
+
+	// Covers, tags and the creation time are not the caller's to change.
+	updated := cloneBook(book)
+	updated.ID = id
+	updated.CreatedAt = current.CreatedAt
+	updated.Genres = current.Genres
+	updated.CoverKey, updated.ThumbnailKey = current.CoverKey, current.ThumbnailKey
+	s.setSeries(updated)
+	s.books[id] = updated
+	return cloneBook(updated), nil
+}
+
+func (s *Storage) DeleteBook(ctx context.Context, id string) (string, error) {
+	const op = "memory.DeleteBook"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.books[id]; !ok {
+		return "", fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+	}
+	delete(s.books, id)
+	for _, shelf := range s.shelves {
+		delete(shelf, id)
+	}
+	return id, nil
+}
+
+func (s *Storage) AddBookToUser(ctx context.Context, userID, bookID string, maxShelfSize int) (string, error) {
+	const op = "memory.AddBookToUser"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.books[bookID]; !ok {
+		return "", fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+	}
+	shelf, ok := s.shelves[userID]
+	if !ok {
+		shelf = make(map[string]struct{})
+		s.shelves[userID] = shelf
+	}
+	if _, ok := shelf[bookID]; ok {
+		return bookID, nil
+	}
+	if maxShelfSize > 0 && len(shelf) >= maxShelfSize {
+		return "", fmt.Errorf("%s: %w", op, storage.ErrShelfFull)
+	}
+	shelf[bookID] = struct{}{}
+	return bookID, nil
+}
+
+func (s *Storage) RemoveBookFromUser(ctx context.Context, userID, bookID string) (string, error) {
+	const op = "memory.RemoveBookFromUser"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.shelves[userID][bookID]; !ok {
+		return "", fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+	}
+	delete(s.shelves[userID], bookID)
+	return bookID, nil
+}
+
+// GetBookFacets counts the books matching filter per genre, author and
+// publication decade. Each facet ignores its own part of the filter so the
+// counts show what selecting another value of that facet would return.
+// limit caps the number of genre and author values.
+func (s *Storage) GetBookFacets(ctx context.Context, filter *models.BookFilter, limit int) (*models.BookFacets, error) {
+	if filter == nil {
+		filter = &models.BookFilter{}
+	}
+
+	genreFilter := *filter
+	genreFilter.Genre, genreFilter.Genres = nil, nil
+	authorFilter := *filter
+	authorFilter.Author, authorFilter.Authors = nil, nil
+	decadeFilter := *filter
+	decadeFilter.PublicationYear, decadeFilter.YearFrom, decadeFilter.YearTo = nil, nil, nil
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	genres := make(map[string]int64)
+	authors := make(map[string]int64)
+	decades := make(map[int32]int64)
+	for _, book := range s.books {
+		if matches(book, &genreFilter) {
+			for _, g := range book.Genres {
+				genres[g]++
+			}
+		}
+		if matches(book, &authorFilter) {
+			authors[book.Author]++
+		}
+		if matches(book, &decadeFilter) {
+			decades[book.PublicationYear/10*10]++
+		}
+	}
+
+	facets := &models.BookFacets{
+		Genres:  topCounts(genres, limit),
+		Authors: topCounts(authors, limit),
+	}
+	years := make([]int32, 0, len(decades))
+	for year := range decades {
+		years = append(years, year)
+	}
+	slices.Sort(years)
+	for _, year := range years {
+		facets.Decades = append(facets.Decades, models.FacetCount{Value: strconv.Itoa(int(year)), Count: decades[year]})
+	}
+	return facets, nil
+}
+
+// topCounts orders counts by count, then value, and keeps the first limit.
+func topCounts(counts map[string]int64, limit int) []models.FacetCount {
+	result := make([]models.FacetCount, 0, len(counts))
+	for value, count := range counts {
+		result = append(result, models.FacetCount{Value: value, Count: count})
+	}
+	slices.SortFunc(result, func(a, b models.FacetCount) int {
+		if a.Count != b.Count {
+			if a.Count > b.Count {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Value, b.Value)
+	})
+	if limit >= 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func (s *Storage) isbnTaken(isbn, exceptID string) bool {
+	for id, book := range s.books {
+		if id != exceptID && book.ISBN == isbn {
+			return true
+		}
+	}
+	return false
+}
+
+// setSeries points book at the series named like its SeriesName, creating
+// the series when needed. Series names are matched case-insensitively and
+// keep the spelling they were created with.
+func (s *Storage) setSeries(book *models.Book) {
+	if book.SeriesName == "" {
+		book.SeriesID = ""
+		return
+	}
+	key := strings.ToLower(book.SeriesName)
+	sr, ok := s.series[key]
+	if !ok {
+		sr = series{id: uuid.New().String(), name: book.SeriesName}
+		s.series[key] = sr
+	}
+	book.SeriesID, book.SeriesName = sr.id, sr.name
+}
+
+// cloneBook copies book so that callers never share memory with the store.
+func cloneBook(book *models.Book) *models.Book {
+	c := book.Clone()
+	if c.Subjects == nil {
+		c.Subjects = []string{}
+	}
+	return c
+}
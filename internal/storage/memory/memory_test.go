@@ -0,0 +1,49 @@
+package memory_test
+
+import (
+	"bookService/internal/domain/models"
+	"bookService/internal/services/bookService"
+	"bookService/internal/storage/memory"
+	"bookService/internal/storage/storagetest"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestStorage(t *testing.T) {
+	storagetest.RunBookStorage(t, func(t *testing.T) storagetest.BookStorage {
+		return memory.New()
+	})
+}
+
+func TestCache(t *testing.T) {
+	storagetest.RunBookCache(t, func(t *testing.T) bookService.BookCache {
+		return memory.NewCache(time.Minute, time.Minute)
+	})
+}
+
+func TestCacheExpiry(t *testing.T) {
+	ctx := context.Background()
+	c := memory.NewCache(time.Millisecond, time.Millisecond)
+
+	if err := c.SetBook(ctx, "book:1", &models.Book{ID: "1"}); err != nil {
+		t.Fatalf("SetBook: %v", err)
+	}
+	if err := c.SetBookNotFound(ctx, "book:2"); err != nil {
+		t.Fatalf("SetBookNotFound: %v", err)
+	}
+	if err := c.SetFacets(ctx, "facets:1", &models.BookFacets{}); err != nil {
+		t.Fatalf("SetFacets: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if book, err := c.GetBook(ctx, "book:1"); book != nil || err != nil {
+		t.Errorf("GetBook after the TTL = %v, %v, want nil, nil", book, err)
+	}
+	if book, err := c.GetBook(ctx, "book:2"); book != nil || err != nil {
+		t.Errorf("GetBook of a missing book after the TTL = %v, %v, want nil, nil", book, err)
+	}
+	if facets, err := c.GetFacets(ctx, "facets:1"); facets != nil || err != nil {
+		t.Errorf("GetFacets after the TTL = %v, %v, want nil, nil", facets, err)
+	}
+}
@@ -0,0 +1,91 @@
+package pgxstore
+
+import (
+	"bookService/internal/domain/models"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// A genre counts the books tagged with it or any of its subgenres, as
+	// filtering by it would return.
+	genreFacetSQL = `WITH RECURSIVE lineage AS (
+			SELECT genre_id AS ancestor_id, genre_id FROM genres
+			UNION
+			SELECT l.ancestor_id, g.genre_id FROM genres g JOIN lineage l ON g.parent_id = l.genre_id
+		)
+		SELECT g.name, count(DISTINCT b.book_id) AS count
+		FROM books b
+		JOIN books_genres bg ON bg.book_id = b.book_id
+		JOIN lineage l ON l.genre_id = bg.genre_id
+		JOIN genres g ON g.genre_id = l.ancestor_id
+		WHERE ` + filterSQL + `
+		GROUP BY g.name
+		ORDER BY count DESC, g.name ASC
+		LIMIT $10`
+	authorFacetSQL = `SELECT b.author, count(*) AS count
+		FROM books b
+		WHERE ` + filterSQL + `
+		GROUP BY b.author
+		ORDER BY count DESC, b.author ASC
+		LIMIT $10`
+	decadeFacetSQL = `SELECT (b.publication_year / 10 * 10)::text, count(*)
+		FROM books b
+		WHERE b.publication_year IS NOT NULL AND ` + filterSQL + `
+		GROUP BY b.publication_year / 10
+		ORDER BY b.publication_year / 10 ASC`
+)
+
+// GetBookFacets counts the books matching filter per genre, author and
+// publication decade. Each facet ignores its own part of the filter so the
+// counts show what selecting another value of that facet would return.
+// limit caps the number of genre and author values.
+func (s *Storage) GetBookFacets(ctx context.Context, filter *models.BookFilter, limit int) (*models.BookFacets, error) {
+	const op = "pgx.GetBookFacets"
+
+	if filter == nil {
+		filter = &models.BookFilter{}
+	}
+
+	genreFilter := *filter
+	genreFilter.Genre, genreFilter.Genres = nil, nil
+	authorFilter := *filter
+	authorFilter.Author, authorFilter.Authors = nil, nil
+	decadeFilter := *filter
+	decadeFilter.PublicationYear, decadeFilter.YearFrom, decadeFilter.YearTo = nil, nil, nil
+
+	var facets models.BookFacets
+	// All three counts are taken from one snapshot.
+	err := pgx.BeginTxFunc(ctx, s.pool, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}, func(tx pgx.Tx) error {
+		var err error
+		if facets.Genres, err = facetCounts(ctx, tx, genreFacetSQL, filterArgs(&genreFilter, limit)); err != nil {
+			return fmt.Errorf("genres: %w", err)
+		}
+		if facets.Authors, err = facetCounts(ctx, tx, authorFacetSQL, filterArgs(&authorFilter, limit)); err != nil {
+			return fmt.Errorf("authors: %w", err)
+		}
+		if facets.Decades, err = facetCounts(ctx, tx, decadeFacetSQL, filterArgs(&decadeFilter)); err != nil {
+			return fmt.Errorf("decades: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &facets, nil
+}
+
+func facetCounts(ctx context.Context, tx pgx.Tx, query string, args []any) ([]models.FacetCount, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.FacetCount, error) {
+		var c models.FacetCount
+		err := row.Scan(&c.Value, &c.Count)
+		return c, err
+	})
+}
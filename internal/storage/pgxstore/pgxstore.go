@@ -0,0 +1,613 @@
+// Package pgxstore stores books with pgx. It implements the same book
+// storage as package postres, with prepared statements cached per
+// connection and bulk inserts through COPY, so that both can be run
+// against the same database and compared.
+package pgxstore
+
+import (
+	"bookService/config"
+	"bookService/internal/domain/models"
+	"bookService/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const pgUniqueViolation = "23505"
+
+// Statements that depend on the filter's order, built once.
+var (
+	listBooksSQL = sorted(`SELECT ` + bookColumns + `
+		FROM books b
+		WHERE ` + filterSQL)
+	userBooksSQL = sorted(`SELECT ` + bookColumns + `
+		FROM books b
+		JOIN users_books ub ON b.book_id = ub.book_id
+		WHERE ub.user_id = $10 AND ` + filterSQL)
+)
+
+type Storage struct {
+	pool *pgxpool.Pool
+}
+
+// New connects a pool to the primary of cfg. Statements running longer
+// than cfg.QueryTimeout, or defaultTimeout when that is not set, are
+// cancelled by the server. Read replicas are not supported: New fails when
+// cfg lists any rather than send every read to the primary unnoticed.
+func New(cfg config.DBConfig, defaultTimeout time.Duration) (*Storage, error) {
+	if len(cfg.Replicas) > 0 {
+		return nil, errors.New("pgx: read replicas are not supported")
+	}
+
+	timeout := cfg.QueryTimeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	poolConfig, err := pgxpool.ParseConfig(fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.DBName, cfg.SSLMode))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.MaxOpenConns > 0 {
+		poolConfig.MaxConns = int32(cfg.MaxOpenConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		poolConfig.MaxConnLifetime = cfg.ConnMaxLifetime
+	}
+	if cfg.ConnMaxIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = cfg.ConnMaxIdleTime
+	}
+	if timeout > 0 {
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(timeout.Milliseconds(), 10)
+	}
+	poolConfig.ConnConfig.Tracer = queryTracer{}
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err := pool.Ping(context.Background()); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return &Storage{pool: pool}, nil
+}
+
+func (s *Storage) GetBook(ctx context.Context, id string) (*models.Book, error) {
+	const op = "pgx.GetBook"
+	const query = `SELECT ` + bookColumns + `
+		FROM books b
+		WHERE b.book_id = $1
+	`
+
+	bookID, err := parseUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+	}
+	book, err := scanBook(s.pool.QueryRow(ctx, query, bookID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.attachGenres(ctx, book); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return book, nil
+}
+
+func (s *Storage) ListBooks(ctx context.Context, filter *models.BookFilter) ([]*models.Book, error) {
+	const op = "pgx.ListBooks"
+
+	rows, err := s.pool.Query(ctx, listBooksSQL.get(filter), filterArgs(filter)...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	books, err := collectBooks(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.attachGenres(ctx, books...); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return books, nil
+}
+
+func (s *Storage) GetUserBooks(ctx context.Context, userID string, filter *models.BookFilter) ([]*models.Book, error) {
+	const op = "pgx.GetUserBooks"
+
+	user, err := parseUUID(userID)
+	if err != nil {
+		return nil, nil
+	}
+	rows, err := s.pool.Query(ctx, userBooksSQL.get(filter), filterArgs(filter, user)...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	books, err := collectBooks(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.attachGenres(ctx, books...); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return books, nil
+}
+
+func (s *Storage) CountUserBooks(ctx context.Context, userID string) (int, error) {
+	const op = "pgx.CountUserBooks"
+	const query = `SELECT count(*) FROM users_books WHERE user_id = $1`
+
+	user, err := parseUUID(userID)
+	if err != nil {
+		return 0, nil
+	}
+	var count int
+	if err := s.pool.QueryRow(ctx, query, user).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return count, nil
+}
+
+func (s *Storage) AddBook(ctx context.Context, book *models.Book) (*models.Book, error) {
+	const op = "pgx.AddBook"
+	const query = `
+		INSERT INTO books AS b (
+			book_id,
+			title,
+			author,
+			publication_year,
+			genre,
+			rating,
+			publisher,
+			language,
+			page_count,
+			edition,
+			description,
+			subjects,
+			series_id,
+			series_number,
+			isbn
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING ` + bookColumns
+
+	if book.ID == "" {
+		book.ID = uuid.New().String()
+	}
+	bookID, err := parseUUID(book.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var result *models.Book
+	err = pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		seriesID, err := ensureSeries(ctx, tx, book.SeriesName)
+		if err != nil {
+			return err
+		}
+		result, err = scanBook(tx.QueryRow(ctx, query,
+			bookID,
+			book.Title,
+			book.Author,
+			book.PublicationYear,
+			book.Genre,
+			book.Rating,
+			nullString(book.Publisher),
+			nullString(book.Language),
+			nullInt32(book.PageCount),
+			nullString(book.Edition),
+			nullString(book.Description),
+			subjects(book.Subjects),
+			seriesID,
+			nullInt32(book.SeriesNumber),
+			nullString(book.ISBN),
+		))
+		return err
+	})
+	if err != nil {
+		if pgCode(err) == pgUniqueViolation {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrBookExists)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// AddBooks inserts books, and the genre tags of genreIDs, in one
+// transaction, streaming them to the server with COPY. Either every book is
+// added or, when one of them fails, none.
+func (s *Storage) AddBooks(ctx context.Context, books []*models.Book, genreIDs []string) ([]*models.Book, error) {
+	const op = "pgx.AddBooks"
+	const query = `SELECT ` + bookColumns + `
+		FROM books b
+		WHERE b.book_id = ANY($1)
+	`
+
+	if len(books) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]pgtype.UUID, len(books))
+	for i, book := range books {
+		if book.ID == "" {
+			book.ID = uuid.New().String()
+		}
+		id, err := parseUUID(book.ID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		ids[i] = id
+	}
+	var tags [][]any
+	for i, genreID := range genreIDs {
+		if genreID == "" {
+			continue
+		}
+		id, err := parseUUID(genreID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tags = append(tags, []any{ids[i], id})
+	}
+
+	var added []*models.Book
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		series := make(map[string]pgtype.UUID)
+		for _, book := range books {
+			if _, ok := series[book.SeriesName]; ok {
+				continue
+			}
+			id, err := ensureSeries(ctx, tx, book.SeriesName)
+			if err != nil {
+				return err
+			}
+			series[book.SeriesName] = id
+		}
+
+		_, err := tx.CopyFrom(ctx,
+			pgx.Identifier{"books"},
+			[]string{
+				"book_id",
+				"title",
+				"author",
+				"publication_year",
+				"genre",
+				"rating",
+				"publisher",
+				"language",
+				"page_count",
+				"edition",
+				"description",
+				"subjects",
+				"series_id",
+				"series_number",
+				"isbn",
+			},
+			pgx.CopyFromSlice(len(books), func(i int) ([]any, error) {
+				book := books[i]
+				return []any{
+					ids[i],
+					book.Title,
+					book.Author,
+					book.PublicationYear,
+					book.Genre,
+					book.Rating,
+					nullString(book.Publisher),
+					nullString(book.Language),
+					nullInt32(book.PageCount),
+					nullString(book.Edition),
+					nullString(book.Description),
+					subjects(book.Subjects),
+					series[book.SeriesName],
+					nullInt32(book.SeriesNumber),
+					nullString(book.ISBN),
+				}, nil
+			}),
+		)
+		if err != nil {
+			return err
+		}
+		if len(tags) > 0 {
+			_, err = tx.CopyFrom(ctx,
+				pgx.Identifier{"books_genres"},
+				[]string{"book_id", "genre_id"},
+				pgx.CopyFromRows(tags),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		rows, err := tx.Query(ctx, query, ids)
+		if err != nil {
+			return err
+		}
+		added, err = collectBooks(rows)
+		return err
+	})
+	if err != nil {
+		if pgCode(err) == pgUniqueViolation {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrBookExists)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Return the books in the order they were given.
+	byID := make(map[string]*models.Book, len(added))
+	for _, book := range added {
+		byID[book.ID] = book
+	}
+	result := make([]*models.Book, 0, len(books))
+	for _, book := range books {
+		if b, ok := byID[book.ID]; ok {
+			result = append(result, b)
+		}
+	}
+	return result, nil
+}
+
+func (s *Storage) UpdateBook(ctx context.Context, id string, update func(book *models.Book) error) (*models.Book, error) {
+	const op = "pgx.UpdateBook"
+	const selectQuery = `SELECT ` + bookColumns + `
+		FROM books b
+		WHERE b.book_id = $1
+		FOR UPDATE OF b
+	`
+	const query = `
+		UPDATE books b
+		SET
+			title = $1,
+			author = $2,
+			publication_year = $3,
+			genre = $4,
+			rating = $5,
+			publisher = $6,
+			language = $7,
+			page_count = $8,
+			edition = $9,
+			description = $10,
+			subjects = $11,
+			series_id = $12,
+			series_number = $13,
+			isbn = $14
+		WHERE b.book_id = $15
+		RETURNING ` + bookColumns
+
+	bookID, err := parseUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+	}
+
+	var result *models.Book
+	err = pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		// The row stays locked until commit, so a concurrent update reads
+		// the book only once this one is written.
+		book, err := scanBook(tx.QueryRow(ctx, selectQuery, bookID))
+		if err != nil {
+			return err
+		}
+		if err := update(book); err != nil {
+			return err
+		}
+		seriesID, err := ensureSeries(ctx, tx, book.SeriesName)
+		if err != nil {
+			return err
+		}
+		result, err = scanBook(tx.QueryRow(ctx, query,
+			book.Title,
+			book.Author,
+			book.PublicationYear,
+			book.Genre,
+			book.Rating,
+			nullString(book.Publisher),
+			nullString(book.Language),
+			nullInt32(book.PageCount),
+			nullString(book.Edition),
+			nullString(book.Description),
+			subjects(book.Subjects),
+			seriesID,
+			nullInt32(book.SeriesNumber),
+			nullString(book.ISBN),
+			bookID,
+		))
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+		}
+		if pgCode(err) == pgUniqueViolation {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrBookExists)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.attachGenres(ctx, result); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+func (s *Storage) DeleteBook(ctx context.Context, id string) (string, error) {
+	const op = "pgx.DeleteBook"
+	const query = `
+		DELETE FROM books
+		WHERE book_id = $1
+		RETURNING book_id
+	`
+
+	bookID, err := parseUUID(id)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+	}
+	var deleted pgtype.UUID
+	if err := s.pool.QueryRow(ctx, query, bookID).Scan(&deleted); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return uuidString(deleted), nil
+}
+
+// AddBookToUser shelves the book for the user. Shelving a book twice is
+// not an error. When maxShelfSize is positive and the book would outgrow
+// the shelf, nothing is added and ErrShelfFull is returned.
+func (s *Storage) AddBookToUser(ctx context.Context, userID, bookID string, maxShelfSize int) (string, error) {
+	const op = "pgx.AddBookToUser"
+	// Locking the user row serialises concurrent shelf changes for the quota.
+	const lockQuery = `SELECT 1 FROM users WHERE user_id = $1 FOR UPDATE`
+	const query = `
+		INSERT INTO users_books (user_id, book_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, book_id) DO NOTHING
+	`
+	const countQuery = `SELECT count(*) FROM users_books WHERE user_id = $1`
+
+	user, err := parseUUID(userID)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	book, err := parseUUID(bookID)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+	}
+	err = pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if maxShelfSize > 0 {
+			if _, err := tx.Exec(ctx, lockQuery, user); err != nil {
+				return err
+			}
+		}
+		tag, err := tx.Exec(ctx, query, user, book)
+		if err != nil {
+			return err
+		}
+		// Already on the shelf: nothing changed, so the quota holds.
+		if tag.RowsAffected() == 0 || maxShelfSize <= 0 {
+			return nil
+		}
+		var count int
+		if err := tx.QueryRow(ctx, countQuery, user).Scan(&count); err != nil {
+			return err
+		}
+		if count > maxShelfSize {
+			return storage.ErrShelfFull
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return bookID, nil
+}
+
+func (s *Storage) RemoveBookFromUser(ctx context.Context, userID, bookID string) (string, error) {
+	const op = "pgx.RemoveBookFromUser"
+	const query = `
+		DELETE FROM users_books
+		WHERE user_id = $1 AND book_id = $2
+	`
+
+	user, err := parseUUID(userID)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+	}
+	book, err := parseUUID(bookID)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+	}
+	tag, err := s.pool.Exec(ctx, query, user, book)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return "", fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+	}
+
+	return bookID, nil
+}
+
+// ensureSeries returns the id of the series called name, creating it when
+// needed. An empty name yields NULL.
+func ensureSeries(ctx context.Context, tx pgx.Tx, name string) (pgtype.UUID, error) {
+	const query = `
+		INSERT INTO series (name)
+		VALUES ($1)
+		ON CONFLICT ((lower(name))) DO UPDATE SET name = series.name
+		RETURNING series_id
+	`
+
+	var id pgtype.UUID
+	if name == "" {
+		return id, nil
+	}
+	err := tx.QueryRow(ctx, query, name).Scan(&id)
+	return id, err
+}
+
+// attachGenres fills in the taxonomy genres of books.
+func (s *Storage) attachGenres(ctx context.Context, books ...*models.Book) error {
+	const query = `
+		SELECT bg.book_id, g.name
+		FROM books_genres bg
+		JOIN genres g ON g.genre_id = bg.genre_id
+		WHERE bg.book_id = ANY($1)
+		ORDER BY g.name
+	`
+
+	if len(books) == 0 {
+		return nil
+	}
+
+	ids := make([]pgtype.UUID, 0, len(books))
+	byID := make(map[string]*models.Book, len(books))
+	for _, b := range books {
+		id, err := parseUUID(b.ID)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+		byID[b.ID] = b
+	}
+
+	rows, err := s.pool.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+	var (
+		bookID pgtype.UUID
+		name   string
+	)
+	_, err = pgx.ForEachRow(rows, []any{&bookID, &name}, func() error {
+		if b, ok := byID[uuidString(bookID)]; ok {
+			b.Genres = append(b.Genres, name)
+		}
+		return nil
+	})
+	return err
+}
+
+func pgCode(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
@@ -0,0 +1,27 @@
+package pgxstore
+
+import (
+	"bookService/internal/storage/storagetest"
+	"context"
+	"testing"
+)
+
+func TestBookStorage(t *testing.T) {
+	cfg := storagetest.Config(t)
+	s, err := New(cfg.DB, 0)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(s.pool.Close)
+
+	storagetest.RunBookStorage(t, func(t *testing.T) storagetest.BookStorage {
+		ctx := context.Background()
+		if _, err := s.pool.Exec(ctx, storagetest.TruncatePostgres); err != nil {
+			t.Fatalf("truncate: %v", err)
+		}
+		if _, err := s.pool.Exec(ctx, storagetest.CreateUsersPostgres, storagetest.UserIDs); err != nil {
+			t.Fatalf("create users: %v", err)
+		}
+		return s
+	})
+}
@@ -0,0 +1,222 @@
+package pgxstore
+
+import (
+	"bookService/internal/domain/models"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// bookColumns is the column list every book query selects, in the order
+// scanBook reads them.
+const bookColumns = `
+	b.book_id,
+	b.title,
+	b.author,
+	coalesce(b.publication_year, 0),
+	coalesce(b.genre, ''),
+	b.rating,
+	b.created_at,
+	coalesce(b.cover_key, ''),
+	coalesce(b.thumbnail_key, ''),
+	coalesce(b.publisher, ''),
+	coalesce(b.language, ''),
+	coalesce(b.page_count, 0),
+	coalesce(b.edition, ''),
+	coalesce(b.description, ''),
+	b.subjects,
+	b.series_id,
+	coalesce((SELECT sr.name FROM series sr WHERE sr.series_id = b.series_id), ''),
+	coalesce(b.series_number, 0),
+	coalesce(b.isbn, '')
+`
+
+func scanBook(row pgx.Row) (*models.Book, error) {
+	var (
+		book         models.Book
+		id, seriesID pgtype.UUID
+	)
+	err := row.Scan(
+		&id,
+		&book.Title,
+		&book.Author,
+		&book.PublicationYear,
+		&book.Genre,
+		&book.Rating,
+		&book.CreatedAt,
+		&book.CoverKey,
+		&book.ThumbnailKey,
+		&book.Publisher,
+		&book.Language,
+		&book.PageCount,
+		&book.Edition,
+		&book.Description,
+		&book.Subjects,
+		&seriesID,
+		&book.SeriesName,
+		&book.SeriesNumber,
+		&book.ISBN,
+	)
+	if err != nil {
+		return nil, err
+	}
+	book.ID = uuidString(id)
+	book.SeriesID = uuidString(seriesID)
+	return &book, nil
+}
+
+func collectBooks(rows pgx.Rows) ([]*models.Book, error) {
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*models.Book, error) {
+		return scanBook(row)
+	})
+}
+
+// filterSQL matches books against the nine arguments of filterArgs, which
+// are NULL for the parts of the filter that are not set. Keeping the text
+// the same for every filter lets pgx prepare it once per connection;
+// Postgres still plans the first executions for the actual values.
+const filterSQL = `
+	($1::text[] IS NULL OR b.author = ANY($1::text[]))
+	AND ($2::int IS NULL OR b.publication_year = $2::int)
+	AND ($3::int IS NULL OR b.publication_year >= $3::int)
+	AND ($4::int IS NULL OR b.publication_year <= $4::int)
+	AND ($5::text[] IS NULL OR b.book_id IN (
+		SELECT book_id FROM books_genres WHERE genre_id IN (
+			WITH RECURSIVE subtree AS (
+				SELECT genre_id FROM genres
+				WHERE lower(name) = ANY($5::text[]) OR aliases && $5::text[]
+				UNION
+				SELECT g.genre_id FROM genres g JOIN subtree s ON g.parent_id = s.genre_id
+			)
+			SELECT genre_id FROM subtree
+		)
+	))
+	AND ($6::text IS NULL OR lower(b.language) = $6::text OR lower(b.language) LIKE $7::text)
+	AND ($8::text IS NULL OR b.series_id IN (SELECT series_id FROM series WHERE lower(name) = lower($8::text)))
+	AND ($9::text IS NULL OR lower(b.title) LIKE $9::text)
+`
+
+// filterArgs returns the arguments of filterSQL followed by extra, which
+// are numbered from $10.
+func filterArgs(filter *models.BookFilter, extra ...any) []any {
+	args := make([]any, 9, 9+len(extra))
+	if filter != nil {
+		authors := filter.Authors
+		if filter.Author != nil {
+			authors = append([]string{*filter.Author}, authors...)
+		}
+		if len(authors) > 0 {
+			args[0] = authors
+		}
+
+		if filter.PublicationYear != nil {
+			args[1] = *filter.PublicationYear
+		}
+		if filter.YearFrom != nil {
+			args[2] = *filter.YearFrom
+		}
+		if filter.YearTo != nil {
+			args[3] = *filter.YearTo
+		}
+
+		genres := filter.Genres
+		if filter.Genre != nil {
+			genres = append([]string{*filter.Genre}, genres...)
+		}
+		if len(genres) > 0 {
+			lowered := make([]string, 0, len(genres))
+			for _, g := range genres {
+				lowered = append(lowered, strings.ToLower(g))
+			}
+			args[4] = lowered
+		}
+
+		if filter.Language != nil && *filter.Language != "" {
+			lang := strings.ToLower(*filter.Language)
+			args[5], args[6] = lang, escapeLike(lang)+"-%"
+		}
+		if filter.Series != nil && *filter.Series != "" {
+			args[7] = *filter.Series
+		}
+		if filter.TitlePrefix != nil && *filter.TitlePrefix != "" {
+			args[8] = escapeLike(strings.ToLower(*filter.TitlePrefix)) + "%"
+		}
+	}
+	return append(args, extra...)
+}
+
+var sortColumns = map[string]string{
+	models.SortByTitle:           "b.title",
+	models.SortByAuthor:          "b.author",
+	models.SortByPublicationYear: "b.publication_year",
+	models.SortByCreatedAt:       "b.created_at",
+	models.SortByRating:          "b.rating",
+}
+
+// sortedQuery holds a query in every order a filter can ask for, so that
+// each variant is a fixed statement too.
+type sortedQuery map[string]string
+
+func sorted(query string) sortedQuery {
+	q := make(sortedQuery, 2*len(sortColumns))
+	for sortBy, column := range sortColumns {
+		for _, direction := range []string{"ASC", "DESC"} {
+			// book_id keeps the order stable between equal sort keys.
+			q[sortBy+" "+direction] = fmt.Sprintf("%s ORDER BY %s %s NULLS LAST, b.book_id ASC", query, column, direction)
+		}
+	}
+	return q
+}
+
+func (q sortedQuery) get(filter *models.BookFilter) string {
+	sortBy, direction := models.SortByTitle, "ASC"
+	if filter != nil {
+		if _, ok := sortColumns[filter.SortBy]; ok {
+			sortBy = filter.SortBy
+		}
+		if filter.SortDesc {
+			direction = "DESC"
+		}
+	}
+	return q[sortBy+" "+direction]
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// parseUUID converts an id to its native form. Ids that are not UUIDs
+// cannot name any row, so callers treat the error as not found.
+func parseUUID(id string) (pgtype.UUID, error) {
+	u, err := uuid.Parse(id)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	return pgtype.UUID{Bytes: u, Valid: true}, nil
+}
+
+func uuidString(u pgtype.UUID) string {
+	if !u.Valid {
+		return ""
+	}
+	return uuid.UUID(u.Bytes).String()
+}
+
+// nullString stores empty optional text columns as NULL.
+func nullString(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
+func nullInt32(n int32) pgtype.Int4 {
+	return pgtype.Int4{Int32: n, Valid: n != 0}
+}
+
+func subjects(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
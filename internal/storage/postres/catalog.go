@@ -0,0 +1,45 @@
+package postres
+
+import (
+	"bookService/internal/domain/models"
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// maxDuplicateCandidates bounds FindDuplicateCandidates; an import compares
+// every candidate in memory.
+const maxDuplicateCandidates = 100
+
+// FindDuplicateCandidates returns the books an imported book may duplicate:
+// those with its ISBN and those whose title or author contains one of
+// words, which must be lowercase without diacritics. Stored titles and
+// authors are folded the same way before they are compared. The most
+// similar books come first, so that the limit drops the least likely ones.
+// Telling actual duplicates apart is up to the caller.
+func (s *Storage) FindDuplicateCandidates(ctx context.Context, book *models.Book, words []string) ([]*models.Book, error) {
+	const op = "postgres.FindDuplicateCandidates"
+
+	patterns := make([]string, 0, len(words))
+	for _, w := range words {
+		patterns = append(patterns, "%"+escapeLike(w)+"%")
+	}
+
+	query := `SELECT ` + bookColumns + `
+		FROM books b
+		WHERE ($1 <> '' AND b.isbn = $1)
+			OR fold_text(b.title) LIKE ANY($2)
+			OR fold_text(b.author) LIKE ANY($2)
+		ORDER BY b.isbn = $1 DESC NULLS LAST,
+			similarity(fold_text(b.title), fold_text($3)) + similarity(fold_text(b.author), fold_text($4)) DESC,
+			b.created_at ASC
+		LIMIT $5`
+
+	var rows []bookRow
+	err := s.db.SelectContext(ctx, &rows, query, book.ISBN, pq.Array(patterns), book.Title, book.Author, maxDuplicateCandidates)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return toBooks(rows), nil
+}
@@ -0,0 +1,37 @@
+package postres
+
+import (
+	"bookService/internal/domain/models"
+	"bookService/internal/storage"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// SetBookCover points the book at new cover blobs and returns the keys it
+// had before, so the caller can delete the replaced blobs.
+func (s *Storage) SetBookCover(ctx context.Context, cover *models.Cover) (*models.Cover, error) {
+	const op = "postgres.SetBookCover"
+	const query = `
+		UPDATE books b
+		SET
+			cover_key = $2,
+			thumbnail_key = $3
+		FROM (SELECT book_id, cover_key, thumbnail_key FROM books WHERE book_id = $1 FOR UPDATE) old
+		WHERE b.book_id = old.book_id
+		RETURNING coalesce(old.cover_key, ''), coalesce(old.thumbnail_key, '')
+	`
+
+	previous := &models.Cover{BookID: cover.BookID}
+	err := s.db.QueryRowContext(ctx, query, cover.BookID, cover.Key, cover.ThumbnailKey).
+		Scan(&previous.Key, &previous.ThumbnailKey)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	s.markBooks(cover.BookID)
+
+	return previous, nil
+}
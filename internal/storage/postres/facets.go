@@ -0,0 +1,74 @@
+package postres
+
+import (
+	"bookService/internal/domain/models"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// GetBookFacets counts the books matching filter per genre, author and
+// publication decade. Each facet ignores its own part of the filter so the
+// counts show what selecting another value of that facet would return.
+// limit caps the number of genre and author values. A genre counts the books
+// tagged with it or any of its subgenres, as filtering by it would return.
+func (s *Storage) GetBookFacets(ctx context.Context, filter *models.BookFilter, limit int) (*models.BookFacets, error) {
+	const op = "postgres.GetBookFacets"
+
+	if filter == nil {
+		filter = &models.BookFilter{}
+	}
+
+	// All three counts are taken from one snapshot.
+	tx, err := s.reader(ctx, catalogKey).BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var facets models.BookFacets
+
+	genreFilter := *filter
+	genreFilter.Genre, genreFilter.Genres = nil, nil
+	var q bookQuery
+	q.applyFilter(&genreFilter)
+	query := genreLineageSQL + `SELECT g.name AS value, count(DISTINCT b.book_id) AS count
+		FROM books b
+		JOIN books_genres bg ON bg.book_id = b.book_id
+		JOIN lineage l ON l.genre_id = bg.genre_id
+		JOIN genres g ON g.genre_id = l.ancestor_id` + q.whereClause() + `
+		GROUP BY g.name
+		ORDER BY count DESC, value ASC
+		LIMIT ` + q.arg(limit)
+	if err := tx.SelectContext(ctx, &facets.Genres, query, q.args...); err != nil {
+		return nil, fmt.Errorf("%s: genres: %w", op, err)
+	}
+
+	authorFilter := *filter
+	authorFilter.Author, authorFilter.Authors = nil, nil
+	q = bookQuery{}
+	q.applyFilter(&authorFilter)
+	query = `SELECT b.author AS value, count(*) AS count
+		FROM books b` + q.whereClause() + `
+		GROUP BY b.author
+		ORDER BY count DESC, value ASC
+		LIMIT ` + q.arg(limit)
+	if err := tx.SelectContext(ctx, &facets.Authors, query, q.args...); err != nil {
+		return nil, fmt.Errorf("%s: authors: %w", op, err)
+	}
+
+	decadeFilter := *filter
+	decadeFilter.PublicationYear, decadeFilter.YearFrom, decadeFilter.YearTo = nil, nil, nil
+	q = bookQuery{}
+	q.where("b.publication_year IS NOT NULL")
+	q.applyFilter(&decadeFilter)
+	query = `SELECT (b.publication_year / 10 * 10)::text AS value, count(*) AS count
+		FROM books b` + q.whereClause() + `
+		GROUP BY b.publication_year / 10
+		ORDER BY b.publication_year / 10 ASC`
+	if err := tx.SelectContext(ctx, &facets.Decades, query, q.args...); err != nil {
+		return nil, fmt.Errorf("%s: decades: %w", op, err)
+	}
+
+	return &facets, nil
+}
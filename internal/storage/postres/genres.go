@@ -0,0 +1,457 @@
+package postres
+
+import (
+	"bookService/internal/domain/models"
+	"bookService/internal/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	pqUniqueViolation     = "23505"
+	pqForeignKeyViolation = "23503"
+)
+
+// genreSubtreeSQL selects the ids of the genres matching any of the given
+// lower-case names or aliases and of all their descendants. %d is the
+// placeholder index of the text[] argument.
+const genreSubtreeSQL = `
+	WITH RECURSIVE subtree AS (
+		SELECT genre_id FROM genres
+		WHERE lower(name) = ANY($%[1]d::text[]) OR aliases && $%[1]d::text[]
+		UNION
+		SELECT g.genre_id FROM genres g JOIN subtree s ON g.parent_id = s.genre_id
+	)
+	SELECT genre_id FROM subtree
+`
+
+// genreLineageSQL pairs every genre with itself and each of its
+// descendants, so that a book tagged with a subgenre counts towards its
+// ancestors too, as genreSubtreeSQL matches it when filtering by them.
+const genreLineageSQL = `
+	WITH RECURSIVE lineage AS (
+		SELECT genre_id AS ancestor_id, genre_id FROM genres
+		UNION
+		SELECT l.ancestor_id, g.genre_id FROM genres g JOIN lineage l ON g.parent_id = l.genre_id
+	)
+`
+
+type genreRow struct {
+	ID       string         `db:"genre_id"`
+	Name     string         `db:"name"`
+	ParentID sql.NullString `db:"parent_id"`
+	Aliases  pq.StringArray `db:"aliases"`
+}
+
+func (r genreRow) toModel() *models.Genre {
+	g := &models.Genre{
+		ID:      r.ID,
+		Name:    r.Name,
+		Aliases: r.Aliases,
+	}
+	if r.ParentID.Valid {
+		g.ParentID = &r.ParentID.String
+	}
+	return g
+}
+
+func pqCode(err error) string {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return string(pqErr.Code)
+	}
+	return ""
+}
+
+func (s *Storage) CreateGenre(ctx context.Context, genre *models.Genre) (*models.Genre, error) {
+	const op = "postgres.CreateGenre"
+	const query = `
+		INSERT INTO genres (genre_id, name, parent_id, aliases)
+		VALUES ($1, $2, $3, $4)
+		RETURNING genre_id, name, parent_id, aliases
+	`
+
+	if genre.ID == "" {
+		genre.ID = uuid.New().String()
+	}
+
+	var row genreRow
+	err := s.db.QueryRowxContext(ctx, query,
+		genre.ID,
+		genre.Name,
+		genre.ParentID,
+		pq.StringArray(genre.Aliases),
+	).StructScan(&row)
+	if err != nil {
+		switch pqCode(err) {
+		case pqUniqueViolation:
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrGenreExists)
+		case pqForeignKeyViolation:
+			return nil, fmt.Errorf("%s: parent: %w", op, storage.ErrGenreNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return row.toModel(), nil
+}
+
+func (s *Storage) GetGenre(ctx context.Context, id string) (*models.Genre, error) {
+	const op = "postgres.GetGenre"
+	const query = `
+		SELECT genre_id, name, parent_id, aliases
+		FROM genres
+		WHERE genre_id = $1
+	`
+
+	var row genreRow
+	if err := s.db.GetContext(ctx, &row, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrGenreNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return row.toModel(), nil
+}
+
+// ResolveGenre finds a genre by name or alias, ignoring case.
+func (s *Storage) ResolveGenre(ctx context.Context, name string) (*models.Genre, error) {
+	const op = "postgres.ResolveGenre"
+	const query = `
+		SELECT genre_id, name, parent_id, aliases
+		FROM genres
+		WHERE lower(name) = lower($1) OR lower($1) = ANY(aliases)
+		ORDER BY lower(name) = lower($1) DESC
+		LIMIT 1
+	`
+
+	var row genreRow
+	if err := s.db.GetContext(ctx, &row, query, name); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrGenreNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return row.toModel(), nil
+}
+
+func (s *Storage) ListGenres(ctx context.Context) ([]*models.Genre, error) {
+	const op = "postgres.ListGenres"
+	const query = `
+		SELECT genre_id, name, parent_id, aliases
+		FROM genres
+		ORDER BY name ASC
+	`
+
+	var rows []genreRow
+	if err := s.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	genres := make([]*models.Genre, 0, len(rows))
+	for _, row := range rows {
+		genres = append(genres, row.toModel())
+	}
+	return genres, nil
+}
+
+// UpdateGenre stores genre and returns it with the ids of the books whose
+// cached copies it changed: on a rename, the books that have the genre as
+// their primary genre, which is renamed along, or as a tag.
+func (s *Storage) UpdateGenre(ctx context.Context, genre *models.Genre) (*models.Genre, []string, error) {
+	const op = "postgres.UpdateGenre"
+	const lockQuery = `SELECT name FROM genres WHERE genre_id = $1 FOR UPDATE`
+	const cycleQuery = `
+		WITH RECURSIVE ancestors AS (
+			SELECT genre_id, parent_id FROM genres WHERE genre_id = $1
+			UNION
+			SELECT g.genre_id, g.parent_id FROM genres g JOIN ancestors a ON g.genre_id = a.parent_id
+		)
+		SELECT EXISTS (SELECT 1 FROM ancestors WHERE genre_id = $2)
+	`
+	const query = `
+		UPDATE genres
+		SET
+			name = $1,
+			parent_id = $2,
+			aliases = $3
+		WHERE genre_id = $4
+		RETURNING genre_id, name, parent_id, aliases
+	`
+	const renameBooksQuery = `
+		WITH renamed AS (
+			UPDATE books SET genre = $2
+			WHERE lower(genre) = lower($1)
+			RETURNING book_id
+		)
+		SELECT book_id FROM renamed
+		UNION
+		SELECT book_id FROM books_genres WHERE genre_id = $3
+	`
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var oldName string
+	if err := tx.GetContext(ctx, &oldName, lockQuery, genre.ID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, fmt.Errorf("%s: %w", op, storage.ErrGenreNotFound)
+		}
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if genre.ParentID != nil {
+		// Walking up from the new parent must not reach the genre itself.
+		var cycle bool
+		if err := tx.GetContext(ctx, &cycle, cycleQuery, *genre.ParentID, genre.ID); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if cycle {
+			return nil, nil, fmt.Errorf("%s: %w", op, storage.ErrGenreCycle)
+		}
+	}
+
+	var row genreRow
+	err = tx.QueryRowxContext(ctx, query,
+		genre.Name,
+		genre.ParentID,
+		pq.StringArray(genre.Aliases),
+		genre.ID,
+	).StructScan(&row)
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			return nil, nil, fmt.Errorf("%s: %w", op, storage.ErrGenreNotFound)
+		case pqCode(err) == pqUniqueViolation:
+			return nil, nil, fmt.Errorf("%s: %w", op, storage.ErrGenreExists)
+		case pqCode(err) == pqForeignKeyViolation:
+			return nil, nil, fmt.Errorf("%s: parent: %w", op, storage.ErrGenreNotFound)
+		}
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var books []string
+	if row.Name != oldName {
+		if err := tx.SelectContext(ctx, &books, renameBooksQuery, oldName, row.Name, row.ID); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(books) > 0 {
+		s.markBooks(books...)
+	}
+	return row.toModel(), books, nil
+}
+
+// DeleteGenre deletes a genre without children and returns the ids of the
+// books that had it as their primary genre, which is cleared, or as a tag.
+func (s *Storage) DeleteGenre(ctx context.Context, id string) (string, []string, error) {
+	const op = "postgres.DeleteGenre"
+	const clearBooksQuery = `
+		WITH cleared AS (
+			UPDATE books SET genre = NULL
+			WHERE lower(genre) = (SELECT lower(name) FROM genres WHERE genre_id = $1)
+			RETURNING book_id
+		)
+		SELECT book_id FROM cleared
+		UNION
+		SELECT book_id FROM books_genres WHERE genre_id = $1
+	`
+	const query = `
+		DELETE FROM genres
+		WHERE genre_id = $1
+		RETURNING genre_id
+	`
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var books []string
+	if err := tx.SelectContext(ctx, &books, clearBooksQuery, id); err != nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var deletedID string
+	err = tx.QueryRowContext(ctx, query, id).Scan(&deletedID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil, fmt.Errorf("%s: %w", op, storage.ErrGenreNotFound)
+		}
+		if pqCode(err) == pqForeignKeyViolation {
+			return "", nil, fmt.Errorf("%s: %w", op, storage.ErrGenreHasChildren)
+		}
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(books) > 0 {
+		s.markBooks(books...)
+	}
+	return deletedID, books, nil
+}
+
+// SetBookGenres replaces the genre tags of a book.
+func (s *Storage) SetBookGenres(ctx context.Context, bookID string, genreIDs []string) ([]string, error) {
+	const op = "postgres.SetBookGenres"
+	const existsQuery = `SELECT EXISTS (SELECT 1 FROM books WHERE book_id = $1)`
+	const deleteQuery = `DELETE FROM books_genres WHERE book_id = $1`
+	const insertQuery = `
+		INSERT INTO books_genres (book_id, genre_id)
+		SELECT $1, unnest($2::uuid[])
+		ON CONFLICT DO NOTHING
+	`
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.GetContext(ctx, &exists, existsQuery, bookID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+	}
+
+	if _, err := tx.ExecContext(ctx, deleteQuery, bookID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(genreIDs) > 0 {
+		if _, err := tx.ExecContext(ctx, insertQuery, bookID, pq.StringArray(genreIDs)); err != nil {
+			if pqCode(err) == pqForeignKeyViolation {
+				return nil, fmt.Errorf("%s: %w", op, storage.ErrGenreNotFound)
+			}
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	genres, err := bookGenres(ctx, tx, []string{bookID})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	s.markBooks(bookID)
+	return genres[bookID], nil
+}
+
+func (s *Storage) TagBook(ctx context.Context, bookID, genreID string) error {
+	const op = "postgres.TagBook"
+	const query = `
+		INSERT INTO books_genres (book_id, genre_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, bookID, genreID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	s.markBooks(bookID)
+	return nil
+}
+
+// RetagBook swaps the tag of the book's previous primary genre, named
+// previous, for genreID in one transaction, so that a book does not keep
+// matching a genre it was moved out of. An empty genreID only drops the old
+// tag.
+func (s *Storage) RetagBook(ctx context.Context, bookID, previous, genreID string) error {
+	const op = "postgres.RetagBook"
+	const deleteQuery = `
+		DELETE FROM books_genres bg
+		USING genres g
+		WHERE bg.book_id = $1 AND bg.genre_id = g.genre_id AND lower(g.name) = lower($2)
+	`
+	const insertQuery = `
+		INSERT INTO books_genres (book_id, genre_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if previous != "" {
+		if _, err := tx.ExecContext(ctx, deleteQuery, bookID, previous); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if genreID != "" {
+		if _, err := tx.ExecContext(ctx, insertQuery, bookID, genreID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	s.markBooks(bookID)
+	return nil
+}
+
+// attachGenres fills Book.Genres for every book in one query.
+func (s *Storage) attachGenres(ctx context.Context, books ...*models.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(books))
+	for _, b := range books {
+		ids = append(ids, b.ID)
+	}
+
+	genres, err := bookGenres(ctx, s.db, ids)
+	if err != nil {
+		return err
+	}
+	for _, b := range books {
+		b.Genres = genres[b.ID]
+	}
+	return nil
+}
+
+func bookGenres(ctx context.Context, q sqlx.QueryerContext, bookIDs []string) (map[string][]string, error) {
+	const query = `
+		SELECT bg.book_id, g.name
+		FROM books_genres bg
+		JOIN genres g ON g.genre_id = bg.genre_id
+		WHERE bg.book_id = ANY($1::uuid[])
+		ORDER BY g.name
+	`
+
+	rows, err := q.QueryxContext(ctx, query, pq.StringArray(bookIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	genres := make(map[string][]string, len(bookIDs))
+	for rows.Next() {
+		var bookID, name string
+		if err := rows.Scan(&bookID, &name); err != nil {
+			return nil, err
+		}
+		genres[bookID] = append(genres[bookID], name)
+	}
+	return genres, rows.Err()
+}
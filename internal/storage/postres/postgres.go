@@ -9,210 +9,371 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"strings"
+	"sync/atomic"
+	"time"
 )
 
 type Storage struct {
-	db *sqlx.DB
+	// db is the primary, which takes every write.
+	db       tracedDB
+	replicas []tracedDB
+	next     atomic.Uint64
+	writes   *recentWrites
 }
 
-func New(cfg config.DBConfig) (*Storage, error) {
-	db, err := sqlx.Open("postgres", fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.DBName, cfg.SSLMode))
+// New connects to the primary and to every replica of cfg. Statements
+// running longer than cfg.QueryTimeout, or defaultTimeout when that is not
+// set, are cancelled by the server.
+func New(cfg config.DBConfig, defaultTimeout time.Duration) (*Storage, error) {
+	timeout := cfg.QueryTimeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	db, err := open(cfg, fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.DBName, cfg.SSLMode), timeout)
 	if err != nil {
 		return nil, err
 	}
-	err = db.Ping()
+
+	s := &Storage{db: db, writes: newRecentWrites(cfg.ReadYourWrites)}
+	for _, dsn := range cfg.Replicas {
+		replica, err := open(cfg, dsn, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("replica: %w", err)
+		}
+		s.replicas = append(s.replicas, replica)
+	}
+	return s, nil
+}
+
+func open(cfg config.DBConfig, dsn string, timeout time.Duration) (tracedDB, error) {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		var err error
+		if dsn, err = pq.ParseURL(dsn); err != nil {
+			return tracedDB{}, err
+		}
+	}
+	if timeout > 0 {
+		dsn += fmt.Sprintf(" statement_timeout=%d", timeout.Milliseconds())
+	}
+
+	db, err := sqlx.Open("postgres", dsn)
 	if err != nil {
-		return nil, err
+		return tracedDB{}, err
 	}
-	return &Storage{db: db}, nil
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return tracedDB{}, err
+	}
+	return tracedDB{DB: db}, nil
 }
 
 func (s *Storage) GetBook(ctx context.Context, id string) (*models.Book, error) {
 	const op = "postgres.GetBook"
-	const query = `
-		SELECT 
-			book_id as id, 
-			title, 
-			author, 
-			publication_year as publicationyear, 
-			genre 
-		FROM books 
-		WHERE book_id = $1
+	const query = `SELECT ` + bookColumns + `
+		FROM books b
+		WHERE b.book_id = $1
 	`
 
-	var book models.Book
-	err := s.db.GetContext(ctx, &book, query, id)
+	var row bookRow
+	err := s.reader(ctx, bookKey(id)).GetContext(ctx, &row, query, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
 		}
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+	book := row.toModel()
+	if err := s.attachGenres(ctx, book); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-	return &book, nil
+	return book, nil
 }
 func (s *Storage) ListBooks(ctx context.Context, filter *models.BookFilter) ([]*models.Book, error) {
 	const op = "postgres.ListBooks"
-	baseQuery := `
-		SELECT 
-			book_id as id, 
-			title, 
-			author, 
-			publication_year as publicationyear, 
-			genre 
-		FROM books 
-		WHERE 1=1
-	`
-	var args []interface{}
-	var conditions []string
 
-	if filter != nil {
-		if filter.Author != nil {
-			args = append(args, *filter.Author)
-			conditions = append(conditions, fmt.Sprintf("author = $%d", len(args)))
-		}
-		if filter.PublicationYear != nil {
-			args = append(args, *filter.PublicationYear)
-			conditions = append(conditions, fmt.Sprintf("publication_year = $%d", len(args)))
-		}
-		if filter.Genre != nil {
-			args = append(args, *filter.Genre)
-			conditions = append(conditions, fmt.Sprintf("genre = $%d", len(args)))
-		}
-	}
+	var q bookQuery
+	q.applyFilter(filter)
+	query := `SELECT ` + bookColumns + ` FROM books b` + q.whereClause() + orderBy(filter)
 
-	if len(conditions) > 0 {
-		baseQuery += " AND " + strings.Join(conditions, " AND ")
-	}
-
-	baseQuery += " ORDER BY title ASC"
-
-	var books []*models.Book
-	err := s.db.SelectContext(ctx, &books, baseQuery, args...)
+	var rows []bookRow
+	err := s.reader(ctx, catalogKey).SelectContext(ctx, &rows, query, q.args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+	books := toBooks(rows)
+	if err := s.attachGenres(ctx, books...); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
 	return books, nil
 }
 func (s *Storage) GetUserBooks(ctx context.Context, userID string, filter *models.BookFilter) ([]*models.Book, error) {
 	const op = "postgres.GetUserBooks"
-	baseQuery := `
-		SELECT 
-			b.book_id as id, 
-			b.title, 
-			b.author, 
-			b.publication_year as publicationyear, 
-			b.genre 
+
+	var q bookQuery
+	q.where("ub.user_id = " + q.arg(userID))
+	q.applyFilter(filter)
+	query := `SELECT ` + bookColumns + `
 		FROM books b
-		JOIN users_books ub ON b.book_id = ub.book_id
-		WHERE ub.user_id = $1
-	`
-	args := []interface{}{userID}
-	paramCounter := 2
-
-	if filter != nil {
-		if filter.Author != nil {
-			args = append(args, *filter.Author)
-			baseQuery += fmt.Sprintf(" AND b.author = $%d", paramCounter)
-			paramCounter++
-		}
-		if filter.PublicationYear != nil {
-			args = append(args, *filter.PublicationYear)
-			baseQuery += fmt.Sprintf(" AND b.publication_year = $%d", paramCounter)
-			paramCounter++
-		}
-		if filter.Genre != nil {
-			args = append(args, *filter.Genre)
-			baseQuery += fmt.Sprintf(" AND b.genre = $%d", paramCounter)
-			paramCounter++
-		}
-	}
+		JOIN users_books ub ON b.book_id = ub.book_id` + q.whereClause() + orderBy(filter)
 
-	var books []*models.Book
-	err := s.db.SelectContext(ctx, &books, baseQuery, args...)
+	var rows []bookRow
+	err := s.reader(ctx, userKey(userID)).SelectContext(ctx, &rows, query, q.args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+	books := toBooks(rows)
+	if err := s.attachGenres(ctx, books...); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
 	return books, nil
 }
+func (s *Storage) CountUserBooks(ctx context.Context, userID string) (int, error) {
+	const op = "postgres.CountUserBooks"
+	const query = `SELECT count(*) FROM users_books WHERE user_id = $1`
+
+	var count int
+	if err := s.reader(ctx, userKey(userID)).GetContext(ctx, &count, query, userID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return count, nil
+}
 func (s *Storage) AddBook(ctx context.Context, book *models.Book) (*models.Book, error) {
 	const op = "postgres.AddBook"
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	result, err := insertBook(ctx, tx, book, nil)
+	if err != nil {
+		if pqCode(err) == pqUniqueViolation {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrBookExists)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	s.markBooks(result.ID)
+
+	return result, nil
+}
+
+// AddBooks inserts books, and the genre tags of genreIDs, in one
+// transaction: either every book is added or, when one of them fails, none.
+func (s *Storage) AddBooks(ctx context.Context, books []*models.Book, genreIDs []string) ([]*models.Book, error) {
+	const op = "postgres.AddBooks"
+	const tagQuery = `
+		INSERT INTO books_genres (book_id, genre_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+
+	if len(books) == 0 {
+		return nil, nil
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	series := make(map[string]sql.NullString)
+	result := make([]*models.Book, 0, len(books))
+	for i, book := range books {
+		added, err := insertBook(ctx, tx, book, series)
+		if err != nil {
+			if pqCode(err) == pqUniqueViolation {
+				return nil, fmt.Errorf("%s: %w", op, storage.ErrBookExists)
+			}
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if genreIDs != nil && genreIDs[i] != "" {
+			if _, err := tx.ExecContext(ctx, tagQuery, added.ID, genreIDs[i]); err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+		result = append(result, added)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	ids := make([]string, 0, len(result))
+	for _, book := range result {
+		ids = append(ids, book.ID)
+	}
+	s.markBooks(ids...)
+
+	return result, nil
+}
+
+// insertBook adds book within tx. series, when not nil, caches the ids of
+// the series already ensured in tx by name.
+func insertBook(ctx context.Context, tx *tracedTx, book *models.Book, series map[string]sql.NullString) (*models.Book, error) {
 	const query = `
-		INSERT INTO books (
+		INSERT INTO books AS b (
 			book_id, 
 			title, 
 			author, 
 			publication_year, 
-			genre
+			genre,
+			rating,
+			publisher,
+			language,
+			page_count,
+			edition,
+			description,
+			subjects,
+			series_id,
+			series_number,
+			isbn
 		)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING 
-			book_id as id, 
-			title, 
-			author, 
-			publication_year as publicationyear, 
-			genre
-	`
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING ` + bookColumns
 
 	if book.ID == "" {
 		book.ID = uuid.New().String()
 	}
 
-	var result models.Book
-	err := s.db.QueryRowxContext(ctx, query,
+	seriesID, ok := series[book.SeriesName]
+	if !ok {
+		var err error
+		if seriesID, err = ensureSeries(ctx, tx, book.SeriesName); err != nil {
+			return nil, err
+		}
+		if series != nil {
+			series[book.SeriesName] = seriesID
+		}
+	}
+
+	var result bookRow
+	err := tx.QueryRowxContext(ctx, query,
 		book.ID,
 		book.Title,
 		book.Author,
 		book.PublicationYear,
 		book.Genre,
+		book.Rating,
+		nullString(book.Publisher),
+		nullString(book.Language),
+		nullInt32(book.PageCount),
+		nullString(book.Edition),
+		nullString(book.Description),
+		subjects(book.Subjects),
+		seriesID,
+		nullInt32(book.SeriesNumber),
+		nullString(book.ISBN),
 	).StructScan(&result)
-
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, err
 	}
-
-	return &result, nil
+	return result.toModel(), nil
 }
-func (s *Storage) UpdateBook(ctx context.Context, book *models.Book) (*models.Book, error) {
+func (s *Storage) UpdateBook(ctx context.Context, id string, update func(book *models.Book) error) (*models.Book, error) {
 	const op = "postgres.UpdateBook"
+	const selectQuery = `SELECT ` + bookColumns + `
+		FROM books b
+		WHERE b.book_id = $1
+		FOR UPDATE OF b
+	`
 	const query = `
-		UPDATE books 
+		UPDATE books b
 		SET 
 			title = $1, 
 			author = $2, 
 			publication_year = $3, 
-			genre = $4
-		WHERE book_id = $5
-		RETURNING 
-			book_id as id, 
-			title, 
-			author, 
-			publication_year as publicationyear, 
-			genre
-	`
+			genre = $4,
+			rating = $5,
+			publisher = $6,
+			language = $7,
+			page_count = $8,
+			edition = $9,
+			description = $10,
+			subjects = $11,
+			series_id = $12,
+			series_number = $13,
+			isbn = $14
+		WHERE b.book_id = $15
+		RETURNING ` + bookColumns
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
 
-	var result models.Book
-	err := s.db.QueryRowxContext(ctx, query,
+	// The row stays locked until commit, so a concurrent update reads the
+	// book only once this one is written.
+	var current bookRow
+	if err := tx.GetContext(ctx, &current, selectQuery, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	book := current.toModel()
+	if err := update(book); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	seriesID, err := ensureSeries(ctx, tx, book.SeriesName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var row bookRow
+	err = tx.QueryRowxContext(ctx, query,
 		book.Title,
 		book.Author,
 		book.PublicationYear,
 		book.Genre,
-		book.ID,
-	).StructScan(&result)
+		book.Rating,
+		nullString(book.Publisher),
+		nullString(book.Language),
+		nullInt32(book.PageCount),
+		nullString(book.Edition),
+		nullString(book.Description),
+		subjects(book.Subjects),
+		seriesID,
+		nullInt32(book.SeriesNumber),
+		nullString(book.ISBN),
+		id,
+	).StructScan(&row)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
 		}
+		if pqCode(err) == pqUniqueViolation {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrBookExists)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	s.markBooks(row.ID)
+	result := row.toModel()
+	if err := s.attachGenres(ctx, result); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &result, nil
+	return result, nil
 }
 func (s *Storage) DeleteBook(ctx context.Context, id string) (string, error) {
 	const op = "postgres.DeleteBook"
@@ -230,28 +391,60 @@ func (s *Storage) DeleteBook(ctx context.Context, id string) (string, error) {
 		}
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
+	s.markBooks(deletedID)
 
 	return deletedID, nil
 }
 
-func (s *Storage) AddBookToUser(ctx context.Context, userID, bookID string) (string, error) {
+// AddBookToUser shelves the book for the user. Shelving a book twice is
+// not an error. When maxShelfSize is positive and the book would outgrow
+// the shelf, nothing is added and ErrShelfFull is returned.
+func (s *Storage) AddBookToUser(ctx context.Context, userID, bookID string, maxShelfSize int) (string, error) {
 	const op = "postgres.AddBookToUser"
+	// Locking the user row serialises concurrent shelf changes for the quota.
+	const lockQuery = `SELECT 1 FROM users WHERE user_id = $1 FOR UPDATE`
 	const query = `
 		INSERT INTO users_books (user_id, book_id)
 		VALUES ($1, $2)
 		ON CONFLICT (user_id, book_id) DO NOTHING
 		RETURNING book_id
 	`
+	const countQuery = `SELECT count(*) FROM users_books WHERE user_id = $1`
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
 
+	if maxShelfSize > 0 {
+		if _, err := tx.ExecContext(ctx, lockQuery, userID); err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+	}
 	var returnedBookID string
-	err := s.db.QueryRowContext(ctx, query, userID, bookID).Scan(&returnedBookID)
+	err = tx.QueryRowContext(ctx, query, userID, bookID).Scan(&returnedBookID)
 	if err != nil {
 		if err == sql.ErrNoRows {
+			// Already on the shelf: nothing changed, so the quota holds.
 			return bookID, nil
 		}
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
+	if maxShelfSize > 0 {
+		var count int
+		if err := tx.GetContext(ctx, &count, countQuery, userID); err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+		if count > maxShelfSize {
+			return "", fmt.Errorf("%s: %w", op, storage.ErrShelfFull)
+		}
+	}
 
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	s.writes.mark(userKey(userID))
 	return returnedBookID, nil
 }
 func (s *Storage) RemoveBookFromUser(ctx context.Context, userID, bookID string) (string, error) {
@@ -264,6 +457,7 @@ func (s *Storage) RemoveBookFromUser(ctx context.Context, userID, bookID string)
 
 	var returnedBookID string
 	err := s.db.QueryRowContext(ctx, query, userID, bookID).Scan(&returnedBookID)
+	s.writes.mark(userKey(userID))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -0,0 +1,118 @@
+package postres
+
+import (
+	"bookService/internal/domain/models"
+	"bookService/internal/storage"
+	"bookService/internal/storage/storagetest"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestBookStorage(t *testing.T) {
+	cfg := storagetest.Config(t)
+	s, err := New(cfg.DB, 0)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	storagetest.RunBookStorage(t, func(t *testing.T) storagetest.BookStorage {
+		ctx := context.Background()
+		if _, err := s.db.ExecContext(ctx, storagetest.TruncatePostgres); err != nil {
+			t.Fatalf("truncate: %v", err)
+		}
+		if _, err := s.db.ExecContext(ctx, storagetest.CreateUsersPostgres, pq.StringArray(storagetest.UserIDs)); err != nil {
+			t.Fatalf("create users: %v", err)
+		}
+		// Reads right after the reset must not go to a lagging replica.
+		s.writes.mark(catalogKey)
+		return s
+	})
+}
+
+func TestFindDuplicateCandidates(t *testing.T) {
+	cfg := storagetest.Config(t)
+	s, err := New(cfg.DB, 0)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := s.db.ExecContext(ctx, storagetest.TruncatePostgres); err != nil {
+		t.Fatalf("truncate: %v", err)
+	}
+
+	for _, book := range []*models.Book{
+		{Title: "Misérables et autres récits", Author: "Anonyme"},
+		{Title: "Les Misérables", Author: "Victor Hugo"},
+	} {
+		if _, err := s.AddBook(ctx, book); err != nil {
+			t.Fatalf("AddBook: %v", err)
+		}
+	}
+
+	// The words come folded; the stored titles are accented.
+	imported := &models.Book{Title: "Les Miserables", Author: "Victor Hugo"}
+	books, err := s.FindDuplicateCandidates(ctx, imported, []string{"miserables", "victor"})
+	if err != nil {
+		t.Fatalf("FindDuplicateCandidates: %v", err)
+	}
+	if len(books) != 2 {
+		t.Fatalf("FindDuplicateCandidates returned %d books, want 2", len(books))
+	}
+	// The closest match comes first even though it was added last.
+	if books[0].Author != "Victor Hugo" {
+		t.Errorf("first candidate = %q by %q, want the one by Victor Hugo", books[0].Title, books[0].Author)
+	}
+}
+
+func TestAddBooksTagsGenres(t *testing.T) {
+	cfg := storagetest.Config(t)
+	s, err := New(cfg.DB, 0)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := s.db.ExecContext(ctx, storagetest.TruncatePostgres); err != nil {
+		t.Fatalf("truncate: %v", err)
+	}
+	drama, err := s.ResolveGenre(ctx, "Drama")
+	if err != nil {
+		t.Fatalf("ResolveGenre: %v", err)
+	}
+
+	added, err := s.AddBooks(ctx, []*models.Book{
+		{Title: "Hamlet", Author: "William Shakespeare", Genre: drama.Name},
+		{Title: "Sonnets", Author: "William Shakespeare"},
+	}, []string{drama.ID, ""})
+	if err != nil {
+		t.Fatalf("AddBooks: %v", err)
+	}
+	for i, want := range [][]string{{drama.Name}, nil} {
+		book, err := s.GetBook(ctx, added[i].ID)
+		if err != nil {
+			t.Fatalf("GetBook: %v", err)
+		}
+		if !slices.Equal(book.Genres, want) {
+			t.Errorf("genres of %q = %v, want %v", book.Title, book.Genres, want)
+		}
+	}
+
+	// A rejected batch leaves no tags behind.
+	_, err = s.AddBooks(ctx, []*models.Book{
+		{Title: "Macbeth", Author: "William Shakespeare", Genre: drama.Name},
+		{Title: "Othello", Author: "William Shakespeare", ID: added[0].ID},
+	}, []string{drama.ID, drama.ID})
+	if !errors.Is(err, storage.ErrBookExists) {
+		t.Fatalf("AddBooks with a taken id = %v, want ErrBookExists", err)
+	}
+	var tags int
+	if err := s.db.GetContext(ctx, &tags, `SELECT count(*) FROM books_genres`); err != nil {
+		t.Fatalf("count tags: %v", err)
+	}
+	if tags != 1 {
+		t.Errorf("%d tags after a rejected batch, want 1", tags)
+	}
+}
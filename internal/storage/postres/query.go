@@ -0,0 +1,176 @@
+package postres
+
+import (
+	"bookService/internal/domain/models"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+// bookColumns is the column list every book query selects, aliased to the
+// field names of models.Book.
+const bookColumns = `
+	b.book_id as id,
+	b.title,
+	b.author,
+	b.publication_year as publicationyear,
+	b.genre,
+	b.rating,
+	b.created_at as createdat,
+	coalesce(b.cover_key, '') as coverkey,
+	coalesce(b.thumbnail_key, '') as thumbnailkey,
+	coalesce(b.publisher, '') as publisher,
+	coalesce(b.language, '') as language,
+	coalesce(b.page_count, 0) as pagecount,
+	coalesce(b.edition, '') as edition,
+	coalesce(b.description, '') as description,
+	b.subjects,
+	coalesce(b.series_id::text, '') as seriesid,
+	coalesce((SELECT sr.name FROM series sr WHERE sr.series_id = b.series_id), '') as seriesname,
+	coalesce(b.series_number, 0) as seriesnumber,
+	coalesce(b.isbn, '') as isbn
+`
+
+// bookRow scans bookColumns. Subjects needs a driver type that models.Book
+// does not carry; the outer field shadows the embedded one.
+type bookRow struct {
+	models.Book
+	Subjects pq.StringArray `db:"subjects"`
+}
+
+func (r *bookRow) toModel() *models.Book {
+	book := r.Book
+	book.Subjects = r.Subjects
+	return &book
+}
+
+func toBooks(rows []bookRow) []*models.Book {
+	books := make([]*models.Book, 0, len(rows))
+	for i := range rows {
+		books = append(books, rows[i].toModel())
+	}
+	return books
+}
+
+var sortColumns = map[string]string{
+	models.SortByTitle:           "b.title",
+	models.SortByAuthor:          "b.author",
+	models.SortByPublicationYear: "b.publication_year",
+	models.SortByCreatedAt:       "b.created_at",
+	models.SortByRating:          "b.rating",
+}
+
+// bookQuery accumulates WHERE conditions and their positional arguments for
+// queries over the books table aliased as b.
+type bookQuery struct {
+	args       []interface{}
+	conditions []string
+}
+
+// arg registers a query argument and returns its placeholder.
+func (q *bookQuery) arg(v interface{}) string {
+	q.args = append(q.args, v)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+func (q *bookQuery) where(condition string) {
+	q.conditions = append(q.conditions, condition)
+}
+
+func (q *bookQuery) applyFilter(filter *models.BookFilter) {
+	if filter == nil {
+		return
+	}
+
+	authors := filter.Authors
+	if filter.Author != nil {
+		authors = append([]string{*filter.Author}, authors...)
+	}
+	if len(authors) == 1 {
+		q.where("b.author = " + q.arg(authors[0]))
+	} else if len(authors) > 1 {
+		q.where("b.author = ANY(" + q.arg(pq.StringArray(authors)) + ")")
+	}
+
+	if filter.PublicationYear != nil {
+		q.where("b.publication_year = " + q.arg(*filter.PublicationYear))
+	}
+	if filter.YearFrom != nil {
+		q.where("b.publication_year >= " + q.arg(*filter.YearFrom))
+	}
+	if filter.YearTo != nil {
+		q.where("b.publication_year <= " + q.arg(*filter.YearTo))
+	}
+
+	genres := filter.Genres
+	if filter.Genre != nil {
+		genres = append([]string{*filter.Genre}, genres...)
+	}
+	if len(genres) > 0 {
+		lowered := make([]string, 0, len(genres))
+		for _, g := range genres {
+			lowered = append(lowered, strings.ToLower(g))
+		}
+		q.arg(pq.StringArray(lowered))
+		q.where(fmt.Sprintf(
+			"b.book_id IN (SELECT book_id FROM books_genres WHERE genre_id IN (%s))",
+			fmt.Sprintf(genreSubtreeSQL, len(q.args)),
+		))
+	}
+
+	if filter.Language != nil && *filter.Language != "" {
+		lang := strings.ToLower(*filter.Language)
+		q.where(fmt.Sprintf("(lower(b.language) = %s OR lower(b.language) LIKE %s)",
+			q.arg(lang), q.arg(escapeLike(lang)+"-%")))
+	}
+	if filter.Series != nil && *filter.Series != "" {
+		q.where("b.series_id IN (SELECT series_id FROM series WHERE lower(name) = lower(" + q.arg(*filter.Series) + "))")
+	}
+
+	if filter.TitlePrefix != nil && *filter.TitlePrefix != "" {
+		q.where("lower(b.title) LIKE " + q.arg(escapeLike(strings.ToLower(*filter.TitlePrefix))+"%"))
+	}
+}
+
+func (q *bookQuery) whereClause() string {
+	if len(q.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(q.conditions, " AND ")
+}
+
+func orderBy(filter *models.BookFilter) string {
+	column := sortColumns[models.SortByTitle]
+	direction := "ASC"
+	if filter != nil {
+		if c, ok := sortColumns[filter.SortBy]; ok {
+			column = c
+		}
+		if filter.SortDesc {
+			direction = "DESC"
+		}
+	}
+	// book_id keeps the order stable between equal sort keys.
+	return fmt.Sprintf(" ORDER BY %s %s NULLS LAST, b.book_id ASC", column, direction)
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// nullString stores empty optional text columns as NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullInt32(n int32) sql.NullInt32 {
+	return sql.NullInt32{Int32: n, Valid: n != 0}
+}
+
+func subjects(values []string) pq.StringArray {
+	if values == nil {
+		return pq.StringArray{}
+	}
+	return pq.StringArray(values)
+}
@@ -0,0 +1,216 @@
+package postres
+
+import (
+	"bookService/internal/domain/models"
+	"context"
+	"fmt"
+	"github.com/lib/pq"
+)
+
+// similaritiesLock is the advisory lock key that keeps replicas from
+// rebuilding book_similarities at the same time.
+const similaritiesLock = 0x626f6f6b73696d // "booksim"
+
+type scoredBookRow struct {
+	bookRow
+	Score float64 `db:"score"`
+}
+
+func toRecommendations(rows []scoredBookRow, reason string) []*models.Recommendation {
+	recs := make([]*models.Recommendation, 0, len(rows))
+	for i := range rows {
+		recs = append(recs, &models.Recommendation{
+			Book:   rows[i].toModel(),
+			Score:  rows[i].Score,
+			Reason: reason,
+		})
+	}
+	return recs
+}
+
+func (s *Storage) attachRecommendationGenres(ctx context.Context, recs []*models.Recommendation) error {
+	books := make([]*models.Book, 0, len(recs))
+	for _, r := range recs {
+		books = append(books, r.Book)
+	}
+	return s.attachGenres(ctx, books...)
+}
+
+// RefreshBookSimilarities rebuilds book_similarities from users_books. Two
+// books score the number of users who shelved both, divided by the geometric
+// mean of their reader counts, so that bestsellers do not crowd out every
+// list. Only pairs shelved together by at least minCoShelved users are kept,
+// and at most topN per book. It reports false, without doing anything, when
+// another replica is rebuilding already.
+func (s *Storage) RefreshBookSimilarities(ctx context.Context, topN, minCoShelved int) (bool, int64, error) {
+	const op = "postgres.RefreshBookSimilarities"
+	const lockQuery = `SELECT pg_try_advisory_xact_lock($1)`
+	const query = `
+		INSERT INTO book_similarities (book_id, similar_book_id, score, co_shelved)
+		WITH readers AS (
+			SELECT book_id, count(*) AS n
+			FROM users_books
+			GROUP BY book_id
+		), pairs AS (
+			SELECT a.book_id, b.book_id AS similar_book_id, count(*) AS co_shelved
+			FROM users_books a
+			JOIN users_books b ON b.user_id = a.user_id AND b.book_id <> a.book_id
+			GROUP BY a.book_id, b.book_id
+			HAVING count(*) >= $2
+		), scored AS (
+			SELECT p.book_id, p.similar_book_id, p.co_shelved,
+				p.co_shelved / sqrt(ra.n::float8 * rb.n) AS score
+			FROM pairs p
+			JOIN readers ra ON ra.book_id = p.book_id
+			JOIN readers rb ON rb.book_id = p.similar_book_id
+		), ranked AS (
+			SELECT *, row_number() OVER (PARTITION BY book_id ORDER BY score DESC, similar_book_id) AS rank
+			FROM scored
+		)
+		SELECT book_id, similar_book_id, score, co_shelved
+		FROM ranked
+		WHERE rank <= $1
+	`
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err := tx.GetContext(ctx, &locked, lockQuery, similaritiesLock); err != nil {
+		return false, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if !locked {
+		return false, 0, nil
+	}
+	// The rebuild scans every shelf and may outlast the per-query timeout.
+	if _, err := tx.ExecContext(ctx, `SET LOCAL statement_timeout = 0`); err != nil {
+		return false, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Readers keep seeing the previous scores until the commit.
+	if _, err := tx.ExecContext(ctx, `DELETE FROM book_similarities`); err != nil {
+		return false, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	res, err := tx.ExecContext(ctx, query, topN, minCoShelved)
+	if err != nil {
+		return false, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return false, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return true, count, nil
+}
+
+// SimilarBooks returns the precomputed neighbours of a book, best first.
+func (s *Storage) SimilarBooks(ctx context.Context, bookID string, limit int) ([]*models.Recommendation, error) {
+	const op = "postgres.SimilarBooks"
+	const query = `SELECT ` + bookColumns + `, s.score
+		FROM book_similarities s
+		JOIN books b ON b.book_id = s.similar_book_id
+		WHERE s.book_id = $1
+		ORDER BY s.score DESC, b.book_id ASC
+		LIMIT $2
+	`
+
+	var rows []scoredBookRow
+	if err := s.db.SelectContext(ctx, &rows, query, bookID, limit); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	recs := toRecommendations(rows, models.ReasonCoShelved)
+	if err := s.attachRecommendationGenres(ctx, recs); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return recs, nil
+}
+
+// UserRecommendations scores every book similar to one on the user's shelf
+// by its mean similarity over the whole shelf, leaving out shelved books.
+func (s *Storage) UserRecommendations(ctx context.Context, userID string, limit int) ([]*models.Recommendation, error) {
+	const op = "postgres.UserRecommendations"
+	const query = `SELECT ` + bookColumns + `, r.score
+		FROM (
+			SELECT s.similar_book_id,
+				sum(s.score) / (SELECT count(*) FROM users_books WHERE user_id = $1) AS score
+			FROM users_books ub
+			JOIN book_similarities s ON s.book_id = ub.book_id
+			WHERE ub.user_id = $1
+				AND NOT EXISTS (
+					SELECT 1 FROM users_books own
+					WHERE own.user_id = $1 AND own.book_id = s.similar_book_id
+				)
+			GROUP BY s.similar_book_id
+		) r
+		JOIN books b ON b.book_id = r.similar_book_id
+		ORDER BY r.score DESC, b.book_id ASC
+		LIMIT $2
+	`
+
+	var rows []scoredBookRow
+	if err := s.db.SelectContext(ctx, &rows, query, userID, limit); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	recs := toRecommendations(rows, models.ReasonCoShelved)
+	if err := s.attachRecommendationGenres(ctx, recs); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return recs, nil
+}
+
+// BooksLike returns books by one of the authors or in one of the genres,
+// compared ignoring case, with author matches first and then the most
+// shelved. With neither authors nor genres it returns the most shelved
+// books. Books listed in exclude are left out.
+func (s *Storage) BooksLike(ctx context.Context, authors, genres, exclude []string, limit int) ([]*models.Book, error) {
+	const op = "postgres.BooksLike"
+	const query = `SELECT ` + bookColumns + `
+		FROM books b
+		LEFT JOIN (
+			SELECT book_id, count(*) AS n
+			FROM users_books
+			GROUP BY book_id
+		) r ON r.book_id = b.book_id
+		WHERE NOT (b.book_id = ANY($3::uuid[]))
+			AND (
+				(cardinality($1::text[]) = 0 AND cardinality($2::text[]) = 0)
+				OR lower(b.author) = ANY($1::text[])
+				OR lower(b.genre) = ANY($2::text[])
+			)
+		ORDER BY lower(b.author) = ANY($1::text[]) DESC,
+			coalesce(r.n, 0) DESC,
+			b.rating DESC NULLS LAST,
+			b.book_id ASC
+		LIMIT $4
+	`
+
+	// A nil slice would be sent as NULL, which matches nothing.
+	array := func(values []string) pq.StringArray {
+		if values == nil {
+			return pq.StringArray{}
+		}
+		return values
+	}
+
+	var rows []bookRow
+	err := s.db.SelectContext(ctx, &rows, query,
+		array(authors),
+		array(genres),
+		array(exclude),
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	books := toBooks(rows)
+	if err := s.attachGenres(ctx, books...); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return books, nil
+}
@@ -0,0 +1,96 @@
+package postres
+
+import (
+	"bookService/internal/storage"
+	"context"
+	"sync"
+	"time"
+)
+
+// catalogKey stands for the book lists and counts in recentWrites: any
+// change to a book moves them to the primary. Single books are tracked one
+// by one under bookKey and users' shelves under userKey, so a write sends
+// only the reads of what it touched there.
+const catalogKey = "catalog"
+
+func bookKey(bookID string) string {
+	return "book:" + bookID
+}
+
+func userKey(userID string) string {
+	return "user:" + userID
+}
+
+// recentWrites remembers what was written during the last window, so that
+// reading it back can be sent to the primary rather than a lagging replica.
+//
+// It only knows the writes made through this Storage: a caller whose next
+// request lands on another replica of the service may still read stale
+// data until the database replicas catch up.
+type recentWrites struct {
+	window time.Duration
+
+	mu sync.Mutex
+	at map[string]time.Time
+}
+
+// pruneSize is the number of keys above which expired ones are dropped.
+const pruneSize = 1024
+
+func newRecentWrites(window time.Duration) *recentWrites {
+	return &recentWrites{window: window, at: make(map[string]time.Time)}
+}
+
+func (w *recentWrites) mark(keys ...string) {
+	if w.window <= 0 {
+		return
+	}
+	now := time.Now()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.at) > pruneSize {
+		for k, t := range w.at {
+			if now.Sub(t) > w.window {
+				delete(w.at, k)
+			}
+		}
+	}
+	for _, k := range keys {
+		w.at[k] = now
+	}
+}
+
+// markBooks records a change to the given books and so to the catalog.
+func (s *Storage) markBooks(bookIDs ...string) {
+	keys := make([]string, 0, len(bookIDs)+1)
+	keys = append(keys, catalogKey)
+	for _, id := range bookIDs {
+		keys = append(keys, bookKey(id))
+	}
+	s.writes.mark(keys...)
+}
+
+func (w *recentWrites) recent(key string) bool {
+	if w.window <= 0 {
+		return false
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	t, ok := w.at[key]
+	return ok && time.Since(t) <= w.window
+}
+
+// reader returns the database to read data under key from: the next replica
+// in turn, or the primary when there are no replicas, ctx asks for it, or
+// key was written too recently for the replicas to have caught up.
+func (s *Storage) reader(ctx context.Context, key string) tracedDB {
+	if len(s.replicas) == 0 || storage.ReadsFromPrimary(ctx) || s.writes.recent(key) {
+		return s.db
+	}
+	n := s.next.Add(1)
+	return s.replicas[n%uint64(len(s.replicas))]
+}
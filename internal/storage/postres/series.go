@@ -0,0 +1,192 @@
+package postres
+
+import (
+	"bookService/internal/domain/models"
+	"bookService/internal/storage"
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// ensureSeries returns the id of the series called name, creating it when
+// needed. An empty name yields NULL.
+func ensureSeries(ctx context.Context, q sqlx.QueryerContext, name string) (sql.NullString, error) {
+	const query = `
+		INSERT INTO series (name)
+		VALUES ($1)
+		ON CONFLICT ((lower(name))) DO UPDATE SET name = series.name
+		RETURNING series_id
+	`
+
+	if name == "" {
+		return sql.NullString{}, nil
+	}
+	var id string
+	if err := q.QueryRowxContext(ctx, query, name).Scan(&id); err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: id, Valid: true}, nil
+}
+
+type seriesRow struct {
+	ID          string `db:"series_id"`
+	Name        string `db:"name"`
+	Description string `db:"description"`
+	BookCount   int    `db:"book_count"`
+}
+
+func (r seriesRow) toModel() *models.Series {
+	return &models.Series{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		BookCount:   r.BookCount,
+	}
+}
+
+func (s *Storage) ListSeries(ctx context.Context) ([]*models.Series, error) {
+	const op = "postgres.ListSeries"
+	const query = `
+		SELECT s.series_id, s.name, coalesce(s.description, '') AS description, count(b.book_id) AS book_count
+		FROM series s
+		LEFT JOIN books b ON b.series_id = s.series_id
+		GROUP BY s.series_id
+		ORDER BY s.name ASC
+	`
+
+	var rows []seriesRow
+	if err := s.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	series := make([]*models.Series, 0, len(rows))
+	for _, row := range rows {
+		series = append(series, row.toModel())
+	}
+	return series, nil
+}
+
+// GetSeries returns the series with its books in reading order.
+func (s *Storage) GetSeries(ctx context.Context, id string) (*models.Series, error) {
+	const op = "postgres.GetSeries"
+	const seriesQuery = `
+		SELECT series_id, name, coalesce(description, '') AS description
+		FROM series
+		WHERE series_id = $1
+	`
+	const booksQuery = `SELECT ` + bookColumns + `
+		FROM books b
+		WHERE b.series_id = $1
+		ORDER BY b.series_number ASC NULLS LAST, b.title ASC, b.book_id ASC
+	`
+
+	var row seriesRow
+	if err := s.db.GetContext(ctx, &row, seriesQuery, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrSeriesNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var rows []bookRow
+	if err := s.db.SelectContext(ctx, &rows, booksQuery, id); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	books := toBooks(rows)
+	if err := s.attachGenres(ctx, books...); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	series := row.toModel()
+	series.Books = books
+	series.BookCount = len(books)
+	return series, nil
+}
+
+// CountSeriesBooks returns the number of books in each of the given series.
+func (s *Storage) CountSeriesBooks(ctx context.Context, seriesIDs []string) (map[string]int, error) {
+	const op = "postgres.CountSeriesBooks"
+	const query = `
+		SELECT series_id, count(*)
+		FROM books
+		WHERE series_id = ANY($1::uuid[])
+		GROUP BY series_id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, pq.StringArray(seriesIDs))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int, len(seriesIDs))
+	for rows.Next() {
+		var id string
+		var count int
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		counts[id] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return counts, nil
+}
+
+// AddSeriesToUser shelves every volume of the series in one transaction and
+// returns the ids of the books that were not on the shelf yet. When
+// maxShelfSize is positive and the shelf would outgrow it, nothing is added.
+func (s *Storage) AddSeriesToUser(ctx context.Context, userID, seriesID string, maxShelfSize int) ([]string, error) {
+	const op = "postgres.AddSeriesToUser"
+	const existsQuery = `SELECT EXISTS (SELECT 1 FROM series WHERE series_id = $1)`
+	// Locking the user row serialises concurrent shelf changes for the quota.
+	const lockQuery = `SELECT 1 FROM users WHERE user_id = $1 FOR UPDATE`
+	const insertQuery = `
+		INSERT INTO users_books (user_id, book_id)
+		SELECT $1, book_id FROM books WHERE series_id = $2
+		ON CONFLICT (user_id, book_id) DO NOTHING
+		RETURNING book_id
+	`
+	const countQuery = `SELECT count(*) FROM users_books WHERE user_id = $1`
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.GetContext(ctx, &exists, existsQuery, seriesID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrSeriesNotFound)
+	}
+	if _, err := tx.ExecContext(ctx, lockQuery, userID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var added []string
+	if err := tx.SelectContext(ctx, &added, insertQuery, userID, seriesID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if maxShelfSize > 0 && len(added) > 0 {
+		var count int
+		if err := tx.GetContext(ctx, &count, countQuery, userID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if count > maxShelfSize {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrShelfFull)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	s.writes.mark(userKey(userID))
+	return added, nil
+}
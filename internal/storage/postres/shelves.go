@@ -0,0 +1,410 @@
+package postres
+
+import (
+	"bookService/internal/domain/models"
+	"bookService/internal/storage"
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"time"
+)
+
+const shelfColumns = `
+	s.shelf_id,
+	s.user_id,
+	s.name,
+	s.visibility,
+	coalesce(s.share_token, '') AS share_token,
+	s.created_at,
+	s.updated_at,
+	(SELECT count(*) FROM shelf_books sb WHERE sb.shelf_id = s.shelf_id) AS book_count
+`
+
+type shelfRow struct {
+	ID         string    `db:"shelf_id"`
+	UserID     string    `db:"user_id"`
+	Name       string    `db:"name"`
+	Visibility string    `db:"visibility"`
+	ShareToken string    `db:"share_token"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+	BookCount  int       `db:"book_count"`
+}
+
+func (r shelfRow) toModel() *models.Shelf {
+	return &models.Shelf{
+		ID:         r.ID,
+		UserID:     r.UserID,
+		Name:       r.Name,
+		Visibility: r.Visibility,
+		ShareToken: r.ShareToken,
+		BookCount:  r.BookCount,
+		CreatedAt:  r.CreatedAt,
+		UpdatedAt:  r.UpdatedAt,
+	}
+}
+
+func getShelf(ctx context.Context, q sqlx.QueryerContext, id string) (*models.Shelf, error) {
+	const query = `SELECT ` + shelfColumns + ` FROM shelves s WHERE s.shelf_id = $1`
+
+	var row shelfRow
+	if err := sqlx.GetContext(ctx, q, &row, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, storage.ErrShelfNotFound
+		}
+		return nil, err
+	}
+	return row.toModel(), nil
+}
+
+// lockShelf locks the shelf row of the user for the rest of the transaction,
+// which serialises changes to its books.
+func lockShelf(ctx context.Context, tx *tracedTx, userID, shelfID string) error {
+	const query = `SELECT 1 FROM shelves WHERE shelf_id = $1 AND user_id = $2 FOR UPDATE`
+
+	var found int
+	if err := tx.GetContext(ctx, &found, query, shelfID, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return storage.ErrShelfNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func touchShelf(ctx context.Context, tx *tracedTx, shelfID string) error {
+	_, err := tx.ExecContext(ctx, `UPDATE shelves SET updated_at = now() WHERE shelf_id = $1`, shelfID)
+	return err
+}
+
+// CreateShelf stores a new shelf. When maxShelves is positive and the user
+// already has that many shelves, nothing is stored.
+func (s *Storage) CreateShelf(ctx context.Context, shelf *models.Shelf, maxShelves int) (*models.Shelf, error) {
+	const op = "postgres.CreateShelf"
+	// Locking the user row serialises concurrent shelf creation for the limit.
+	const lockQuery = `SELECT 1 FROM users WHERE user_id = $1 FOR UPDATE`
+	const countQuery = `SELECT count(*) FROM shelves WHERE user_id = $1`
+	const insertQuery = `
+		INSERT INTO shelves (user_id, name, visibility, share_token)
+		VALUES ($1, $2, $3, $4)
+		RETURNING shelf_id
+	`
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, lockQuery, shelf.UserID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if maxShelves > 0 {
+		var count int
+		if err := tx.GetContext(ctx, &count, countQuery, shelf.UserID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if count >= maxShelves {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrShelfLimit)
+		}
+	}
+
+	var id string
+	err = tx.QueryRowxContext(ctx, insertQuery,
+		shelf.UserID,
+		shelf.Name,
+		shelf.Visibility,
+		nullString(shelf.ShareToken),
+	).Scan(&id)
+	if err != nil {
+		if pqCode(err) == pqUniqueViolation {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrShelfExists)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	created, err := getShelf(ctx, tx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return created, nil
+}
+
+func (s *Storage) GetShelf(ctx context.Context, id string) (*models.Shelf, error) {
+	const op = "postgres.GetShelf"
+
+	shelf, err := getShelf(ctx, s.db, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return shelf, nil
+}
+
+func (s *Storage) ListShelves(ctx context.Context, userID string) ([]*models.Shelf, error) {
+	const op = "postgres.ListShelves"
+	const query = `SELECT ` + shelfColumns + `
+		FROM shelves s
+		WHERE s.user_id = $1
+		ORDER BY s.created_at ASC, s.shelf_id ASC
+	`
+
+	var rows []shelfRow
+	if err := s.db.SelectContext(ctx, &rows, query, userID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	shelves := make([]*models.Shelf, 0, len(rows))
+	for _, row := range rows {
+		shelves = append(shelves, row.toModel())
+	}
+	return shelves, nil
+}
+
+func (s *Storage) RenameShelf(ctx context.Context, userID, shelfID, name string) (*models.Shelf, error) {
+	const op = "postgres.RenameShelf"
+	const query = `
+		UPDATE shelves
+		SET name = $3, updated_at = now()
+		WHERE shelf_id = $1 AND user_id = $2
+	`
+
+	res, err := s.db.ExecContext(ctx, query, shelfID, userID, name)
+	if err != nil {
+		if pqCode(err) == pqUniqueViolation {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrShelfExists)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return s.updatedShelf(ctx, op, res, shelfID)
+}
+
+// SetShelfVisibility changes who can read the shelf. token is stored as the
+// share token; an empty one clears it.
+func (s *Storage) SetShelfVisibility(ctx context.Context, userID, shelfID, visibility, token string) (*models.Shelf, error) {
+	const op = "postgres.SetShelfVisibility"
+	const query = `
+		UPDATE shelves
+		SET visibility = $3, share_token = $4, updated_at = now()
+		WHERE shelf_id = $1 AND user_id = $2
+	`
+
+	res, err := s.db.ExecContext(ctx, query, shelfID, userID, visibility, nullString(token))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return s.updatedShelf(ctx, op, res, shelfID)
+}
+
+func (s *Storage) updatedShelf(ctx context.Context, op string, res sql.Result, shelfID string) (*models.Shelf, error) {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if n == 0 {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrShelfNotFound)
+	}
+	shelf, err := getShelf(ctx, s.db, shelfID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return shelf, nil
+}
+
+func (s *Storage) DeleteShelf(ctx context.Context, userID, shelfID string) (string, error) {
+	const op = "postgres.DeleteShelf"
+	const query = `
+		DELETE FROM shelves
+		WHERE shelf_id = $1 AND user_id = $2
+		RETURNING shelf_id
+	`
+
+	var id string
+	if err := s.db.QueryRowContext(ctx, query, shelfID, userID).Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("%s: %w", op, storage.ErrShelfNotFound)
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	return id, nil
+}
+
+// AddBookToShelf puts the book at the end of the shelf unless it is there
+// already. When maxShelfSize is positive and the shelf is full, nothing is
+// added.
+func (s *Storage) AddBookToShelf(ctx context.Context, userID, shelfID, bookID string, maxShelfSize int) (*models.Shelf, error) {
+	const op = "postgres.AddBookToShelf"
+	const insertQuery = `
+		INSERT INTO shelf_books (shelf_id, book_id, position)
+		SELECT $1, $2, coalesce(max(position), 0) + 1
+		FROM shelf_books
+		WHERE shelf_id = $1
+		ON CONFLICT (shelf_id, book_id) DO NOTHING
+	`
+	const countQuery = `SELECT count(*) FROM shelf_books WHERE shelf_id = $1`
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if err := lockShelf(ctx, tx, userID, shelfID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := tx.ExecContext(ctx, insertQuery, shelfID, bookID)
+	if err != nil {
+		if pqCode(err) == pqForeignKeyViolation {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if added, err := res.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	} else if added > 0 {
+		if maxShelfSize > 0 {
+			var count int
+			if err := tx.GetContext(ctx, &count, countQuery, shelfID); err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			if count > maxShelfSize {
+				return nil, fmt.Errorf("%s: %w", op, storage.ErrShelfFull)
+			}
+		}
+		if err := touchShelf(ctx, tx, shelfID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return s.commitShelf(ctx, op, tx, shelfID)
+}
+
+func (s *Storage) RemoveBookFromShelf(ctx context.Context, userID, shelfID, bookID string) (*models.Shelf, error) {
+	const op = "postgres.RemoveBookFromShelf"
+	const query = `DELETE FROM shelf_books WHERE shelf_id = $1 AND book_id = $2`
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if err := lockShelf(ctx, tx, userID, shelfID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	res, err := tx.ExecContext(ctx, query, shelfID, bookID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	} else if n == 0 {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrBookNotFound)
+	}
+	if err := touchShelf(ctx, tx, shelfID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.commitShelf(ctx, op, tx, shelfID)
+}
+
+// ReorderShelf puts the books of the shelf in the order of bookIDs, which
+// must list every book of the shelf exactly once.
+func (s *Storage) ReorderShelf(ctx context.Context, userID, shelfID string, bookIDs []string) (*models.Shelf, error) {
+	const op = "postgres.ReorderShelf"
+	const booksQuery = `SELECT book_id FROM shelf_books WHERE shelf_id = $1`
+	const updateQuery = `
+		UPDATE shelf_books sb
+		SET position = o.position
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS o (book_id, position)
+		WHERE sb.shelf_id = $1 AND sb.book_id = o.book_id
+	`
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if err := lockShelf(ctx, tx, userID, shelfID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var current []string
+	if err := tx.SelectContext(ctx, &current, booksQuery, shelfID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !samePermutation(current, bookIDs) {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrShelfMismatch)
+	}
+
+	if _, err := tx.ExecContext(ctx, updateQuery, shelfID, pq.StringArray(bookIDs)); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := touchShelf(ctx, tx, shelfID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.commitShelf(ctx, op, tx, shelfID)
+}
+
+func (s *Storage) commitShelf(ctx context.Context, op string, tx *tracedTx, shelfID string) (*models.Shelf, error) {
+	shelf, err := getShelf(ctx, tx, shelfID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return shelf, nil
+}
+
+// samePermutation reports whether ids lists every element of current once.
+func samePermutation(current, ids []string) bool {
+	if len(current) != len(ids) {
+		return false
+	}
+	remaining := make(map[string]bool, len(current))
+	for _, id := range current {
+		remaining[id] = true
+	}
+	for _, id := range ids {
+		if !remaining[id] {
+			return false
+		}
+		delete(remaining, id)
+	}
+	return true
+}
+
+// GetShelfBooks lists the books on the shelf that match filter, in shelf
+// order unless filter sets a sort field.
+func (s *Storage) GetShelfBooks(ctx context.Context, shelfID string, filter *models.BookFilter) ([]*models.Book, error) {
+	const op = "postgres.GetShelfBooks"
+
+	var q bookQuery
+	q.where("sb.shelf_id = " + q.arg(shelfID))
+	q.applyFilter(filter)
+	order := " ORDER BY sb.position ASC, b.book_id ASC"
+	if filter != nil && filter.SortBy != "" {
+		order = orderBy(filter)
+	}
+	query := `SELECT ` + bookColumns + `
+		FROM books b
+		JOIN shelf_books sb ON b.book_id = sb.book_id` + q.whereClause() + order
+
+	var rows []bookRow
+	if err := s.db.SelectContext(ctx, &rows, query, q.args...); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	books := toBooks(rows)
+	if err := s.attachGenres(ctx, books...); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return books, nil
+}
@@ -0,0 +1,174 @@
+package postres
+
+import (
+	"context"
+	"database/sql"
+	"runtime"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("bookService/internal/storage/postres")
+
+// tracedDB runs every query in a span of its own, named after the storage
+// method that issued it, and hands out transactions that do the same.
+type tracedDB struct {
+	*sqlx.DB
+}
+
+type tracedTx struct {
+	*sqlx.Tx
+}
+
+// startQuery starts the span of one query.
+func startQuery(ctx context.Context, query string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, queryName(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationName(operation(query)),
+			semconv.DBQueryText(query),
+		),
+	)
+}
+
+func endQuery(span trace.Span, err error) {
+	if err != nil && err != sql.ErrNoRows {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// queryName names a span after the first function of this package up the
+// stack outside this file, e.g. "postgres.GetBook".
+func queryName() string {
+	const pkg = "bookService/internal/storage/postres."
+
+	pcs := make([]uintptr, 8)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if name, ok := strings.CutPrefix(frame.Function, pkg); ok &&
+			!strings.HasPrefix(name, "tracedDB.") && !strings.HasPrefix(name, "tracedTx.") {
+			name = strings.TrimPrefix(name, "(*Storage).")
+			return "postgres." + name
+		}
+		if !more {
+			return "postgres.query"
+		}
+	}
+}
+
+// operation returns the leading keyword of a query, such as SELECT.
+func operation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
+
+func (db tracedDB) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, span := startQuery(ctx, query)
+	err := db.DB.GetContext(ctx, dest, query, args...)
+	endQuery(span, err)
+	return err
+}
+
+func (db tracedDB) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, span := startQuery(ctx, query)
+	err := db.DB.SelectContext(ctx, dest, query, args...)
+	endQuery(span, err)
+	return err
+}
+
+func (db tracedDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startQuery(ctx, query)
+	res, err := db.DB.ExecContext(ctx, query, args...)
+	endQuery(span, err)
+	return res, err
+}
+
+func (db tracedDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := startQuery(ctx, query)
+	rows, err := db.DB.QueryContext(ctx, query, args...)
+	endQuery(span, err)
+	return rows, err
+}
+
+func (db tracedDB) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	ctx, span := startQuery(ctx, query)
+	rows, err := db.DB.QueryxContext(ctx, query, args...)
+	endQuery(span, err)
+	return rows, err
+}
+
+func (db tracedDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span := startQuery(ctx, query)
+	row := db.DB.QueryRowContext(ctx, query, args...)
+	endQuery(span, row.Err())
+	return row
+}
+
+func (db tracedDB) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	ctx, span := startQuery(ctx, query)
+	row := db.DB.QueryRowxContext(ctx, query, args...)
+	endQuery(span, row.Err())
+	return row
+}
+
+func (db tracedDB) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*tracedTx, error) {
+	tx, err := db.DB.BeginTxx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedTx{Tx: tx}, nil
+}
+
+func (tx *tracedTx) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, span := startQuery(ctx, query)
+	err := tx.Tx.GetContext(ctx, dest, query, args...)
+	endQuery(span, err)
+	return err
+}
+
+func (tx *tracedTx) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, span := startQuery(ctx, query)
+	err := tx.Tx.SelectContext(ctx, dest, query, args...)
+	endQuery(span, err)
+	return err
+}
+
+func (tx *tracedTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startQuery(ctx, query)
+	res, err := tx.Tx.ExecContext(ctx, query, args...)
+	endQuery(span, err)
+	return res, err
+}
+
+func (tx *tracedTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := startQuery(ctx, query)
+	rows, err := tx.Tx.QueryContext(ctx, query, args...)
+	endQuery(span, err)
+	return rows, err
+}
+
+func (tx *tracedTx) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	ctx, span := startQuery(ctx, query)
+	rows, err := tx.Tx.QueryxContext(ctx, query, args...)
+	endQuery(span, err)
+	return rows, err
+}
+
+func (tx *tracedTx) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	ctx, span := startQuery(ctx, query)
+	row := tx.Tx.QueryRowxContext(ctx, query, args...)
+	endQuery(span, row.Err())
+	return row
+}
@@ -0,0 +1,263 @@
+package postres
+
+import (
+	"bookService/internal/domain/models"
+	"bookService/internal/storage"
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"time"
+)
+
+type webhookRow struct {
+	ID         string         `db:"subscription_id"`
+	URL        string         `db:"url"`
+	EventTypes pq.StringArray `db:"event_types"`
+	Secret     string         `db:"secret"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+func (r webhookRow) toModel() *models.WebhookSubscription {
+	return &models.WebhookSubscription{
+		ID:         r.ID,
+		URL:        r.URL,
+		EventTypes: r.EventTypes,
+		Secret:     r.Secret,
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+type deliveryRow struct {
+	ID             string       `db:"delivery_id"`
+	SubscriptionID string       `db:"subscription_id"`
+	EventType      string       `db:"event_type"`
+	Payload        []byte       `db:"payload"`
+	Status         string       `db:"status"`
+	Attempts       int32        `db:"attempts"`
+	LastError      string       `db:"last_error"`
+	NextAttemptAt  time.Time    `db:"next_attempt_at"`
+	CreatedAt      time.Time    `db:"created_at"`
+	DeliveredAt    sql.NullTime `db:"delivered_at"`
+}
+
+func (r deliveryRow) toModel() *models.WebhookDelivery {
+	d := &models.WebhookDelivery{
+		ID:             r.ID,
+		SubscriptionID: r.SubscriptionID,
+		EventType:      r.EventType,
+		Payload:        r.Payload,
+		Status:         r.Status,
+		Attempts:       r.Attempts,
+		LastError:      r.LastError,
+		NextAttemptAt:  r.NextAttemptAt,
+		CreatedAt:      r.CreatedAt,
+	}
+	if r.DeliveredAt.Valid {
+		d.DeliveredAt = &r.DeliveredAt.Time
+	}
+	return d
+}
+
+const deliveryColumns = `
+	delivery_id,
+	subscription_id,
+	event_type,
+	payload,
+	status,
+	attempts,
+	last_error,
+	next_attempt_at,
+	created_at,
+	delivered_at
+`
+
+func (s *Storage) CreateWebhook(ctx context.Context, sub *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	const op = "postgres.CreateWebhook"
+	const query = `
+		INSERT INTO webhook_subscriptions (subscription_id, url, event_types, secret)
+		VALUES ($1, $2, $3, $4)
+		RETURNING subscription_id, url, event_types, secret, created_at
+	`
+
+	if sub.ID == "" {
+		sub.ID = uuid.New().String()
+	}
+
+	var row webhookRow
+	err := s.db.QueryRowxContext(ctx, query,
+		sub.ID,
+		sub.URL,
+		pq.StringArray(sub.EventTypes),
+		sub.Secret,
+	).StructScan(&row)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return row.toModel(), nil
+}
+
+func (s *Storage) ListWebhooks(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	const op = "postgres.ListWebhooks"
+	const query = `
+		SELECT subscription_id, url, event_types, secret, created_at
+		FROM webhook_subscriptions
+		ORDER BY created_at ASC
+	`
+
+	var rows []webhookRow
+	if err := s.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	subs := make([]*models.WebhookSubscription, 0, len(rows))
+	for _, row := range rows {
+		subs = append(subs, row.toModel())
+	}
+	return subs, nil
+}
+
+func (s *Storage) DeleteWebhook(ctx context.Context, id string) (string, error) {
+	const op = "postgres.DeleteWebhook"
+	const query = `
+		DELETE FROM webhook_subscriptions
+		WHERE subscription_id = $1
+		RETURNING subscription_id
+	`
+
+	var deletedID string
+	err := s.db.QueryRowContext(ctx, query, id).Scan(&deletedID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("%s: %w", op, storage.ErrWebhookNotFound)
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deletedID, nil
+}
+
+// EnqueueWebhookDeliveries creates one pending delivery per subscription
+// listening for eventType.
+func (s *Storage) EnqueueWebhookDeliveries(ctx context.Context, eventType string, payload []byte) (int64, error) {
+	const op = "postgres.EnqueueWebhookDeliveries"
+	const query = `
+		INSERT INTO webhook_deliveries (delivery_id, subscription_id, event_type, payload)
+		SELECT gen_random_uuid(), subscription_id, $1, $2
+		FROM webhook_subscriptions
+		WHERE $1 = ANY(event_types)
+	`
+
+	res, err := s.db.ExecContext(ctx, query, eventType, payload)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return n, nil
+}
+
+// ClaimDueWebhookDeliveries locks up to limit pending deliveries whose next
+// attempt is due and pushes their next attempt forward by lease, so that
+// concurrent dispatchers on other replicas skip them.
+func (s *Storage) ClaimDueWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error) {
+	const op = "postgres.ClaimDueWebhookDeliveries"
+	const query = `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = now() + $2 * interval '1 millisecond'
+		WHERE delivery_id IN (
+			SELECT delivery_id
+			FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= now()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + deliveryColumns
+
+	var rows []deliveryRow
+	if err := s.db.SelectContext(ctx, &rows, query, limit, lease.Milliseconds()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	deliveries := make([]*models.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		deliveries = append(deliveries, row.toModel())
+	}
+	return deliveries, nil
+}
+
+func (s *Storage) GetWebhook(ctx context.Context, id string) (*models.WebhookSubscription, error) {
+	const op = "postgres.GetWebhook"
+	const query = `
+		SELECT subscription_id, url, event_types, secret, created_at
+		FROM webhook_subscriptions
+		WHERE subscription_id = $1
+	`
+
+	var row webhookRow
+	if err := s.db.GetContext(ctx, &row, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrWebhookNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return row.toModel(), nil
+}
+
+func (s *Storage) UpdateWebhookDelivery(ctx context.Context, d *models.WebhookDelivery) error {
+	const op = "postgres.UpdateWebhookDelivery"
+	const query = `
+		UPDATE webhook_deliveries
+		SET
+			status = $1,
+			attempts = $2,
+			last_error = $3,
+			next_attempt_at = $4,
+			delivered_at = $5
+		WHERE delivery_id = $6
+	`
+
+	_, err := s.db.ExecContext(ctx, query,
+		d.Status,
+		d.Attempts,
+		d.LastError,
+		d.NextAttemptAt,
+		d.DeliveredAt,
+		d.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (s *Storage) ListWebhookDeliveries(ctx context.Context, subscriptionID, status string, limit int) ([]*models.WebhookDelivery, error) {
+	const op = "postgres.ListWebhookDeliveries"
+	query := `SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries
+		WHERE subscription_id = $1
+	`
+	args := []interface{}{subscriptionID}
+	if status != "" {
+		args = append(args, status)
+		query += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d", len(args))
+
+	var rows []deliveryRow
+	if err := s.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	deliveries := make([]*models.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		deliveries = append(deliveries, row.toModel())
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,43 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// invalidationChannel carries the keys of books changed on any replica.
+const invalidationChannel = "cache:invalidate"
+
+// Publish tells every subscribed replica to drop key from its local cache.
+func (c *Cache) Publish(ctx context.Context, key string) error {
+	if err := c.client.Publish(ctx, invalidationChannel, key).Err(); err != nil {
+		return fmt.Errorf("redis publish error: %w", err)
+	}
+	return nil
+}
+
+// Subscribe calls onKey with every published key until ctx is done or the
+// connection fails. onReset is called once the subscription is in place:
+// keys published before then were missed, so nothing cached locally can be
+// trusted.
+func (c *Cache) Subscribe(ctx context.Context, onKey func(key string), onReset func()) error {
+	ps := c.client.Subscribe(ctx, invalidationChannel)
+	defer ps.Close()
+
+	for {
+		msg, err := ps.Receive(ctx)
+		if err != nil {
+			return fmt.Errorf("redis subscribe error: %w", err)
+		}
+		switch m := msg.(type) {
+		case *redis.Subscription:
+			if m.Kind == "subscribe" {
+				onReset()
+			}
+		case *redis.Message:
+			onKey(m.Payload)
+		}
+	}
+}
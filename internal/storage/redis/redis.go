@@ -3,47 +3,88 @@ package redis
 import (
 	"bookService/config"
 	"bookService/internal/domain/models"
+	"bookService/internal/storage"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/go-redis/redis/v8"
+	"math"
+	"math/rand/v2"
 	"time"
 )
 
 type Cache struct {
-	client *redis.Client
-	ttl    time.Duration
+	client       *redis.Client
+	ttl          time.Duration
+	jitter       float64
+	notFoundTTL  time.Duration
+	earlyRefresh time.Duration
 }
 
+// notFound is stored in place of a book that does not exist. It is not
+// valid JSON, so it cannot be mistaken for a book.
+const notFound = "-"
+
+// New connects to Redis and fails when it does not answer.
 func New(cfg config.RedisConfig) (*Cache, error) {
+	c := Open(cfg)
+	if err := c.Ping(context.Background()); err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Open returns a cache for cfg without checking that Redis is up; the
+// connection is made on first use.
+func Open(cfg config.RedisConfig) *Cache {
 	client := redis.NewClient(&redis.Options{
-		Addr:     cfg.Addr,
-		Password: cfg.Password,
-		DB:       cfg.DB,
+		Addr:         cfg.Addr,
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		DialTimeout:  cfg.Timeout,
+		ReadTimeout:  cfg.Timeout,
+		WriteTimeout: cfg.Timeout,
 	})
+	client.AddHook(tracingHook{})
 
-	if err := client.Ping(context.Background()).Err(); err != nil {
-		return nil, err
+	return &Cache{
+		client:       client,
+		ttl:          time.Duration(cfg.TTL) * time.Minute,
+		jitter:       cfg.TTLJitter,
+		notFoundTTL:  cfg.NotFoundTTL,
+		earlyRefresh: cfg.EarlyRefresh,
 	}
+}
 
-	return &Cache{
-		client: client,
-		ttl:    time.Duration(cfg.TTL) * time.Minute,
-	}, nil
+func (c *Cache) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
+// Client exposes the underlying connection for components that share it,
+// such as the rate limiter.
+func (c *Cache) Client() *redis.Client {
+	return c.client
 }
 
 func (c *Cache) Close() error {
 	return c.client.Close()
 }
 func (c *Cache) GetBook(ctx context.Context, key string) (*models.Book, error) {
-	data, err := c.client.Get(ctx, key).Bytes()
+	data, ttl, err := c.get(ctx, key)
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("redis get error: %w", err)
 	}
+	if string(data) == notFound {
+		return nil, storage.ErrBookNotFound
+	}
+	if c.refreshEarly(ttl) {
+		return nil, nil
+	}
 
 	var book models.Book
 	if err := json.Unmarshal(data, &book); err != nil {
@@ -53,15 +94,107 @@ func (c *Cache) GetBook(ctx context.Context, key string) (*models.Book, error) {
 	return &book, nil
 }
 
+// get reads key and, when early refresh is on, the time it has left.
+func (c *Cache) get(ctx context.Context, key string) ([]byte, time.Duration, error) {
+	if c.earlyRefresh <= 0 {
+		data, err := c.client.Get(ctx, key).Bytes()
+		return data, 0, err
+	}
+	pipe := c.client.Pipeline()
+	get := pipe.Get(ctx, key)
+	ttl := pipe.PTTL(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, 0, err
+	}
+	data, err := get.Bytes()
+	return data, ttl.Val(), err
+}
+
+// refreshEarly decides whether a book with ttl left is treated as a miss so
+// that it gets reloaded before it expires for everyone at once. The closer
+// the expiry, the likelier: with many lookups, one of them reloads it in
+// time while the others still hit.
+func (c *Cache) refreshEarly(ttl time.Duration) bool {
+	if c.earlyRefresh <= 0 || ttl <= 0 {
+		return false
+	}
+	return float64(ttl) < -float64(c.earlyRefresh)*math.Log(1-rand.Float64())
+}
+
 func (c *Cache) SetBook(ctx context.Context, key string, book *models.Book) error {
 	data, err := json.Marshal(book)
 	if err != nil {
 		return fmt.Errorf("marshal error: %w", err)
 	}
 
-	return c.client.Set(ctx, key, data, c.ttl).Err()
+	return c.client.Set(ctx, key, data, c.jittered(c.ttl)).Err()
+}
+
+// SetBookNotFound remembers for a short while that a book does not exist.
+func (c *Cache) SetBookNotFound(ctx context.Context, key string) error {
+	if c.notFoundTTL <= 0 {
+		return nil
+	}
+	return c.client.Set(ctx, key, notFound, c.jittered(c.notFoundTTL)).Err()
+}
+
+// jittered moves ttl by a random amount of up to c.jitter of it either way.
+func (c *Cache) jittered(ttl time.Duration) time.Duration {
+	if ttl <= 0 || c.jitter <= 0 {
+		return ttl
+	}
+	spread := float64(ttl) * min(c.jitter, 1)
+	// Redis takes a TTL of 0 as no expiry at all.
+	return max(ttl+time.Duration((rand.Float64()*2-1)*spread), time.Millisecond)
 }
 
 func (c *Cache) InvalidateBook(ctx context.Context, key string) error {
 	return c.client.Del(ctx, key).Err()
 }
+
+// facetsGenerationKey counts facet invalidations. Facet keys carry the
+// generation they were computed in, so an invalidation is one INCR and
+// stale counts, even those written back after it, are never read again.
+// Every key expires on its own.
+const facetsGenerationKey = "facets:generation"
+
+func (c *Cache) FacetsGeneration(ctx context.Context) (int64, error) {
+	gen, err := c.client.Get(ctx, facetsGenerationKey).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("redis get error: %w", err)
+	}
+	return gen, nil
+}
+
+func (c *Cache) GetFacets(ctx context.Context, key string) (*models.BookFacets, error) {
+	data, err := c.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("redis get error: %w", err)
+	}
+
+	var facets models.BookFacets
+	if err := json.Unmarshal(data, &facets); err != nil {
+		return nil, fmt.Errorf("unmarshal error: %w", err)
+	}
+
+	return &facets, nil
+}
+
+func (c *Cache) SetFacets(ctx context.Context, key string, facets *models.BookFacets) error {
+	data, err := json.Marshal(facets)
+	if err != nil {
+		return fmt.Errorf("marshal error: %w", err)
+	}
+
+	return c.client.Set(ctx, key, data, c.jittered(c.ttl)).Err()
+}
+
+func (c *Cache) InvalidateFacets(ctx context.Context) error {
+	return c.client.Incr(ctx, facetsGenerationKey).Err()
+}
@@ -0,0 +1,101 @@
+package redis
+
+import (
+	"bookService/internal/services/bookService"
+	"bookService/internal/storage/storagetest"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	cfg := storagetest.Config(t)
+	c, err := New(cfg.Cache)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+
+	storagetest.RunBookCache(t, func(t *testing.T) bookService.BookCache {
+		return c
+	})
+}
+
+func TestPubSub(t *testing.T) {
+	cfg := storagetest.Config(t)
+	c, err := New(cfg.Cache)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ready, keys := make(chan struct{}), make(chan string, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Subscribe(ctx, func(key string) { keys <- key }, func() { close(ready) })
+	}()
+
+	select {
+	case <-ready:
+	case err := <-done:
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if err := c.Publish(ctx, "book:1"); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	select {
+	case key := <-keys:
+		if key != "book:1" {
+			t.Errorf("got key %q, want book:1", key)
+		}
+	case <-ctx.Done():
+		t.Fatal("the published key never arrived")
+	}
+
+	cancel()
+	if err := <-done; err == nil {
+		t.Error("Subscribe returned no error after ctx was cancelled")
+	}
+}
+
+func TestJittered(t *testing.T) {
+	c := &Cache{jitter: 0.1}
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 100; i++ {
+		ttl := c.jittered(time.Hour)
+		if ttl < 54*time.Minute || ttl > 66*time.Minute {
+			t.Fatalf("jittered(1h) = %v, want within 10%%", ttl)
+		}
+		seen[ttl] = true
+	}
+	if len(seen) < 2 {
+		t.Error("jittered returned the same TTL every time")
+	}
+	if ttl := c.jittered(0); ttl != 0 {
+		t.Errorf("jittered(0) = %v, want no expiry kept", ttl)
+	}
+}
+
+func TestRefreshEarly(t *testing.T) {
+	c := &Cache{earlyRefresh: time.Second}
+	early := func(ttl time.Duration) int {
+		n := 0
+		for i := 0; i < 1000; i++ {
+			if c.refreshEarly(ttl) {
+				n++
+			}
+		}
+		return n
+	}
+	if n := early(time.Hour); n != 0 {
+		t.Errorf("%d of 1000 lookups refreshed a book an hour from expiry", n)
+	}
+	if n := early(time.Millisecond); n < 900 {
+		t.Errorf("only %d of 1000 lookups refreshed a book about to expire", n)
+	}
+	if n := early(-1); n != 0 {
+		t.Errorf("%d of 1000 lookups refreshed a book that never expires", n)
+	}
+}
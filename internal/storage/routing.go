@@ -0,0 +1,17 @@
+package storage
+
+import "context"
+
+type primaryKey struct{}
+
+// ReadFromPrimary marks ctx so that reads made with it skip the read
+// replicas, for reads that a following write depends on.
+func ReadFromPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// ReadsFromPrimary reports whether ctx was marked by ReadFromPrimary.
+func ReadsFromPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
@@ -3,5 +3,18 @@ package storage
 import "errors"
 
 var (
-	ErrBookNotFound = errors.New("book not found")
+	ErrBookNotFound     = errors.New("book not found")
+	ErrBookExists       = errors.New("book with this ISBN already exists")
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrGenreNotFound    = errors.New("genre not found")
+	ErrGenreExists      = errors.New("genre already exists")
+	ErrGenreHasChildren = errors.New("genre has child genres")
+	ErrGenreCycle       = errors.New("genre cannot be its own ancestor")
+	ErrSeriesNotFound   = errors.New("series not found")
+	ErrShelfFull        = errors.New("shelf is full")
+	ErrShelfNotFound    = errors.New("shelf not found")
+	ErrShelfLimit       = errors.New("too many shelves")
+	ErrShelfExists      = errors.New("shelf with this name already exists")
+	ErrShelfMismatch    = errors.New("books do not match the shelf")
+	ErrNotSupported     = errors.New("not supported by this storage")
 )
@@ -0,0 +1,649 @@
+// Package storagetest holds conformance suites that every implementation of
+// the book storage and cache interfaces has to pass, so that the in-memory
+// backends stay interchangeable with Postgres and Redis.
+package storagetest
+
+import (
+	"bookService/internal/domain/models"
+	"bookService/internal/services/bookService"
+	"bookService/internal/storage"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// BookStorage is what RunBookStorage tests.
+type BookStorage interface {
+	bookService.BookSaver
+	bookService.BookProvider
+}
+
+// UserIDs are the users the suite puts books on the shelves of.
+// Implementations that check users exist must create them for every
+// storage they hand to the suite.
+var UserIDs = []string{
+	"7b0c1f4e-2d5a-4c3b-9e8f-0a1b2c3d4e01",
+	"7b0c1f4e-2d5a-4c3b-9e8f-0a1b2c3d4e02",
+}
+
+// RunBookStorage runs the suite against storages made by newStorage, which
+// must return an empty one on every call.
+func RunBookStorage(t *testing.T, newStorage func(t *testing.T) BookStorage) {
+	t.Run("AddAndGet", func(t *testing.T) { testAddAndGet(t, newStorage(t)) })
+	t.Run("NotFound", func(t *testing.T) { testNotFound(t, newStorage(t)) })
+	t.Run("Conflicts", func(t *testing.T) { testConflicts(t, newStorage(t)) })
+	t.Run("AddBooks", func(t *testing.T) { testAddBooks(t, newStorage(t)) })
+	t.Run("Update", func(t *testing.T) { testUpdate(t, newStorage(t)) })
+	t.Run("ConcurrentUpdates", func(t *testing.T) { testConcurrentUpdates(t, newStorage(t)) })
+	t.Run("Delete", func(t *testing.T) { testDelete(t, newStorage(t)) })
+	t.Run("Series", func(t *testing.T) { testSeries(t, newStorage(t)) })
+	t.Run("Filters", func(t *testing.T) { testFilters(t, newStorage(t)) })
+	t.Run("Ordering", func(t *testing.T) { testOrdering(t, newStorage(t)) })
+	t.Run("UserBooks", func(t *testing.T) { testUserBooks(t, newStorage(t)) })
+	t.Run("ShelfQuota", func(t *testing.T) { testShelfQuota(t, newStorage(t)) })
+	t.Run("Facets", func(t *testing.T) { testFacets(t, newStorage(t)) })
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// replace returns an update to UpdateBook that overwrites the book with a
+// copy of book.
+func replace(book *models.Book) func(*models.Book) error {
+	return func(current *models.Book) error {
+		*current = *book
+		return nil
+	}
+}
+
+// catalog is the data the listing tests run over. Titles and authors start
+// with distinct letters, so that every collation orders them alike.
+func catalog() []*models.Book {
+	return []*models.Book{
+		{Title: "Alpha Centauri", Author: "Kim Stanley", PublicationYear: 1993, Genre: "Science Fiction",
+			Rating: ptr(4.5), Language: "en", SeriesName: "Mars", SeriesNumber: 1},
+		{Title: "Bravo Two Zero", Author: "Andy McNab", PublicationYear: 1993, Genre: "History",
+			Rating: ptr(3.25), Language: "en-GB"},
+		{Title: "Charlie and the Chocolate Factory", Author: "Roald Dahl", PublicationYear: 1964, Genre: "Children",
+			Language: "en"},
+		{Title: "Delta of Venus", Author: "Kim Stanley", PublicationYear: 2001, Genre: "Science Fiction",
+			Rating: ptr(4.75), Language: "fr", SeriesName: "Mars", SeriesNumber: 2},
+		{Title: "Echo Park", Author: "Michael Connelly", PublicationYear: 2006, Genre: "Crime",
+			Rating: ptr(3.25), Language: "en-US"},
+	}
+}
+
+func addCatalog(t *testing.T, s BookStorage) map[string]*models.Book {
+	t.Helper()
+
+	byTitle := make(map[string]*models.Book)
+	for _, book := range catalog() {
+		added, err := s.AddBook(context.Background(), book)
+		if err != nil {
+			t.Fatalf("AddBook(%q): %v", book.Title, err)
+		}
+		byTitle[added.Title] = added
+	}
+	return byTitle
+}
+
+func titles(books []*models.Book) []string {
+	result := make([]string, 0, len(books))
+	for _, b := range books {
+		result = append(result, b.Title)
+	}
+	return result
+}
+
+// assertBook compares the fields callers set; ids, series ids and times
+// are the storage's.
+func assertBook(t *testing.T, want, got *models.Book) {
+	t.Helper()
+
+	if got.Title != want.Title || got.Author != want.Author || got.PublicationYear != want.PublicationYear ||
+		got.Genre != want.Genre || got.Publisher != want.Publisher || got.Language != want.Language ||
+		got.PageCount != want.PageCount || got.Edition != want.Edition || got.Description != want.Description ||
+		got.ISBN != want.ISBN || got.SeriesName != want.SeriesName || got.SeriesNumber != want.SeriesNumber {
+		t.Errorf("book = %+v, want %+v", got, want)
+	}
+	if (got.Rating == nil) != (want.Rating == nil) || got.Rating != nil && *got.Rating != *want.Rating {
+		t.Errorf("rating = %v, want %v", got.Rating, want.Rating)
+	}
+	if !slices.Equal(got.Subjects, want.Subjects) && (len(got.Subjects) > 0 || len(want.Subjects) > 0) {
+		t.Errorf("subjects = %v, want %v", got.Subjects, want.Subjects)
+	}
+}
+
+func testAddAndGet(t *testing.T, s BookStorage) {
+	ctx := context.Background()
+	book := &models.Book{
+		Title:           "Foxtrot",
+		Author:          "Jane Doe",
+		PublicationYear: 2010,
+		Genre:           "Drama",
+		Rating:          ptr(2.5),
+		Publisher:       "Acme",
+		Language:        "pt-BR",
+		PageCount:       321,
+		Edition:         "2nd",
+		Description:     "A description.",
+		Subjects:        []string{"dance", "music"},
+		ISBN:            "9780306406157",
+		SeriesName:      "Dances",
+		SeriesNumber:    3,
+	}
+	want := *book
+
+	added, err := s.AddBook(ctx, book)
+	if err != nil {
+		t.Fatalf("AddBook: %v", err)
+	}
+	if _, err := uuid.Parse(added.ID); err != nil {
+		t.Errorf("id = %q, want a UUID", added.ID)
+	}
+	if added.SeriesID == "" {
+		t.Error("series id is empty")
+	}
+	if added.CreatedAt.IsZero() {
+		t.Error("created at is zero")
+	}
+	assertBook(t, &want, added)
+
+	got, err := s.GetBook(ctx, added.ID)
+	if err != nil {
+		t.Fatalf("GetBook: %v", err)
+	}
+	assertBook(t, &want, got)
+	if got.ID != added.ID || got.SeriesID != added.SeriesID {
+		t.Errorf("GetBook = %+v, want %+v", got, added)
+	}
+
+	// Changing a returned book must not change the stored one.
+	got.Title = "Changed"
+	again, err := s.GetBook(ctx, added.ID)
+	if err != nil {
+		t.Fatalf("GetBook: %v", err)
+	}
+	if again.Title != want.Title {
+		t.Errorf("title = %q after changing a copy, want %q", again.Title, want.Title)
+	}
+
+	// Callers may choose the id.
+	id := uuid.New().String()
+	chosen, err := s.AddBook(ctx, &models.Book{ID: id, Title: "Golf", Author: "Jane Doe"})
+	if err != nil {
+		t.Fatalf("AddBook: %v", err)
+	}
+	if chosen.ID != id {
+		t.Errorf("id = %q, want %q", chosen.ID, id)
+	}
+	if len(chosen.Subjects) != 0 || chosen.Rating != nil || chosen.SeriesID != "" {
+		t.Errorf("optional fields of %+v are set", chosen)
+	}
+}
+
+func testNotFound(t *testing.T, s BookStorage) {
+	ctx := context.Background()
+	missing := uuid.New().String()
+
+	if _, err := s.GetBook(ctx, missing); !errors.Is(err, storage.ErrBookNotFound) {
+		t.Errorf("GetBook = %v, want ErrBookNotFound", err)
+	}
+	if _, err := s.UpdateBook(ctx, missing, replace(&models.Book{ID: missing, Title: "T", Author: "A"})); !errors.Is(err, storage.ErrBookNotFound) {
+		t.Errorf("UpdateBook = %v, want ErrBookNotFound", err)
+	}
+	if _, err := s.DeleteBook(ctx, missing); !errors.Is(err, storage.ErrBookNotFound) {
+		t.Errorf("DeleteBook = %v, want ErrBookNotFound", err)
+	}
+	if _, err := s.RemoveBookFromUser(ctx, UserIDs[0], missing); !errors.Is(err, storage.ErrBookNotFound) {
+		t.Errorf("RemoveBookFromUser = %v, want ErrBookNotFound", err)
+	}
+	if _, err := s.AddBookToUser(ctx, UserIDs[0], missing, 0); err == nil {
+		t.Error("AddBookToUser of a missing book succeeded")
+	}
+}
+
+func testConflicts(t *testing.T, s BookStorage) {
+	ctx := context.Background()
+
+	first, err := s.AddBook(ctx, &models.Book{Title: "Hotel", Author: "A", ISBN: "9780306406157"})
+	if err != nil {
+		t.Fatalf("AddBook: %v", err)
+	}
+	if _, err := s.AddBook(ctx, &models.Book{Title: "India", Author: "B", ISBN: "9780306406157"}); !errors.Is(err, storage.ErrBookExists) {
+		t.Errorf("AddBook with a taken ISBN = %v, want ErrBookExists", err)
+	}
+	if _, err := s.AddBook(ctx, &models.Book{ID: first.ID, Title: "Juliett", Author: "C"}); !errors.Is(err, storage.ErrBookExists) {
+		t.Errorf("AddBook with a taken id = %v, want ErrBookExists", err)
+	}
+
+	// Books without an ISBN never conflict.
+	for _, title := range []string{"Kilo", "Lima"} {
+		if _, err := s.AddBook(ctx, &models.Book{Title: title, Author: "D"}); err != nil {
+			t.Errorf("AddBook(%q): %v", title, err)
+		}
+	}
+
+	second, err := s.AddBook(ctx, &models.Book{Title: "Mike", Author: "E", ISBN: "9781861972712"})
+	if err != nil {
+		t.Fatalf("AddBook: %v", err)
+	}
+	second.ISBN = first.ISBN
+	if _, err := s.UpdateBook(ctx, second.ID, replace(second)); !errors.Is(err, storage.ErrBookExists) {
+		t.Errorf("UpdateBook to a taken ISBN = %v, want ErrBookExists", err)
+	}
+	// Keeping its own ISBN is no conflict.
+	first.Title = "Hotel California"
+	if _, err := s.UpdateBook(ctx, first.ID, replace(first)); err != nil {
+		t.Errorf("UpdateBook: %v", err)
+	}
+}
+
+func testAddBooks(t *testing.T, s BookStorage) {
+	ctx := context.Background()
+
+	books := catalog()
+	added, err := s.AddBooks(ctx, books, nil)
+	if err != nil {
+		t.Fatalf("AddBooks: %v", err)
+	}
+	if len(added) != len(books) {
+		t.Fatalf("AddBooks returned %d books, want %d", len(added), len(books))
+	}
+	for i, book := range catalog() {
+		assertBook(t, book, added[i])
+		if got, err := s.GetBook(ctx, added[i].ID); err != nil || got.Title != book.Title {
+			t.Errorf("GetBook(%q) = %v, %v", added[i].ID, got, err)
+		}
+	}
+	if added[0].SeriesID == "" || added[0].SeriesID != added[3].SeriesID {
+		t.Errorf("series ids %q and %q differ", added[0].SeriesID, added[3].SeriesID)
+	}
+
+	// One conflict rejects the whole batch.
+	_, err = s.AddBooks(ctx, []*models.Book{
+		{Title: "November", Author: "F", ISBN: "9780470059029"},
+		{Title: "Oscar", Author: "G", ID: added[0].ID},
+	}, nil)
+	if !errors.Is(err, storage.ErrBookExists) {
+		t.Errorf("AddBooks with a taken id = %v, want ErrBookExists", err)
+	}
+	_, err = s.AddBooks(ctx, []*models.Book{
+		{Title: "Papa", Author: "H", ISBN: "9780596520687"},
+		{Title: "Quebec", Author: "I", ISBN: "9780596520687"},
+	}, nil)
+	if !errors.Is(err, storage.ErrBookExists) {
+		t.Errorf("AddBooks with the same ISBN twice = %v, want ErrBookExists", err)
+	}
+	all, err := s.ListBooks(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListBooks: %v", err)
+	}
+	if len(all) != len(books) {
+		t.Errorf("%d books after rejected batches, want %d: %v", len(all), len(books), titles(all))
+	}
+
+	if added, err := s.AddBooks(ctx, nil, nil); err != nil || len(added) != 0 {
+		t.Errorf("AddBooks(nil) = %v, %v", added, err)
+	}
+}
+
+func testUpdate(t *testing.T, s BookStorage) {
+	ctx := context.Background()
+
+	added, err := s.AddBook(ctx, &models.Book{Title: "Romeo", Author: "A", Rating: ptr(1.5), SeriesName: "Plays"})
+	if err != nil {
+		t.Fatalf("AddBook: %v", err)
+	}
+
+	update := *added
+	update.Title = "Romeo and Juliet"
+	update.Author = "William Shakespeare"
+	update.PublicationYear = 1597
+	update.Rating = nil
+	update.Language = "en-GB"
+	update.Subjects = []string{"tragedy"}
+	update.SeriesName = ""
+	update.SeriesNumber = 0
+	update.CreatedAt = added.CreatedAt.AddDate(-1, 0, 0)
+	want := update
+
+	updated, err := s.UpdateBook(ctx, added.ID, replace(&update))
+	if err != nil {
+		t.Fatalf("UpdateBook: %v", err)
+	}
+	assertBook(t, &want, updated)
+	if updated.ID != added.ID || updated.SeriesID != "" {
+		t.Errorf("UpdateBook = %+v", updated)
+	}
+	if !updated.CreatedAt.Equal(added.CreatedAt) {
+		t.Errorf("created at = %v, want %v", updated.CreatedAt, added.CreatedAt)
+	}
+
+	got, err := s.GetBook(ctx, added.ID)
+	if err != nil {
+		t.Fatalf("GetBook: %v", err)
+	}
+	assertBook(t, &want, got)
+}
+
+// testConcurrentUpdates changes two fields of a book from two goroutines at
+// once. Each update must see the one before it, so neither field is lost.
+func testConcurrentUpdates(t *testing.T, s BookStorage) {
+	ctx := context.Background()
+	const rounds = 20
+
+	added, err := s.AddBook(ctx, &models.Book{Title: "Sierra", Author: "A"})
+	if err != nil {
+		t.Fatalf("AddBook: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for _, set := range []func(*models.Book){
+		func(b *models.Book) { b.PageCount++ },
+		func(b *models.Book) { b.PublicationYear++ },
+	} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range rounds {
+				_, err := s.UpdateBook(ctx, added.ID, func(b *models.Book) error {
+					set(b)
+					return nil
+				})
+				if err != nil {
+					t.Errorf("UpdateBook: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := s.GetBook(ctx, added.ID)
+	if err != nil {
+		t.Fatalf("GetBook: %v", err)
+	}
+	if got.PageCount != rounds || got.PublicationYear != rounds {
+		t.Errorf("page count %d and publication year %d, want %d each", got.PageCount, got.PublicationYear, rounds)
+	}
+}
+
+func testDelete(t *testing.T, s BookStorage) {
+	ctx := context.Background()
+	books := addCatalog(t, s)
+	book := books["Echo Park"]
+
+	if _, err := s.AddBookToUser(ctx, UserIDs[0], book.ID, 0); err != nil {
+		t.Fatalf("AddBookToUser: %v", err)
+	}
+	id, err := s.DeleteBook(ctx, book.ID)
+	if err != nil || id != book.ID {
+		t.Fatalf("DeleteBook = %q, %v", id, err)
+	}
+	if _, err := s.GetBook(ctx, book.ID); !errors.Is(err, storage.ErrBookNotFound) {
+		t.Errorf("GetBook after DeleteBook = %v, want ErrBookNotFound", err)
+	}
+	if _, err := s.DeleteBook(ctx, book.ID); !errors.Is(err, storage.ErrBookNotFound) {
+		t.Errorf("DeleteBook twice = %v, want ErrBookNotFound", err)
+	}
+	if n, err := s.CountUserBooks(ctx, UserIDs[0]); err != nil || n != 0 {
+		t.Errorf("CountUserBooks after DeleteBook = %d, %v, want 0", n, err)
+	}
+	all, err := s.ListBooks(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListBooks: %v", err)
+	}
+	if len(all) != len(books)-1 {
+		t.Errorf("ListBooks = %v after deleting one of %d", titles(all), len(books))
+	}
+}
+
+func testSeries(t *testing.T, s BookStorage) {
+	ctx := context.Background()
+
+	first, err := s.AddBook(ctx, &models.Book{Title: "Sierra", Author: "A", SeriesName: "The Expanse", SeriesNumber: 1})
+	if err != nil {
+		t.Fatalf("AddBook: %v", err)
+	}
+	second, err := s.AddBook(ctx, &models.Book{Title: "Tango", Author: "A", SeriesName: "the expanse", SeriesNumber: 2})
+	if err != nil {
+		t.Fatalf("AddBook: %v", err)
+	}
+	if first.SeriesID == "" || second.SeriesID != first.SeriesID {
+		t.Errorf("series ids = %q and %q, want the same", first.SeriesID, second.SeriesID)
+	}
+	// A series keeps the name it was created with.
+	if second.SeriesName != "The Expanse" {
+		t.Errorf("series name = %q, want %q", second.SeriesName, "The Expanse")
+	}
+
+	books, err := s.ListBooks(ctx, &models.BookFilter{Series: ptr("THE EXPANSE")})
+	if err != nil {
+		t.Fatalf("ListBooks: %v", err)
+	}
+	if got := titles(books); !slices.Equal(got, []string{"Sierra", "Tango"}) {
+		t.Errorf("books of the series = %v", got)
+	}
+}
+
+func testFilters(t *testing.T, s BookStorage) {
+	ctx := context.Background()
+	addCatalog(t, s)
+
+	tests := []struct {
+		name   string
+		filter *models.BookFilter
+		want   []string
+	}{
+		{"none", nil, []string{"Alpha Centauri", "Bravo Two Zero", "Charlie and the Chocolate Factory", "Delta of Venus", "Echo Park"}},
+		{"author", &models.BookFilter{Author: ptr("Kim Stanley")}, []string{"Alpha Centauri", "Delta of Venus"}},
+		{"authors", &models.BookFilter{Author: ptr("Roald Dahl"), Authors: []string{"Andy McNab"}},
+			[]string{"Bravo Two Zero", "Charlie and the Chocolate Factory"}},
+		{"author is exact", &models.BookFilter{Author: ptr("kim stanley")}, nil},
+		{"year", &models.BookFilter{PublicationYear: ptr(int32(1993))}, []string{"Alpha Centauri", "Bravo Two Zero"}},
+		{"year range", &models.BookFilter{YearFrom: ptr(int32(1993)), YearTo: ptr(int32(2001))},
+			[]string{"Alpha Centauri", "Bravo Two Zero", "Delta of Venus"}},
+		{"language and subtags", &models.BookFilter{Language: ptr("EN")},
+			[]string{"Alpha Centauri", "Bravo Two Zero", "Charlie and the Chocolate Factory", "Echo Park"}},
+		{"language subtag", &models.BookFilter{Language: ptr("en-gb")}, []string{"Bravo Two Zero"}},
+		{"series", &models.BookFilter{Series: ptr("mars")}, []string{"Alpha Centauri", "Delta of Venus"}},
+		{"title prefix", &models.BookFilter{TitlePrefix: ptr("ch")}, []string{"Charlie and the Chocolate Factory"}},
+		{"title prefix is literal", &models.BookFilter{TitlePrefix: ptr("%")}, nil},
+		{"combined", &models.BookFilter{Author: ptr("Kim Stanley"), YearFrom: ptr(int32(2000))}, []string{"Delta of Venus"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			books, err := s.ListBooks(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("ListBooks: %v", err)
+			}
+			if got := titles(books); !slices.Equal(got, tt.want) && (len(got) > 0 || len(tt.want) > 0) {
+				t.Errorf("ListBooks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func testOrdering(t *testing.T, s BookStorage) {
+	ctx := context.Background()
+	books := addCatalog(t, s)
+
+	// Books with equal keys are ordered by id.
+	byID := func(a, b string) []string {
+		if books[a].ID > books[b].ID {
+			return []string{b, a}
+		}
+		return []string{a, b}
+	}
+	sameYear := byID("Alpha Centauri", "Bravo Two Zero")
+	sameRating := byID("Bravo Two Zero", "Echo Park")
+
+	tests := []struct {
+		name   string
+		filter *models.BookFilter
+		want   []string
+	}{
+		{"title desc", &models.BookFilter{SortDesc: true},
+			[]string{"Echo Park", "Delta of Venus", "Charlie and the Chocolate Factory", "Bravo Two Zero", "Alpha Centauri"}},
+		{"unknown column sorts by title", &models.BookFilter{SortBy: "pages"},
+			[]string{"Alpha Centauri", "Bravo Two Zero", "Charlie and the Chocolate Factory", "Delta of Venus", "Echo Park"}},
+		{"author", &models.BookFilter{SortBy: models.SortByAuthor, Author: ptr("Kim Stanley"), Authors: []string{"Andy McNab", "Michael Connelly"}},
+			append([]string{"Bravo Two Zero"}, append(byID("Alpha Centauri", "Delta of Venus"), "Echo Park")...)},
+		{"year", &models.BookFilter{SortBy: models.SortByPublicationYear},
+			append(append([]string{"Charlie and the Chocolate Factory"}, sameYear...), "Delta of Venus", "Echo Park")},
+		{"year desc", &models.BookFilter{SortBy: models.SortByPublicationYear, SortDesc: true},
+			append([]string{"Echo Park", "Delta of Venus"}, append(sameYear, "Charlie and the Chocolate Factory")...)},
+		{"rating puts unrated last", &models.BookFilter{SortBy: models.SortByRating},
+			append(sameRating, "Alpha Centauri", "Delta of Venus", "Charlie and the Chocolate Factory")},
+		{"rating desc puts unrated last", &models.BookFilter{SortBy: models.SortByRating, SortDesc: true},
+			append([]string{"Delta of Venus", "Alpha Centauri"}, append(sameRating, "Charlie and the Chocolate Factory")...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.ListBooks(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("ListBooks: %v", err)
+			}
+			if !slices.Equal(titles(got), tt.want) {
+				t.Errorf("ListBooks = %v, want %v", titles(got), tt.want)
+			}
+		})
+	}
+}
+
+func testUserBooks(t *testing.T, s BookStorage) {
+	ctx := context.Background()
+	books := addCatalog(t, s)
+	user, other := UserIDs[0], UserIDs[1]
+
+	for _, title := range []string{"Echo Park", "Alpha Centauri", "Delta of Venus"} {
+		id, err := s.AddBookToUser(ctx, user, books[title].ID, 3)
+		if err != nil || id != books[title].ID {
+			t.Fatalf("AddBookToUser(%q) = %q, %v", title, id, err)
+		}
+	}
+	// Adding a book twice is not an error and does not count twice, even
+	// on a full shelf.
+	if id, err := s.AddBookToUser(ctx, user, books["Echo Park"].ID, 3); err != nil || id != books["Echo Park"].ID {
+		t.Errorf("AddBookToUser twice = %q, %v", id, err)
+	}
+	if _, err := s.AddBookToUser(ctx, user, books["Bravo Two Zero"].ID, 3); !errors.Is(err, storage.ErrShelfFull) {
+		t.Errorf("AddBookToUser to a full shelf = %v, want ErrShelfFull", err)
+	}
+	if _, err := s.AddBookToUser(ctx, other, books["Bravo Two Zero"].ID, 3); err != nil {
+		t.Fatalf("AddBookToUser: %v", err)
+	}
+
+	if n, err := s.CountUserBooks(ctx, user); err != nil || n != 3 {
+		t.Errorf("CountUserBooks = %d, %v, want 3", n, err)
+	}
+	got, err := s.GetUserBooks(ctx, user, nil)
+	if err != nil {
+		t.Fatalf("GetUserBooks: %v", err)
+	}
+	if want := []string{"Alpha Centauri", "Delta of Venus", "Echo Park"}; !slices.Equal(titles(got), want) {
+		t.Errorf("GetUserBooks = %v, want %v", titles(got), want)
+	}
+	got, err = s.GetUserBooks(ctx, user, &models.BookFilter{Author: ptr("Kim Stanley"), SortDesc: true})
+	if err != nil {
+		t.Fatalf("GetUserBooks: %v", err)
+	}
+	if want := []string{"Delta of Venus", "Alpha Centauri"}; !slices.Equal(titles(got), want) {
+		t.Errorf("GetUserBooks with filter = %v, want %v", titles(got), want)
+	}
+
+	id, err := s.RemoveBookFromUser(ctx, user, books["Echo Park"].ID)
+	if err != nil || id != books["Echo Park"].ID {
+		t.Fatalf("RemoveBookFromUser = %q, %v", id, err)
+	}
+	if _, err := s.RemoveBookFromUser(ctx, user, books["Echo Park"].ID); !errors.Is(err, storage.ErrBookNotFound) {
+		t.Errorf("RemoveBookFromUser twice = %v, want ErrBookNotFound", err)
+	}
+	if _, err := s.RemoveBookFromUser(ctx, user, books["Bravo Two Zero"].ID); !errors.Is(err, storage.ErrBookNotFound) {
+		t.Errorf("RemoveBookFromUser of another user's book = %v, want ErrBookNotFound", err)
+	}
+	if n, err := s.CountUserBooks(ctx, user); err != nil || n != 2 {
+		t.Errorf("CountUserBooks = %d, %v, want 2", n, err)
+	}
+	if n, err := s.CountUserBooks(ctx, other); err != nil || n != 1 {
+		t.Errorf("CountUserBooks of the other user = %d, %v, want 1", n, err)
+	}
+}
+
+// testShelfQuota shelves every book at once: however the adds interleave,
+// no more than the quota may land.
+func testShelfQuota(t *testing.T, s BookStorage) {
+	ctx := context.Background()
+	books := addCatalog(t, s)
+	const quota = 2
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		full int
+	)
+	for _, book := range books {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.AddBookToUser(ctx, UserIDs[0], book.ID, quota)
+			switch {
+			case errors.Is(err, storage.ErrShelfFull):
+				mu.Lock()
+				full++
+				mu.Unlock()
+			case err != nil:
+				t.Errorf("AddBookToUser: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n, err := s.CountUserBooks(ctx, UserIDs[0]); err != nil || n != quota {
+		t.Errorf("CountUserBooks = %d, %v, want %d", n, err, quota)
+	}
+	if want := len(books) - quota; full != want {
+		t.Errorf("%d adds failed with ErrShelfFull, want %d", full, want)
+	}
+}
+
+func testFacets(t *testing.T, s BookStorage) {
+	ctx := context.Background()
+	addCatalog(t, s)
+
+	facets, err := s.GetBookFacets(ctx, nil, 2)
+	if err != nil {
+		t.Fatalf("GetBookFacets: %v", err)
+	}
+	// Andy McNab and Michael Connelly tie; the limit keeps the first by name.
+	assertCounts(t, "authors", facets.Authors, []models.FacetCount{{Value: "Kim Stanley", Count: 2}, {Value: "Andy McNab", Count: 1}})
+	assertCounts(t, "decades", facets.Decades, []models.FacetCount{
+		{Value: "1960", Count: 1}, {Value: "1990", Count: 2}, {Value: "2000", Count: 2},
+	})
+	// Genre counts come from tagging, which needs a genre taxonomy that not
+	// every storage has, so the books were added untagged.
+	assertCounts(t, "genres", facets.Genres, nil)
+
+	// Each facet ignores its own part of the filter.
+	facets, err = s.GetBookFacets(ctx, &models.BookFilter{Author: ptr("Kim Stanley"), YearFrom: ptr(int32(2000))}, 10)
+	if err != nil {
+		t.Fatalf("GetBookFacets: %v", err)
+	}
+	assertCounts(t, "filtered authors", facets.Authors, []models.FacetCount{
+		{Value: "Kim Stanley", Count: 1}, {Value: "Michael Connelly", Count: 1},
+	})
+	assertCounts(t, "filtered decades", facets.Decades, []models.FacetCount{{Value: "1990", Count: 1}, {Value: "2000", Count: 1}})
+}
+
+func assertCounts(t *testing.T, name string, got, want []models.FacetCount) {
+	t.Helper()
+	if !slices.Equal(got, want) && (len(got) > 0 || len(want) > 0) {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
@@ -0,0 +1,155 @@
+package storagetest
+
+import (
+	"bookService/internal/domain/models"
+	"bookService/internal/services/bookService"
+	"bookService/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// RunBookCache runs the suite against caches made by newCache, which must
+// remember missing books. The cache may be shared with other data: the
+// suite only uses keys of its own, but it does drop every cached facet.
+func RunBookCache(t *testing.T, newCache func(t *testing.T) bookService.BookCache) {
+	t.Run("Books", func(t *testing.T) { testCacheBooks(t, newCache(t)) })
+	t.Run("NotFound", func(t *testing.T) { testCacheNotFound(t, newCache(t)) })
+	t.Run("Facets", func(t *testing.T) { testCacheFacets(t, newCache(t)) })
+}
+
+func testCacheBooks(t *testing.T, c bookService.BookCache) {
+	ctx := context.Background()
+	key := "book:" + uuid.New().String()
+	t.Cleanup(func() { _ = c.InvalidateBook(context.Background(), key) })
+
+	// A miss is not an error.
+	if book, err := c.GetBook(ctx, key); book != nil || err != nil {
+		t.Fatalf("GetBook of a missing key = %v, %v, want nil, nil", book, err)
+	}
+
+	book := &models.Book{
+		ID:       uuid.New().String(),
+		Title:    "Uniform",
+		Author:   "A",
+		Rating:   ptr(3.5),
+		Genres:   []string{"Drama"},
+		Subjects: []string{"clothes"},
+	}
+	if err := c.SetBook(ctx, key, book); err != nil {
+		t.Fatalf("SetBook: %v", err)
+	}
+	// The cache holds a copy.
+	book.Title = "Changed"
+
+	got, err := c.GetBook(ctx, key)
+	if err != nil || got == nil {
+		t.Fatalf("GetBook = %v, %v", got, err)
+	}
+	want := *book
+	want.Title = "Uniform"
+	assertBook(t, &want, got)
+	if got.ID != book.ID || len(got.Genres) != 1 || got.Genres[0] != "Drama" {
+		t.Errorf("GetBook = %+v, want %+v", got, want)
+	}
+
+	if err := c.InvalidateBook(ctx, key); err != nil {
+		t.Fatalf("InvalidateBook: %v", err)
+	}
+	if got, err := c.GetBook(ctx, key); got != nil || err != nil {
+		t.Errorf("GetBook after InvalidateBook = %v, %v, want nil, nil", got, err)
+	}
+	// Invalidating a missing key is not an error.
+	if err := c.InvalidateBook(ctx, key); err != nil {
+		t.Errorf("InvalidateBook twice: %v", err)
+	}
+}
+
+func testCacheNotFound(t *testing.T, c bookService.BookCache) {
+	ctx := context.Background()
+	key := "book:" + uuid.New().String()
+	t.Cleanup(func() { _ = c.InvalidateBook(context.Background(), key) })
+
+	if err := c.SetBookNotFound(ctx, key); err != nil {
+		t.Fatalf("SetBookNotFound: %v", err)
+	}
+	if book, err := c.GetBook(ctx, key); book != nil || !errors.Is(err, storage.ErrBookNotFound) {
+		t.Fatalf("GetBook of a missing book = %v, %v, want %v", book, err, storage.ErrBookNotFound)
+	}
+
+	// Invalidation forgets that the book was missing.
+	if err := c.InvalidateBook(ctx, key); err != nil {
+		t.Fatalf("InvalidateBook: %v", err)
+	}
+	if book, err := c.GetBook(ctx, key); book != nil || err != nil {
+		t.Errorf("GetBook after InvalidateBook = %v, %v, want nil, nil", book, err)
+	}
+
+	// A book set later replaces the note.
+	if err := c.SetBookNotFound(ctx, key); err != nil {
+		t.Fatalf("SetBookNotFound: %v", err)
+	}
+	if err := c.SetBook(ctx, key, &models.Book{ID: uuid.New().String(), Title: "Found"}); err != nil {
+		t.Fatalf("SetBook: %v", err)
+	}
+	if book, err := c.GetBook(ctx, key); err != nil || book == nil || book.Title != "Found" {
+		t.Errorf("GetBook after SetBook = %v, %v, want the book", book, err)
+	}
+}
+
+func testCacheFacets(t *testing.T, c bookService.BookCache) {
+	ctx := context.Background()
+	t.Cleanup(func() { _ = c.InvalidateFacets(context.Background()) })
+
+	gen, err := c.FacetsGeneration(ctx)
+	if err != nil {
+		t.Fatalf("FacetsGeneration: %v", err)
+	}
+	first, second := uuid.New().String(), uuid.New().String()
+	key := func(gen int64, filter string) string { return fmt.Sprintf("facets:%d:%s", gen, filter) }
+
+	if facets, err := c.GetFacets(ctx, key(gen, first)); facets != nil || err != nil {
+		t.Fatalf("GetFacets of a missing key = %v, %v, want nil, nil", facets, err)
+	}
+
+	facets := &models.BookFacets{
+		Authors: []models.FacetCount{{Value: "Kim Stanley", Count: 2}},
+		Decades: []models.FacetCount{{Value: "1990", Count: 1}},
+	}
+	for _, filter := range []string{first, second} {
+		if err := c.SetFacets(ctx, key(gen, filter), facets); err != nil {
+			t.Fatalf("SetFacets: %v", err)
+		}
+	}
+	facets.Authors[0].Count = 5
+
+	got, err := c.GetFacets(ctx, key(gen, first))
+	if err != nil || got == nil {
+		t.Fatalf("GetFacets = %v, %v", got, err)
+	}
+	assertCounts(t, "authors", got.Authors, []models.FacetCount{{Value: "Kim Stanley", Count: 2}})
+	assertCounts(t, "decades", got.Decades, []models.FacetCount{{Value: "1990", Count: 1}})
+	assertCounts(t, "genres", got.Genres, nil)
+
+	// Invalidation moves every filter to a new generation at once.
+	if err := c.InvalidateFacets(ctx); err != nil {
+		t.Fatalf("InvalidateFacets: %v", err)
+	}
+	next, err := c.FacetsGeneration(ctx)
+	if err != nil || next == gen {
+		t.Fatalf("FacetsGeneration after InvalidateFacets = %d, %v, want other than %d", next, err, gen)
+	}
+	// Counts computed before the invalidation and written back after it
+	// stay out of sight.
+	if err := c.SetFacets(ctx, key(gen, first), facets); err != nil {
+		t.Fatalf("SetFacets: %v", err)
+	}
+	for _, filter := range []string{first, second} {
+		if got, err := c.GetFacets(ctx, key(next, filter)); got != nil || err != nil {
+			t.Errorf("GetFacets(%q) after InvalidateFacets = %v, %v, want nil, nil", filter, got, err)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package storagetest
+
+import (
+	"bookService/config"
+	"os"
+	"testing"
+
+	"github.com/ilyakaznacheev/cleanenv"
+)
+
+// Statements that prepare a Postgres database for RunBookStorage: the
+// first empties the tables the suite writes to, the second creates UserIDs,
+// passed as its text[] argument.
+const (
+	TruncatePostgres    = `TRUNCATE users, books, series CASCADE`
+	CreateUsersPostgres = `
+		INSERT INTO users (user_id, login, password_hash)
+		SELECT id::uuid, 'storagetest-' || id, '' FROM unnest($1::text[]) AS id
+	`
+)
+
+// Config loads the configuration named by TEST_CONFIG_PATH, for running
+// the suites against real Postgres and Redis, and skips t when it is not
+// set. The suites empty the books, series and users tables of its
+// database, so it must never point at one that matters.
+func Config(t *testing.T) *config.Config {
+	t.Helper()
+
+	path := os.Getenv("TEST_CONFIG_PATH")
+	if path == "" {
+		t.Skip("TEST_CONFIG_PATH is not set")
+	}
+	var cfg config.Config
+	if err := cleanenv.ReadConfig(path, &cfg); err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	return &cfg
+}
@@ -0,0 +1,56 @@
+package validation
+
+import (
+	gen "bookService/internal/delivery/protos/gen/go"
+	"bookService/internal/domain/models"
+	"strings"
+)
+
+// Error lists the violations of a value that did not come in as a request,
+// such as a record of a catalog import.
+type Error struct {
+	Violations []*Violation
+}
+
+func (e *Error) Error() string {
+	parts := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		parts = append(parts, v.Field+" "+v.Description)
+	}
+	return "invalid book: " + strings.Join(parts, "; ")
+}
+
+// ValidateBook checks book against the rules of AddBookRequest, so books
+// created outside the AddBook RPC meet the same constraints.
+func ValidateBook(book *models.Book) error {
+	req := &gen.AddBookRequest{
+		Title:    book.Title,
+		Author:   book.Author,
+		Rating:   book.Rating,
+		Subjects: book.Subjects,
+	}
+	setIf(&req.PublicationYear, book.PublicationYear)
+	setIf(&req.Genre, book.Genre)
+	setIf(&req.Publisher, book.Publisher)
+	setIf(&req.Language, book.Language)
+	setIf(&req.PageCount, book.PageCount)
+	setIf(&req.Edition, book.Edition)
+	setIf(&req.Description, book.Description)
+	setIf(&req.SeriesName, book.SeriesName)
+	setIf(&req.SeriesNumber, book.SeriesNumber)
+	setIf(&req.Isbn, book.ISBN)
+
+	if violations := Validate(req); len(violations) > 0 {
+		return &Error{Violations: violations}
+	}
+	return nil
+}
+
+// setIf sets an optional request field unless v is the zero value, which
+// models.Book uses for "not set".
+func setIf[T comparable](dst **T, v T) {
+	var zero T
+	if v != zero {
+		*dst = &v
+	}
+}
@@ -0,0 +1,171 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"bookService/internal/delivery/protos/gen/go/validate"
+	"bookService/internal/isbn"
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type Violation = errdetails.BadRequest_FieldViolation
+
+// Validate checks msg against the (validate.rules) options declared in the
+// proto files and returns one violation per failed rule.
+func Validate(msg proto.Message) []*Violation {
+	return validateMessage(msg.ProtoReflect(), "")
+}
+
+func validateMessage(m protoreflect.Message, prefix string) []*Violation {
+	var violations []*Violation
+
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		path := prefix + string(fd.Name())
+
+		rules := fieldRules(fd)
+		if rules != nil {
+			violations = append(violations, validateField(m, fd, rules, path)...)
+		}
+
+		// Descend into nested messages so their own rules apply as well.
+		if fd.Kind() != protoreflect.MessageKind || !m.Has(fd) || fd.IsMap() {
+			continue
+		}
+		if fd.IsList() {
+			list := m.Get(fd).List()
+			for j := 0; j < list.Len(); j++ {
+				violations = append(violations, validateMessage(list.Get(j).Message(), fmt.Sprintf("%s[%d].", path, j))...)
+			}
+			continue
+		}
+		violations = append(violations, validateMessage(m.Get(fd).Message(), path+".")...)
+	}
+
+	return violations
+}
+
+func fieldRules(fd protoreflect.FieldDescriptor) *validate.FieldRules {
+	opts := fd.Options()
+	if opts == nil || !proto.HasExtension(opts, validate.E_Rules) {
+		return nil
+	}
+	rules, _ := proto.GetExtension(opts, validate.E_Rules).(*validate.FieldRules)
+	return rules
+}
+
+func validateField(m protoreflect.Message, fd protoreflect.FieldDescriptor, rules *validate.FieldRules, path string) []*Violation {
+	if fd.IsList() {
+		list := m.Get(fd).List()
+		if rules.GetRequired() && list.Len() == 0 {
+			return []*Violation{violation(path, "is required")}
+		}
+		if rules.MaxItems != nil && uint32(list.Len()) > rules.GetMaxItems() {
+			return []*Violation{violation(path, fmt.Sprintf("must contain at most %d items", rules.GetMaxItems()))}
+		}
+		var violations []*Violation
+		for i := 0; i < list.Len(); i++ {
+			violations = append(violations, validateValue(fd, list.Get(i), rules, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return violations
+	}
+
+	if !isSet(m, fd) {
+		if rules.GetRequired() {
+			return []*Violation{violation(path, "is required")}
+		}
+		return nil
+	}
+
+	return validateValue(fd, m.Get(fd), rules, path)
+}
+
+// isSet reports whether the field carries a value: presence for optional and
+// message fields, non-zero for implicit-presence scalars.
+func isSet(m protoreflect.Message, fd protoreflect.FieldDescriptor) bool {
+	if fd.HasPresence() {
+		return m.Has(fd)
+	}
+	return m.Has(fd) && m.Get(fd).Interface() != fd.Default().Interface()
+}
+
+func validateValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, rules *validate.FieldRules, path string) []*Violation {
+	var violations []*Violation
+
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		s := v.String()
+		n := uint32(utf8.RuneCountInString(s))
+		if rules.MinLen != nil && n < rules.GetMinLen() {
+			violations = append(violations, violation(path, fmt.Sprintf("must be at least %d characters", rules.GetMinLen())))
+		}
+		if rules.MaxLen != nil && n > rules.GetMaxLen() {
+			violations = append(violations, violation(path, fmt.Sprintf("must be at most %d characters", rules.GetMaxLen())))
+		}
+		if rules.GetUuid() {
+			if _, err := uuid.Parse(s); err != nil || len(s) != 36 {
+				violations = append(violations, violation(path, "must be a valid UUID"))
+			}
+		}
+		if rules.GetHttpUrl() {
+			u, err := url.Parse(s)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				violations = append(violations, violation(path, "must be an absolute http or https URL"))
+			}
+		}
+		if rules.GetBcp47() {
+			if _, err := language.Parse(s); err != nil {
+				violations = append(violations, violation(path, "must be a valid BCP-47 language tag"))
+			}
+		}
+		if rules.GetIsbn() && !isbn.Valid(s) {
+			violations = append(violations, violation(path, "must be a valid ISBN"))
+		}
+		if len(rules.GetIn()) > 0 && !slices.Contains(rules.GetIn(), s) {
+			violations = append(violations, violation(path, fmt.Sprintf("must be one of %v", rules.GetIn())))
+		}
+
+	case protoreflect.Int32Kind, protoreflect.Int64Kind,
+		protoreflect.Sint32Kind, protoreflect.Sint64Kind,
+		protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		n := v.Int()
+		if rules.Gte != nil && n < rules.GetGte() {
+			violations = append(violations, violation(path, fmt.Sprintf("must be greater than or equal to %d", rules.GetGte())))
+		}
+		if rules.Lte != nil && n > rules.GetLte() {
+			violations = append(violations, violation(path, fmt.Sprintf("must be less than or equal to %d", rules.GetLte())))
+		}
+		if rules.GetNotFutureYear() && n > int64(time.Now().Year()) {
+			violations = append(violations, violation(path, "must not be in the future"))
+		}
+
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		f := v.Float()
+		if rules.Min != nil && f < rules.GetMin() {
+			violations = append(violations, violation(path, fmt.Sprintf("must be greater than or equal to %g", rules.GetMin())))
+		}
+		if rules.Max != nil && f > rules.GetMax() {
+			violations = append(violations, violation(path, fmt.Sprintf("must be less than or equal to %g", rules.GetMax())))
+		}
+
+	case protoreflect.EnumKind:
+		if rules.GetDefinedOnly() && fd.Enum().Values().ByNumber(v.Enum()) == nil {
+			violations = append(violations, violation(path, "must be a defined enum value"))
+		}
+	}
+
+	return violations
+}
+
+func violation(field, description string) *Violation {
+	return &Violation{Field: field, Description: description}
+}
@@ -0,0 +1,202 @@
+package webhooks
+
+import (
+	"bookService/config"
+	"bookService/internal/domain/models"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+type Storage interface {
+	EnqueueWebhookDeliveries(ctx context.Context, eventType string, payload []byte) (int64, error)
+	ClaimDueWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error)
+	GetWebhook(ctx context.Context, id string) (*models.WebhookSubscription, error)
+	UpdateWebhookDelivery(ctx context.Context, d *models.WebhookDelivery) error
+}
+
+type Dispatcher struct {
+	log     *slog.Logger
+	storage Storage
+	client  *http.Client
+	cfg     config.WebhookConfig
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(log *slog.Logger, storage Storage, cfg config.WebhookConfig) *Dispatcher {
+	return &Dispatcher{
+		log:     log,
+		storage: storage,
+		client:  &http.Client{Timeout: cfg.RequestTimeout},
+		cfg:     cfg,
+	}
+}
+
+// Publish records a delivery for every subscription listening for the event.
+// The actual HTTP calls are made asynchronously by the dispatch loop.
+func (d *Dispatcher) Publish(ctx context.Context, event models.BookEvent) error {
+	const op = "webhooks.Publish"
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := d.storage.EnqueueWebhookDeliveries(ctx, event.Type, payload); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (d *Dispatcher) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.run(ctx)
+	}()
+}
+
+func (d *Dispatcher) Stop() {
+	if d.cancel != nil {
+		d.cancel()
+	}
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) run(ctx context.Context) {
+	const op = "webhooks.run"
+	log := d.log.With(slog.String("op", op))
+
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// The lease keeps other replicas away while this batch is in flight.
+		lease := d.cfg.RequestTimeout*time.Duration(d.cfg.BatchSize) + d.cfg.PollInterval
+		deliveries, err := d.storage.ClaimDueWebhookDeliveries(ctx, d.cfg.BatchSize, lease)
+		if err != nil {
+			log.Error("failed to claim deliveries", slog.String("error", err.Error()))
+			continue
+		}
+		for _, delivery := range deliveries {
+			d.deliver(ctx, delivery)
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery *models.WebhookDelivery) {
+	const op = "webhooks.deliver"
+	log := d.log.With(
+		slog.String("op", op),
+		slog.String("delivery_id", delivery.ID),
+		slog.String("subscription_id", delivery.SubscriptionID),
+	)
+
+	sub, err := d.storage.GetWebhook(ctx, delivery.SubscriptionID)
+	if err != nil {
+		log.Error("failed to load subscription", slog.String("error", err.Error()))
+		return
+	}
+
+	delivery.Attempts++
+	sendErr := d.send(ctx, sub, delivery)
+	now := time.Now()
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = models.DeliveryStatusDelivered
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+		log.Debug("webhook delivered", slog.Int("attempts", int(delivery.Attempts)))
+	case delivery.Attempts >= d.cfg.MaxAttempts:
+		delivery.Status = models.DeliveryStatusDead
+		delivery.LastError = sendErr.Error()
+		log.Warn("webhook moved to dead letter", slog.String("error", sendErr.Error()))
+	default:
+		delivery.LastError = sendErr.Error()
+		delivery.NextAttemptAt = now.Add(Backoff(delivery.Attempts, d.cfg.InitialBackoff, d.cfg.MaxBackoff))
+		log.Info("webhook delivery failed, will retry",
+			slog.String("error", sendErr.Error()),
+			slog.Time("next_attempt_at", delivery.NextAttemptAt),
+		)
+	}
+
+	if err := d.storage.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		log.Error("failed to update delivery", slog.String("error", err.Error()))
+	}
+}
+
+func (d *Dispatcher) send(ctx context.Context, sub *models.WebhookSubscription, delivery *models.WebhookDelivery) error {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(sub.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the hex HMAC-SHA256 of "timestamp.payload". Receivers should
+// recompute it and reject requests with stale timestamps to prevent replays.
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns initial * 2^(attempt-1), capped at max.
+func Backoff(attempt int32, initial, max time.Duration) time.Duration {
+	delay := initial
+	for i := int32(1); i < attempt; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return delay
+}